@@ -0,0 +1,111 @@
+// Command definednet-mock serves a fake Defined.net HTTP API for running Terraform against.
+//
+// The provider is pointed at the fake API with the provider's endpoint attribute or the DEFINEDNET_ENDPOINT
+// environment variable, e.g.:
+//
+//	definednet-mock -port 8080 -fixture fixture.yaml -state state.json &
+//	DEFINEDNET_ENDPOINT=http://127.0.0.1:8080/ terraform apply
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+
+	fakeserver "github.com/sendsmaily/terraform-provider-definednet/internal/testing/server"
+)
+
+func main() {
+	var (
+		addr    string
+		port    int
+		fixture string
+		state   string
+		token   string
+		faults  string
+	)
+
+	flag.StringVar(&addr, "addr", "127.0.0.1", "address to listen on")
+	flag.IntVar(&port, "port", 8080, "port to listen on")
+	flag.StringVar(&fixture, "fixture", "", "YAML or JSON fixture file to seed the API with")
+	flag.StringVar(&state, "state", "", "JSON file to persist the API's data to, seeded from the fixture when missing")
+	flag.StringVar(&token, "token", "", "HTTP API token requests must be authorized with, any token is accepted when empty")
+	flag.StringVar(&faults, "faults", "", "YAML or JSON file listing faults to inject into the API's responses")
+	flag.Parse()
+
+	logger := log.New(os.Stderr, "", log.LstdFlags)
+	srv := fakeserver.NewHandler(logger)
+	srv.Token = token
+
+	seed, err := loadSeed(fixture, state)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	if seed != nil {
+		if err := srv.Seed(seed); err != nil {
+			logger.Fatal(err)
+		}
+	}
+
+	if faults != "" {
+		plan, err := fakeserver.LoadFaults(faults)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		srv.Faults.Inject(plan...)
+	}
+
+	var handler http.Handler = srv
+	if state != "" {
+		handler = persist(srv, state, logger)
+	}
+
+	listen := fmt.Sprintf("%s:%d", addr, port)
+	logger.Printf("serving fake Defined.net HTTP API on http://%s/", listen)
+
+	if err := http.ListenAndServe(listen, handler); err != nil {
+		logger.Fatal(err)
+	}
+}
+
+// loadSeed loads the data the API is seeded with, preferring the persisted state over the fixture.
+func loadSeed(fixture, state string) (*fakeserver.Fixture, error) {
+	if state != "" {
+		seed, err := fakeserver.LoadFixture(state)
+		if err == nil || !errors.Is(err, os.ErrNotExist) {
+			return seed, err
+		}
+	}
+
+	if fixture != "" {
+		return fakeserver.LoadFixture(fixture)
+	}
+
+	return nil, nil
+}
+
+// persist saves the API's data to the state file after every modifying request.
+func persist(srv *fakeserver.Handler, state string, logger *log.Logger) http.Handler {
+	var mu sync.Mutex
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv.ServeHTTP(w, r)
+
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if err := fakeserver.SaveFixture(state, srv.Snapshot()); err != nil {
+			logger.Printf("error persisting state: %s", err)
+		}
+	})
+}
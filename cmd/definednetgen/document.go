@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet/openapi"
+	"go.yaml.in/yaml/v3"
+)
+
+// load decodes the OpenAPI document with the provider's overlay applied.
+//
+// The overlay retains the order of the document's keys, which declares the order of generated fields.
+func load() (*document, error) {
+	data, err := openapi.Document()
+	if err != nil {
+		return nil, fmt.Errorf("error applying overlay: %w", err)
+	}
+
+	var doc document
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	return &doc, nil
+}
+
+// document is the subset of an OpenAPI document the generator supports.
+type document struct {
+	Paths      orderedMap[*pathItem] `yaml:"paths"`
+	Components struct {
+		Schemas    orderedMap[*schema]   `yaml:"schemas"`
+		Parameters map[string]*parameter `yaml:"parameters"`
+		Responses  map[string]*response  `yaml:"responses"`
+	} `yaml:"components"`
+}
+
+type pathItem struct {
+	Parameters []*parameter `yaml:"parameters"`
+	Get        *operation   `yaml:"get"`
+	Post       *operation   `yaml:"post"`
+	Put        *operation   `yaml:"put"`
+	Delete     *operation   `yaml:"delete"`
+}
+
+// operation returns the path's operation of the HTTP method, if any.
+func (p *pathItem) operation(method string) *operation {
+	switch method {
+	case "get":
+		return p.Get
+	case "post":
+		return p.Post
+	case "put":
+		return p.Put
+	case "delete":
+		return p.Delete
+	default:
+		return nil
+	}
+}
+
+// operations returns the path's operations.
+func (p *pathItem) operations() []*operation {
+	var ops []*operation
+	for _, method := range methods {
+		if op := p.operation(method); op != nil {
+			ops = append(ops, op)
+		}
+	}
+
+	return ops
+}
+
+type operation struct {
+	OperationID string               `yaml:"operationId"`
+	GoDoc       string               `yaml:"x-go-doc"`
+	Parameters  []*parameter         `yaml:"parameters"`
+	RequestBody *requestBody         `yaml:"requestBody"`
+	Responses   map[string]*response `yaml:"responses"`
+}
+
+type parameter struct {
+	Ref  string `yaml:"$ref"`
+	Name string `yaml:"name"`
+	In   string `yaml:"in"`
+}
+
+type requestBody struct {
+	Content map[string]mediaType `yaml:"content"`
+}
+
+type response struct {
+	Ref     string               `yaml:"$ref"`
+	Content map[string]mediaType `yaml:"content"`
+}
+
+type mediaType struct {
+	Schema *schema `yaml:"schema"`
+}
+
+type schema struct {
+	Ref        string              `yaml:"$ref"`
+	Type       string              `yaml:"type"`
+	Format     string              `yaml:"format"`
+	Required   []string            `yaml:"required"`
+	Properties orderedMap[*schema] `yaml:"properties"`
+	Items      *schema             `yaml:"items"`
+	GoDoc      string              `yaml:"x-go-doc"`
+	GoName     string              `yaml:"x-go-name"`
+	OmitEmpty  bool                `yaml:"x-omitempty"`
+}
+
+// orderedMap is a YAML mapping, which retains the order of its keys.
+//
+// The order of schema properties declares the order of generated fields.
+type orderedMap[V any] struct {
+	keys   []string
+	values map[string]V
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (m *orderedMap[V]) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: expected a mapping", node.Line)
+	}
+
+	m.values = make(map[string]V, len(node.Content)/2)
+	for idx := 0; idx < len(node.Content); idx += 2 {
+		var v V
+		if err := node.Content[idx+1].Decode(&v); err != nil {
+			return err
+		}
+
+		key := node.Content[idx].Value
+		m.keys = append(m.keys, key)
+		m.values[key] = v
+	}
+
+	return nil
+}
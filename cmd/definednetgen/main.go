@@ -0,0 +1,427 @@
+// Command definednetgen generates the Defined.net HTTP API client's models and endpoint functions from the API's
+// OpenAPI document in package openapi, with the provider's overlay applied. The overlay declares the generated names
+// and doc comments.
+//
+// Component schemas are generated as models, except the schemas of request bodies, which are merged into the
+// operations' request models with the operations' path parameters. Operations are generated as functions in the
+// client's call style:
+//
+//	func GetHost(ctx context.Context, client Client, req GetHostRequest) (*Host, error)
+//
+// Operations responding with a page of a schema's objects retrieve all pages, operations responding with a schema's
+// object in the data field return the object, and the rest only return an error.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+func main() {
+	var out, pkg string
+
+	flag.StringVar(&out, "out", "", "Go file to generate")
+	flag.StringVar(&pkg, "package", "definednet", "package name of the generated file")
+	flag.Parse()
+
+	doc, err := load()
+	if err != nil {
+		log.Fatalf("error decoding OpenAPI document: %s", err)
+	}
+
+	src, err := generate(doc, pkg)
+	if err != nil {
+		log.Fatalf("error generating from OpenAPI document: %s", err)
+	}
+
+	if err := os.WriteFile(out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// methods declares the generated HTTP methods, in the order of generated functions sharing a path.
+var methods = []string{"get", "post", "put", "delete"}
+
+// generate generates the Go source of the document's models and endpoint functions.
+func generate(doc *document, pkg string) ([]byte, error) {
+	g := &generator{doc: doc}
+
+	// Request body schemas are merged into the operations' request models.
+	bodies := map[string]bool{}
+	for _, path := range doc.Paths.keys {
+		for _, op := range doc.Paths.values[path].operations() {
+			if op.RequestBody != nil {
+				bodies[refName(op.RequestBody.Content["application/json"].Schema.Ref)] = true
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(doc.Components.Schemas) {
+		if bodies[name] {
+			continue
+		}
+
+		if err := g.model(name, doc.Components.Schemas.values[name]); err != nil {
+			return nil, err
+		}
+	}
+
+	var ops []endpoint
+	for _, path := range doc.Paths.keys {
+		item := doc.Paths.values[path]
+		for _, method := range methods {
+			if op := item.operation(method); op != nil {
+				ops = append(ops, endpoint{path: path, method: method, item: item, op: op})
+			}
+		}
+	}
+
+	slices.SortFunc(ops, func(a, b endpoint) int {
+		return strings.Compare(a.op.OperationID, b.op.OperationID)
+	})
+
+	for _, ep := range ops {
+		if err := g.endpoint(ep); err != nil {
+			return nil, fmt.Errorf("operation %s: %w", ep.op.OperationID, err)
+		}
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by definednetgen from the Defined.net OpenAPI document. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "package %s\n\n", pkg)
+	fmt.Fprintf(&src, "import (\n\"context\"\n\"net/http\"\n")
+	if g.usesTime {
+		fmt.Fprintf(&src, "\"time\"\n")
+	}
+	fmt.Fprintf(&src, ")\n")
+	src.Write(g.buf.Bytes())
+
+	return format.Source(src.Bytes())
+}
+
+type generator struct {
+	doc      *document
+	buf      bytes.Buffer
+	usesTime bool
+}
+
+type endpoint struct {
+	path   string
+	method string
+	item   *pathItem
+	op     *operation
+}
+
+// model generates a model of the schema.
+func (g *generator) model(name string, s *schema) error {
+	name = coalesce(s.GoName, name)
+
+	if s.Type != "object" {
+		return fmt.Errorf("schema %s: only object schemas are supported", name)
+	}
+
+	fmt.Fprintf(&g.buf, "\n// %s %s\ntype %s struct {\n", name, s.GoDoc, name)
+	if err := g.fields(s); err != nil {
+		return fmt.Errorf("schema %s: %w", name, err)
+	}
+	fmt.Fprintf(&g.buf, "}\n")
+
+	return nil
+}
+
+// fields generates the fields of the object schema.
+func (g *generator) fields(s *schema) error {
+	for _, prop := range s.Properties.keys {
+		field := s.Properties.values[prop]
+		required := slices.Contains(s.Required, prop)
+
+		typ, pointer, err := g.goType(field, required)
+		if err != nil {
+			return fmt.Errorf("property %s: %w", prop, err)
+		}
+
+		tag := prop
+		if pointer || field.OmitEmpty {
+			tag += ",omitempty"
+		}
+
+		fmt.Fprintf(&g.buf, "%s %s `json:%q`\n", coalesce(field.GoName, goName(prop)), typ, tag)
+	}
+
+	return nil
+}
+
+// goType returns the Go type of the schema, and whether the type is a pointer.
+//
+// Optional objects and timestamps are pointers, so they are omitted when not set.
+func (g *generator) goType(s *schema, required bool) (string, bool, error) {
+	if s.Ref != "" {
+		name := refName(s.Ref)
+		target, ok := g.doc.Components.Schemas.values[name]
+		if !ok {
+			return "", false, fmt.Errorf("unknown schema %s", s.Ref)
+		}
+
+		name = coalesce(target.GoName, name)
+		if !required {
+			return "*" + name, true, nil
+		}
+
+		return name, false, nil
+	}
+
+	switch s.Type {
+	case "":
+		return "any", false, nil
+
+	case "string":
+		if s.Format != "date-time" {
+			return "string", false, nil
+		}
+
+		g.usesTime = true
+		if !required {
+			return "*time.Time", true, nil
+		}
+
+		return "time.Time", false, nil
+
+	case "integer":
+		return "int", false, nil
+
+	case "number":
+		return "float64", false, nil
+
+	case "boolean":
+		return "bool", false, nil
+
+	case "array":
+		if s.Items == nil {
+			return "", false, fmt.Errorf("array items must be declared")
+		}
+
+		item, _, err := g.goType(s.Items, true)
+		if err != nil {
+			return "", false, err
+		}
+
+		return "[]" + item, false, nil
+
+	default:
+		return "", false, fmt.Errorf("unsupported schema type %q, declare objects as component schemas", s.Type)
+	}
+}
+
+// endpoint generates the endpoint function of the operation, and its request model.
+func (g *generator) endpoint(ep endpoint) error {
+	op := ep.op
+	name := op.OperationID
+
+	params := slices.Concat(ep.item.Parameters, op.Parameters)
+	for idx, p := range params {
+		if p.Ref != "" {
+			resolved, ok := g.doc.Components.Parameters[refName(p.Ref)]
+			if !ok {
+				return fmt.Errorf("unknown parameter %s", p.Ref)
+			}
+
+			params[idx] = resolved
+		}
+	}
+
+	result, paginated, err := g.result(op)
+	if err != nil {
+		return err
+	}
+
+	// Path segments are joined by the client, path parameters are taken from the request model.
+	segments := strings.Split(strings.Trim(ep.path, "/"), "/")
+	for idx, segment := range segments {
+		if strings.HasPrefix(segment, "{") {
+			segments[idx] = "req." + goName(strings.Trim(segment, "{}"))
+		} else {
+			segments[idx] = fmt.Sprintf("%q", segment)
+		}
+	}
+
+	path := "[]string{" + strings.Join(segments, ", ") + "}"
+	method := "http.Method" + strings.ToUpper(ep.method[:1]) + ep.method[1:]
+	body := "nil"
+	if op.RequestBody != nil {
+		body = "req"
+	}
+
+	fmt.Fprintf(&g.buf, "\n// %s %s\n", name, op.GoDoc)
+
+	switch {
+	case paginated:
+		fmt.Fprintf(&g.buf, "func %s(ctx context.Context, client Client, req %sRequest) ([]%s, error) {\n", name, name, result)
+		fmt.Fprintf(&g.buf, "return listAll[%s](ctx, client, %s, req.PageSize)\n}\n", result, path)
+
+	case result != "":
+		fmt.Fprintf(&g.buf, "func %s(ctx context.Context, client Client, req %sRequest) (*%s, error) {\n", name, name, result)
+		fmt.Fprintf(&g.buf, "var resp Response[%s]\n", result)
+		fmt.Fprintf(&g.buf, "if err := client.Do(ctx, %s, %s, %s, &resp); err != nil {\nreturn nil, err\n}\n\n", method, path, body)
+		fmt.Fprintf(&g.buf, "return &resp.Data, nil\n}\n")
+
+	default:
+		fmt.Fprintf(&g.buf, "func %s(ctx context.Context, client Client, req %sRequest) error {\n", name, name)
+		fmt.Fprintf(&g.buf, "return client.Do(ctx, %s, %s, %s, nil)\n}\n", method, path, body)
+	}
+
+	fmt.Fprintf(&g.buf, "\n// %sRequest is a request data model for %s endpoint.\ntype %sRequest struct {\n", name, name, name)
+
+	for _, p := range params {
+		switch {
+		case p.In == "path" && op.RequestBody != nil:
+			fmt.Fprintf(&g.buf, "%s string `json:\"-\"`\n", goName(p.Name))
+
+		case p.In == "path":
+			fmt.Fprintf(&g.buf, "%s string\n", goName(p.Name))
+
+		case paginated && p.Name == "pageSize":
+			fmt.Fprintf(&g.buf, "// PageSize is the number of %ss retrieved per request, defaults to DefaultPageSize.\n", strings.ToLower(result))
+			fmt.Fprintf(&g.buf, "PageSize int\n")
+
+		case paginated && p.Name == "cursor":
+			// Cursors are followed by listAll.
+
+		default:
+			return fmt.Errorf("unsupported %s parameter %s", p.In, p.Name)
+		}
+	}
+
+	if op.RequestBody != nil {
+		ref := op.RequestBody.Content["application/json"].Schema.Ref
+		s, ok := g.doc.Components.Schemas.values[refName(ref)]
+		if !ok {
+			return fmt.Errorf("unknown request body schema %s", ref)
+		}
+
+		if err := g.fields(s); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(&g.buf, "}\n")
+
+	return nil
+}
+
+// result returns the name of the schema the operation responds with, and whether the response is paginated.
+func (g *generator) result(op *operation) (string, bool, error) {
+	resp, ok := op.Responses["200"]
+	if !ok {
+		return "", false, fmt.Errorf("successful response must be declared")
+	}
+
+	if resp.Ref != "" {
+		if resp, ok = g.doc.Components.Responses[refName(resp.Ref)]; !ok {
+			return "", false, fmt.Errorf("unknown response %s", op.Responses["200"].Ref)
+		}
+	}
+
+	content, ok := resp.Content["application/json"]
+	if !ok || content.Schema == nil {
+		return "", false, nil
+	}
+
+	data, ok := content.Schema.Properties.values["data"]
+	if !ok {
+		return "", false, nil
+	}
+
+	_, paginated := content.Schema.Properties.values["metadata"]
+	if paginated && data.Type == "array" && data.Items.Ref != "" {
+		return g.schemaName(data.Items.Ref), true, nil
+	}
+
+	if data.Ref != "" {
+		return g.schemaName(data.Ref), false, nil
+	}
+
+	return "", false, nil
+}
+
+// schemaName returns the Go name of the referenced schema.
+func (g *generator) schemaName(ref string) string {
+	name := refName(ref)
+	if s, ok := g.doc.Components.Schemas.values[name]; ok {
+		return coalesce(s.GoName, name)
+	}
+
+	return name
+}
+
+// initialisms are the words written in upper case in Go names.
+var initialisms = map[string]bool{"API": true, "CA": true, "CIDR": true, "ID": true, "IP": true, "URL": true}
+
+// goName converts the JSON name to a Go name, e.g. networkID to NetworkID, and ipAddress to IPAddress.
+func goName(name string) string {
+	var (
+		out  strings.Builder
+		word []rune
+	)
+
+	flush := func() {
+		if len(word) == 0 {
+			return
+		}
+
+		w := string(word)
+		if upper := strings.ToUpper(w); initialisms[upper] {
+			out.WriteString(upper)
+		} else {
+			out.WriteString(strings.ToUpper(w[:1]) + w[1:])
+		}
+
+		word = word[:0]
+	}
+
+	runes := []rune(name)
+	for idx, r := range runes {
+		// Words start at upper case letters following lower case letters.
+		if idx > 0 && unicode.IsUpper(r) && unicode.IsLower(runes[idx-1]) {
+			flush()
+		}
+
+		word = append(word, r)
+	}
+
+	flush()
+
+	return out.String()
+}
+
+// refName returns the name of the component referenced by the JSON reference.
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// coalesce returns the first non-empty string.
+func coalesce(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// sortedKeys returns the map's keys in Go name order.
+func sortedKeys(m orderedMap[*schema]) []string {
+	keys := slices.Clone(m.keys)
+	slices.SortFunc(keys, func(a, b string) int {
+		return strings.Compare(coalesce(m.values[a].GoName, a), coalesce(m.values[b].GoName, b))
+	})
+
+	return keys
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("generating the client", func() {
+	Specify("the generated client is up to date with the OpenAPI document", func() {
+		doc, err := load()
+		Expect(err).NotTo(HaveOccurred())
+
+		src, err := generate(doc, "definednet")
+		Expect(err).NotTo(HaveOccurred())
+
+		generated, err := os.ReadFile("../../internal/definednet/api_gen.go")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(string(src)).To(Equal(string(generated)), "regenerate the client with `make generate`")
+	})
+})
@@ -0,0 +1,16 @@
+variable "definednet_token" {
+  description = "Defined.net HTTP API token"
+  sensitive   = true
+}
+
+provider "definednet" {
+  token = var.definednet_token
+}
+
+list "definednet_lighthouse" "all" {
+  provider = definednet
+
+  config {
+    network_id = "network-7P81MCS2TVAY9XJWQTNJ3PWYPD"
+  }
+}
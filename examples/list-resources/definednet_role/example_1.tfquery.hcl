@@ -0,0 +1,12 @@
+variable "definednet_token" {
+  description = "Defined.net HTTP API token"
+  sensitive   = true
+}
+
+provider "definednet" {
+  token = var.definednet_token
+}
+
+list "definednet_role" "all" {
+  provider = definednet
+}
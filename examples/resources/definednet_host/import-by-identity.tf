@@ -0,0 +1,6 @@
+import {
+  to = definednet_host.example
+  identity = {
+    id = "host-JK9G2VEYD6SHBMCBKJXHLS8Z3A"
+  }
+}
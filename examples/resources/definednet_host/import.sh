@@ -0,0 +1,5 @@
+# Hosts can be imported by their ID.
+terraform import definednet_host.example host-JK9G2VEYD6SHBMCBKJXHLS8Z3A
+
+# Hosts can be imported by their network's name and their name.
+terraform import definednet_host.example network/example/example.defined.test
@@ -0,0 +1,6 @@
+import {
+  to = definednet_lighthouse.example
+  identity = {
+    id = "host-JK9G2VEYD6SHBMCBKJXHLS8Z3A"
+  }
+}
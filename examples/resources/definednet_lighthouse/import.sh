@@ -0,0 +1,5 @@
+# Lighthouses can be imported by their ID.
+terraform import definednet_lighthouse.example host-JK9G2VEYD6SHBMCBKJXHLS8Z3A
+
+# Lighthouses can be imported by their network's name and their name.
+terraform import definednet_lighthouse.example network/example/lighthouse.defined.test
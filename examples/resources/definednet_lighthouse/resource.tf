@@ -8,21 +8,41 @@ provider "definednet" {
 }
 
 resource "definednet_lighthouse" "example" {
-  name             = "example.defined.test"
-  network_id       = "network-7P81MCS2TVAY9XJWQTNJ3PWYPD"
-  role_id          = "role-WSG78880Z655TQJVQFL5CZ405B"
-  listen_port      = 4242
-  static_addresses = ["84.123.10.1"]
-  tags             = ["service:app"]
+  name        = "example.defined.test"
+  network_id  = "network-7P81MCS2TVAY9XJWQTNJ3PWYPD"
+  role_id     = "role-WSG78880Z655TQJVQFL5CZ405B"
+  listen_port = 4242
+  tags        = ["service:app"]
+
+  deletion_protection = true
+
+  static_address = [
+    { host = "84.123.10.1" },
+  ]
+}
+
+resource "definednet_lighthouse" "port_forward" {
+  name        = "example.defined.test"
+  network_id  = "network-7P81MCS2TVAY9XJWQTNJ3PWYPD"
+  role_id     = "role-WSG78880Z655TQJVQFL5CZ405B"
+  listen_port = 4242
+
+  static_address = [
+    { host = "lighthouse.example.com", port = 14242 },
+    { host = "10.0.0.10" },
+  ]
 }
 
 resource "definednet_lighthouse" "metrics_minimal" {
-  name             = "example.defined.test"
-  network_id       = "network-7P81MCS2TVAY9XJWQTNJ3PWYPD"
-  role_id          = "role-WSG78880Z655TQJVQFL5CZ405B"
-  listen_port      = 4242
-  static_addresses = ["84.123.10.1"]
-  tags             = ["service:app"]
+  name        = "example.defined.test"
+  network_id  = "network-7P81MCS2TVAY9XJWQTNJ3PWYPD"
+  role_id     = "role-WSG78880Z655TQJVQFL5CZ405B"
+  listen_port = 4242
+  tags        = ["service:app"]
+
+  static_address = [
+    { host = "84.123.10.1" },
+  ]
 
   metrics {
     enabled = true
@@ -30,12 +50,15 @@ resource "definednet_lighthouse" "metrics_minimal" {
 }
 
 resource "definednet_lighthouse" "metrics" {
-  name             = "example.defined.test"
-  network_id       = "network-7P81MCS2TVAY9XJWQTNJ3PWYPD"
-  role_id          = "role-WSG78880Z655TQJVQFL5CZ405B"
-  listen_port      = 4242
-  static_addresses = ["84.123.10.1"]
-  tags             = ["service:app"]
+  name        = "example.defined.test"
+  network_id  = "network-7P81MCS2TVAY9XJWQTNJ3PWYPD"
+  role_id     = "role-WSG78880Z655TQJVQFL5CZ405B"
+  listen_port = 4242
+  tags        = ["service:app"]
+
+  static_address = [
+    { host = "84.123.10.1" },
+  ]
 
   metrics {
     enabled              = true
@@ -0,0 +1,6 @@
+import {
+  to = definednet_role.example
+  identity = {
+    id = "role-WSG78880Z655TQJVQFL5CZ405B"
+  }
+}
@@ -0,0 +1,6 @@
+# Roles can be imported by their ID. Imported roles declare a firewall rule per port or port range, rules declaring
+# multiple ports are only recovered from the state.
+terraform import definednet_role.example role-WSG78880Z655TQJVQFL5CZ405B
+
+# Roles can be imported by their name.
+terraform import definednet_role.example role/example
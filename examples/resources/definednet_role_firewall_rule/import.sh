@@ -0,0 +1,6 @@
+# Firewall rules can be imported by their role's ID and their position in the role's firewall rules, counting from 1.
+# Each Defined.net firewall rule carries a single port or port range, so rules are imported with port or port_range.
+terraform import definednet_role_firewall_rule.example role-WSG78880Z655TQJVQFL5CZ405B/2
+
+# Firewall rules declaring a single port or port range can be imported by their ID.
+terraform import definednet_role_firewall_rule.example role-WSG78880Z655TQJVQFL5CZ405B/3f2a9c1d0b8e7a65
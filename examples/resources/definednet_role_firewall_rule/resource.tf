@@ -0,0 +1,21 @@
+variable "definednet_token" {
+  description = "Defined.net HTTP API token"
+  sensitive   = true
+}
+
+provider "definednet" {
+  token = var.definednet_token
+}
+
+resource "definednet_role" "example" {
+  name                   = "example"
+  ignore_unmanaged_rules = true
+}
+
+resource "definednet_role_firewall_rule" "web" {
+  role_id      = definednet_role.example.id
+  protocol     = "TCP"
+  description  = "Web access"
+  ports        = ["80", "443"]
+  allowed_tags = ["service:lb"]
+}
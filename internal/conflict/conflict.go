@@ -0,0 +1,78 @@
+// Package conflict reports Defined.net HTTP API uniqueness conflicts as attribute diagnostics.
+package conflict
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+)
+
+// HostName converts host request errors to diagnostics.
+//
+// Duplicate host names are reported on the name attribute along with the host already using the name.
+func HostName(ctx context.Context, client definednet.Client, err error, networkID, name string) (diags diag.Diagnostics) {
+	if _, ok := definednet.DuplicateValue(err, "name"); !ok {
+		diags.AddError("Request Failure", err.Error())
+		return diags
+	}
+
+	hosts, listErr := definednet.ListHosts(ctx, client, definednet.ListHostsRequest{})
+	owner, found := lo.Find(hosts, func(h definednet.Host) bool {
+		return h.NetworkID == networkID && h.Name == name
+	})
+
+	if listErr != nil || !found {
+		diags.AddAttributeError(
+			path.Root("name"),
+			"Duplicate Name",
+			fmt.Sprintf("Name %q is already used by another host in network %q.", name, networkID),
+		)
+
+		return diags
+	}
+
+	diags.AddAttributeError(
+		path.Root("name"),
+		"Duplicate Name",
+		fmt.Sprintf("Name %q is already used by %s %q in network %q.", name, owner.Kind(), owner.ID, networkID),
+	)
+
+	return diags
+}
+
+// RoleName converts role request errors to diagnostics.
+//
+// Duplicate role names are reported on the name attribute along with the role already using the name.
+func RoleName(ctx context.Context, client definednet.Client, err error, name string) (diags diag.Diagnostics) {
+	if _, ok := definednet.DuplicateValue(err, "name"); !ok {
+		diags.AddError("Request Failure", err.Error())
+		return diags
+	}
+
+	roles, listErr := definednet.ListRoles(ctx, client, definednet.ListRolesRequest{})
+	owner, found := lo.Find(roles, func(r definednet.Role) bool {
+		return r.Name == name
+	})
+
+	if listErr != nil || !found {
+		diags.AddAttributeError(
+			path.Root("name"),
+			"Duplicate Name",
+			fmt.Sprintf("Name %q is already used by another role.", name),
+		)
+
+		return diags
+	}
+
+	diags.AddAttributeError(
+		path.Root("name"),
+		"Duplicate Name",
+		fmt.Sprintf("Name %q is already used by role %q.", name, owner.ID),
+	)
+
+	return diags
+}
@@ -0,0 +1,94 @@
+package conflict_test
+
+import (
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/conflict"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	fakeserver "github.com/sendsmaily/terraform-provider-definednet/internal/testing/server"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/server/servertest"
+)
+
+var _ = Describe("reporting conflicts", func() {
+	var (
+		server    *fakeserver.Server
+		duplicate error
+	)
+
+	BeforeEach(func() {
+		server = servertest.New()
+		DeferCleanup(server.Close)
+
+		Expect(server.Hosts.Add(fakeserver.Host{Host: definednet.Host{
+			ID:           "host-LIGHTHOUSE",
+			NetworkID:    "network-id",
+			Name:         "lighthouse.defined.test",
+			IsLighthouse: true,
+		}})).To(Succeed())
+
+		Expect(server.Roles.Add(fakeserver.Role{ID: "role-id", Name: "test: Role"})).To(Succeed())
+
+		duplicate = &definednet.Error{
+			StatusCode: 400,
+			Errors: []definednet.ErrorDetail{
+				{Code: definednet.ErrCodeDuplicateValue, Message: "value already exists", Path: "name"},
+			},
+		}
+	})
+
+	Specify("duplicate host names are reported on the name attribute", func(ctx SpecContext) {
+		Expect(conflict.HostName(ctx, server.Client(), duplicate, "network-id", "lighthouse.defined.test")).To(ConsistOf(
+			diag.NewAttributeErrorDiagnostic(
+				path.Root("name"),
+				"Duplicate Name",
+				`Name "lighthouse.defined.test" is already used by lighthouse "host-LIGHTHOUSE" in network "network-id".`,
+			),
+		))
+	})
+
+	Specify("duplicate role names are reported on the name attribute", func(ctx SpecContext) {
+		Expect(conflict.RoleName(ctx, server.Client(), duplicate, "test: Role")).To(ConsistOf(
+			diag.NewAttributeErrorDiagnostic(
+				path.Root("name"),
+				"Duplicate Name",
+				`Name "test: Role" is already used by role "role-id".`,
+			),
+		))
+	})
+
+	Specify("conflicting objects are omitted, when they can not be found", func(ctx SpecContext) {
+		server.Close()
+
+		Expect(conflict.HostName(ctx, server.Client(), duplicate, "network-id", "host.defined.test")).To(ConsistOf(
+			diag.NewAttributeErrorDiagnostic(
+				path.Root("name"),
+				"Duplicate Name",
+				`Name "host.defined.test" is already used by another host in network "network-id".`,
+			),
+		))
+
+		Expect(conflict.RoleName(ctx, server.Client(), duplicate, "test: Other role")).To(ConsistOf(
+			diag.NewAttributeErrorDiagnostic(
+				path.Root("name"),
+				"Duplicate Name",
+				`Name "test: Other role" is already used by another role.`,
+			),
+		))
+	})
+
+	Specify("other errors are reported as request failures", func(ctx SpecContext) {
+		err := errors.New("connection refused")
+
+		Expect(conflict.HostName(ctx, server.Client(), err, "network-id", "host.defined.test")).To(ConsistOf(
+			diag.NewErrorDiagnostic("Request Failure", "connection refused"),
+		))
+
+		Expect(conflict.RoleName(ctx, server.Client(), err, "test: Role")).To(ConsistOf(
+			diag.NewErrorDiagnostic("Request Failure", "connection refused"),
+		))
+	})
+})
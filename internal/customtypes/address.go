@@ -0,0 +1,110 @@
+package customtypes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// AddressType is an IP address or DNS name type.
+type AddressType struct {
+	basetypes.StringType
+}
+
+var _ basetypes.StringTypable = AddressType{}
+
+// NewAddressType creates an IP address or DNS name type.
+func NewAddressType() AddressType {
+	return AddressType{}
+}
+
+// Equal returns true if the passed type is equivalent.
+func (t AddressType) Equal(o attr.Type) bool {
+	other, ok := o.(AddressType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+// String returns a human-readable representation of the type.
+func (t AddressType) String() string {
+	return "customtypes.AddressType"
+}
+
+// ValueFromString converts the string value to an address value.
+func (t AddressType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return Address{StringValue: in}, nil
+}
+
+// ValueFromTerraform converts the Terraform value to an address value.
+func (t AddressType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	val, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	str, ok := val.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", val)
+	}
+
+	return Address{StringValue: str}, nil
+}
+
+// ValueType returns the type's value type.
+func (t AddressType) ValueType(_ context.Context) attr.Value {
+	return Address{}
+}
+
+// Address is an IP address or DNS name.
+//
+// Addresses are semantically equal when they are the same IP address, regardless of its representation, e.g. ::1
+// and 0:0::1 are the same IPv6 address. DNS names are compared as-is.
+type Address struct {
+	basetypes.StringValue
+}
+
+var _ basetypes.StringValuableWithSemanticEquals = Address{}
+
+// NewAddressValue creates an address from the passed IP address or DNS name.
+func NewAddressValue(addr string) Address {
+	return Address{StringValue: basetypes.NewStringValue(addr)}
+}
+
+// Type returns the value's type.
+func (v Address) Type(_ context.Context) attr.Type {
+	return NewAddressType()
+}
+
+// Equal returns true if the passed value is exactly equal.
+func (v Address) Equal(o attr.Value) bool {
+	other, ok := o.(Address)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals returns true if the addresses are the same, after normalizing them.
+func (v Address) StringSemanticEquals(_ context.Context, prior basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	other, ok := prior.(Address)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got %T. Please report this to the provider developers.", v, prior),
+		)
+
+		return false, diags
+	}
+
+	return NormalizeAddress(v.ValueString()) == NormalizeAddress(other.ValueString()), diags
+}
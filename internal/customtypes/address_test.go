@@ -0,0 +1,38 @@
+package customtypes_test
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/customtypes"
+)
+
+var _ = DescribeTable("comparing addresses",
+	func(ctx SpecContext, a, b string, equal bool) {
+		got, diags := customtypes.NewAddressValue(a).StringSemanticEquals(ctx, customtypes.NewAddressValue(b))
+		Expect(diags).To(BeEmpty())
+		Expect(got).To(Equal(equal))
+	},
+	Entry("same addresses are equal", "127.0.0.1", "127.0.0.1", true),
+	Entry("differently represented IPv6 addresses are equal", "fd00::beef", "FD00:0:0:0:0:0:0:BEEF", true),
+	Entry("different addresses are not equal", "127.0.0.1", "127.0.0.2", false),
+	Entry("same DNS names are equal", "lighthouse.defined.test", "lighthouse.defined.test", true),
+	Entry("different DNS names are not equal", "one.defined.test", "two.defined.test", false),
+)
+
+var _ = Describe("address type", func() {
+	Specify("values are converted to addresses", func(ctx SpecContext) {
+		addr := customtypes.NewAddressValue("::1")
+
+		val, err := customtypes.NewAddressType().ValueFromTerraform(ctx, lo.Must(addr.ToTerraformValue(ctx)))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(val).To(Equal(addr))
+		Expect(val.Type(ctx).Equal(customtypes.NewAddressType())).To(BeTrue())
+	})
+
+	Specify("comparing to other value types fails", func(ctx SpecContext) {
+		_, diags := customtypes.NewAddressValue("::1").StringSemanticEquals(ctx, basetypes.NewStringValue("::1"))
+		Expect(diags.HasError()).To(BeTrue())
+	})
+})
@@ -0,0 +1,129 @@
+package customtypes
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// AddressSetType is a set of IP addresses type.
+type AddressSetType struct {
+	basetypes.SetType
+}
+
+var _ basetypes.SetTypable = AddressSetType{}
+
+// NewAddressSetType creates a set of IP addresses type.
+func NewAddressSetType() AddressSetType {
+	return AddressSetType{SetType: basetypes.SetType{ElemType: basetypes.StringType{}}}
+}
+
+// Equal returns true if the passed type is equivalent.
+func (t AddressSetType) Equal(o attr.Type) bool {
+	other, ok := o.(AddressSetType)
+	if !ok {
+		return false
+	}
+
+	return t.SetType.Equal(other.SetType)
+}
+
+// String returns a human-readable representation of the type.
+func (t AddressSetType) String() string {
+	return "customtypes.AddressSetType"
+}
+
+// ValueFromSet converts the set value to a set of IP addresses value.
+func (t AddressSetType) ValueFromSet(_ context.Context, in basetypes.SetValue) (basetypes.SetValuable, diag.Diagnostics) {
+	return AddressSet{SetValue: in}, nil
+}
+
+// ValueFromTerraform converts the Terraform value to a set of IP addresses value.
+func (t AddressSetType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	val, err := t.SetType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	set, ok := val.(basetypes.SetValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", val)
+	}
+
+	return AddressSet{SetValue: set}, nil
+}
+
+// ValueType returns the type's value type.
+func (t AddressSetType) ValueType(_ context.Context) attr.Value {
+	return AddressSet{}
+}
+
+// AddressSet is a set of IP addresses.
+//
+// Address sets are semantically equal when they hold the same addresses, regardless of their order
+// and representation, e.g. ::1 and 0:0::1 are the same IPv6 address.
+type AddressSet struct {
+	basetypes.SetValue
+}
+
+var _ basetypes.SetValuableWithSemanticEquals = AddressSet{}
+
+// NewAddressSetNull creates a null set of IP addresses.
+func NewAddressSetNull() AddressSet {
+	return AddressSet{SetValue: basetypes.NewSetNull(basetypes.StringType{})}
+}
+
+// NewAddressSetValueFrom creates a set of IP addresses from the passed addresses.
+func NewAddressSetValueFrom(ctx context.Context, addrs []string) (AddressSet, diag.Diagnostics) {
+	set, diags := basetypes.NewSetValueFrom(ctx, basetypes.StringType{}, addrs)
+	return AddressSet{SetValue: set}, diags
+}
+
+// Type returns the value's type.
+func (v AddressSet) Type(_ context.Context) attr.Type {
+	return NewAddressSetType()
+}
+
+// Equal returns true if the passed value is exactly equal.
+func (v AddressSet) Equal(o attr.Value) bool {
+	other, ok := o.(AddressSet)
+	if !ok {
+		return false
+	}
+
+	return v.SetValue.Equal(other.SetValue)
+}
+
+// SetSemanticEquals returns true if the sets hold the same IP addresses.
+func (v AddressSet) SetSemanticEquals(ctx context.Context, prior basetypes.SetValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	other, ok := prior.(AddressSet)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got %T. Please report this to the provider developers.", v, prior),
+		)
+
+		return false, diags
+	}
+
+	return stringSetSemanticEquals(ctx, v.SetValue, other.SetValue, NormalizeAddress)
+}
+
+// NormalizeAddress returns the IP address in its canonical representation.
+//
+// Values, which are not IP addresses, are returned as-is.
+func NormalizeAddress(addr string) string {
+	ip, err := netip.ParseAddr(addr)
+	if err != nil {
+		return addr
+	}
+
+	return ip.String()
+}
@@ -0,0 +1,79 @@
+package customtypes_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/customtypes"
+)
+
+var _ = DescribeTable("comparing address sets",
+	func(ctx SpecContext, a, b []string, equal bool) {
+		got, diags := mustAddressSet(a).SetSemanticEquals(ctx, mustAddressSet(b))
+		Expect(diags).To(BeEmpty())
+		Expect(got).To(Equal(equal))
+	},
+	Entry("same addresses are equal",
+		[]string{"127.0.0.1", "::1"},
+		[]string{"127.0.0.1", "::1"},
+		true,
+	),
+	Entry("reordered addresses are equal",
+		[]string{"127.0.0.1", "172.16.0.1"},
+		[]string{"172.16.0.1", "127.0.0.1"},
+		true,
+	),
+	Entry("differently represented IPv6 addresses are equal",
+		[]string{"::1", "fd00::beef"},
+		[]string{"0:0::1", "FD00:0:0:0:0:0:0:BEEF"},
+		true,
+	),
+	Entry("different addresses are not equal",
+		[]string{"127.0.0.1"},
+		[]string{"127.0.0.2"},
+		false,
+	),
+	Entry("invalid addresses are compared as-is",
+		[]string{"localhost"},
+		[]string{"localhost"},
+		true,
+	),
+)
+
+var _ = DescribeTable("normalizing addresses",
+	func(addr, expected string) {
+		Expect(customtypes.NormalizeAddress(addr)).To(Equal(expected))
+	},
+	Entry("IPv4 address", "127.0.0.1", "127.0.0.1"),
+	Entry("IPv6 address", "0:0::1", "::1"),
+	Entry("uppercase IPv6 address", "FD00::BEEF", "fd00::beef"),
+	Entry("invalid address", "localhost", "localhost"),
+)
+
+var _ = Describe("address set type", func() {
+	Specify("null sets are not equal", func(ctx SpecContext) {
+		got, diags := customtypes.NewAddressSetNull().SetSemanticEquals(ctx, customtypes.NewAddressSetNull())
+		Expect(diags).To(BeEmpty())
+		Expect(got).To(BeFalse())
+	})
+
+	Specify("values are converted to address sets", func(ctx SpecContext) {
+		addrs := mustAddressSet([]string{"127.0.0.1"})
+
+		val, err := customtypes.NewAddressSetType().ValueFromTerraform(ctx, lo.Must(addrs.ToTerraformValue(ctx)))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(val).To(Equal(addrs))
+		Expect(val.Type(ctx).Equal(customtypes.NewAddressSetType())).To(BeTrue())
+	})
+})
+
+func mustAddressSet(addrs []string) customtypes.AddressSet {
+	GinkgoHelper()
+
+	set, diags := customtypes.NewAddressSetValueFrom(context.Background(), addrs)
+	Expect(diags).To(BeEmpty())
+
+	return set
+}
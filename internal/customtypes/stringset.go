@@ -0,0 +1,50 @@
+// Package customtypes implements Terraform custom types with semantic equality.
+package customtypes
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/samber/lo"
+)
+
+// stringSetSemanticEquals reports whether the string sets hold the same values, after normalizing them.
+//
+// Null and unknown sets are never semantically equal.
+func stringSetSemanticEquals(ctx context.Context, a, b basetypes.SetValue, normalize func(string) string) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if a.IsNull() || a.IsUnknown() || b.IsNull() || b.IsUnknown() {
+		return false, diags
+	}
+
+	var aElems, bElems []string
+	diags.Append(a.ElementsAs(ctx, &aElems, false)...)
+	diags.Append(b.ElementsAs(ctx, &bElems, false)...)
+
+	if diags.HasError() {
+		return false, diags
+	}
+
+	return lo.ElementsMatch(
+		lo.Uniq(lo.Map(aElems, func(v string, _ int) string { return normalize(v) })),
+		lo.Uniq(lo.Map(bElems, func(v string, _ int) string { return normalize(v) })),
+	), diags
+}
+
+// ListToSet converts a list of strings to a set, dropping duplicate elements, e.g. for upgrading states declaring
+// sets as lists.
+func ListToSet(ctx context.Context, list types.List) (types.Set, diag.Diagnostics) {
+	if list.IsNull() {
+		return types.SetNull(types.StringType), nil
+	}
+
+	var elements []string
+	if diags := list.ElementsAs(ctx, &elements, false); diags.HasError() {
+		return types.SetNull(types.StringType), diags
+	}
+
+	return types.SetValueFrom(ctx, types.StringType, lo.Uniq(elements))
+}
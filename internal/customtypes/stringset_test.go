@@ -0,0 +1,36 @@
+package customtypes_test
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/customtypes"
+)
+
+var _ = DescribeTable("converting lists to sets",
+	func(ctx SpecContext, list types.List, expected types.Set) {
+		set, diags := customtypes.ListToSet(ctx, list)
+		Expect(diags).To(BeEmpty())
+		Expect(set.Equal(expected)).To(BeTrue(), "got %s", set)
+	},
+	Entry("null lists are converted to null sets",
+		types.ListNull(types.StringType),
+		types.SetNull(types.StringType),
+	),
+	Entry("empty lists are converted to empty sets",
+		types.ListValueMust(types.StringType, []attr.Value{}),
+		types.SetValueMust(types.StringType, []attr.Value{}),
+	),
+	Entry("duplicate elements are dropped",
+		types.ListValueMust(types.StringType, []attr.Value{
+			types.StringValue("tag:one"),
+			types.StringValue("tag:two"),
+			types.StringValue("tag:one"),
+		}),
+		types.SetValueMust(types.StringType, []attr.Value{
+			types.StringValue("tag:two"),
+			types.StringValue("tag:one"),
+		}),
+	),
+)
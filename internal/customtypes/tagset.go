@@ -0,0 +1,123 @@
+package customtypes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// TagSetType is a set of host tags type.
+type TagSetType struct {
+	basetypes.SetType
+}
+
+var _ basetypes.SetTypable = TagSetType{}
+
+// NewTagSetType creates a set of host tags type.
+func NewTagSetType() TagSetType {
+	return TagSetType{SetType: basetypes.SetType{ElemType: basetypes.StringType{}}}
+}
+
+// Equal returns true if the passed type is equivalent.
+func (t TagSetType) Equal(o attr.Type) bool {
+	other, ok := o.(TagSetType)
+	if !ok {
+		return false
+	}
+
+	return t.SetType.Equal(other.SetType)
+}
+
+// String returns a human-readable representation of the type.
+func (t TagSetType) String() string {
+	return "customtypes.TagSetType"
+}
+
+// ValueFromSet converts the set value to a set of host tags value.
+func (t TagSetType) ValueFromSet(_ context.Context, in basetypes.SetValue) (basetypes.SetValuable, diag.Diagnostics) {
+	return TagSet{SetValue: in}, nil
+}
+
+// ValueFromTerraform converts the Terraform value to a set of host tags value.
+func (t TagSetType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	val, err := t.SetType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	set, ok := val.(basetypes.SetValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", val)
+	}
+
+	return TagSet{SetValue: set}, nil
+}
+
+// ValueType returns the type's value type.
+func (t TagSetType) ValueType(_ context.Context) attr.Value {
+	return TagSet{}
+}
+
+// TagSet is a set of host tags.
+//
+// Tag sets are semantically equal when they hold the same tags in their canonical representation, regardless of
+// their order.
+type TagSet struct {
+	basetypes.SetValue
+}
+
+var _ basetypes.SetValuableWithSemanticEquals = TagSet{}
+
+// NewTagSetNull creates a null set of host tags.
+func NewTagSetNull() TagSet {
+	return TagSet{SetValue: basetypes.NewSetNull(basetypes.StringType{})}
+}
+
+// NewTagSetValueFrom creates a set of host tags from the passed tags.
+func NewTagSetValueFrom(ctx context.Context, tags []string) (TagSet, diag.Diagnostics) {
+	set, diags := basetypes.NewSetValueFrom(ctx, basetypes.StringType{}, tags)
+	return TagSet{SetValue: set}, diags
+}
+
+// Type returns the value's type.
+func (v TagSet) Type(_ context.Context) attr.Type {
+	return NewTagSetType()
+}
+
+// Equal returns true if the passed value is exactly equal.
+func (v TagSet) Equal(o attr.Value) bool {
+	other, ok := o.(TagSet)
+	if !ok {
+		return false
+	}
+
+	return v.SetValue.Equal(other.SetValue)
+}
+
+// SetSemanticEquals returns true if the sets hold the same tags, after normalizing them.
+func (v TagSet) SetSemanticEquals(ctx context.Context, prior basetypes.SetValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	other, ok := prior.(TagSet)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got %T. Please report this to the provider developers.", v, prior),
+		)
+
+		return false, diags
+	}
+
+	return stringSetSemanticEquals(ctx, v.SetValue, other.SetValue, NormalizeTag)
+}
+
+// NormalizeTag returns the host tag in its canonical representation, lower-cased and without surrounding
+// whitespace, as Defined.net stores tags.
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
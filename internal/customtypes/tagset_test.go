@@ -0,0 +1,97 @@
+package customtypes_test
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/customtypes"
+)
+
+var _ = DescribeTable("comparing tag sets",
+	func(ctx SpecContext, a, b []string, equal bool) {
+		got, diags := mustTagSet(a).SetSemanticEquals(ctx, mustTagSet(b))
+		Expect(diags).To(BeEmpty())
+		Expect(got).To(Equal(equal))
+	},
+	Entry("same tags are equal",
+		[]string{"tag:one", "tag:two"},
+		[]string{"tag:one", "tag:two"},
+		true,
+	),
+	Entry("reordered tags are equal",
+		[]string{"tag:one", "tag:two"},
+		[]string{"tag:two", "tag:one"},
+		true,
+	),
+	Entry("different tags are not equal",
+		[]string{"tag:one", "tag:two"},
+		[]string{"tag:one", "tag:three"},
+		false,
+	),
+	Entry("subsets are not equal",
+		[]string{"tag:one", "tag:two"},
+		[]string{"tag:one"},
+		false,
+	),
+	Entry("tags differing by case are equal",
+		[]string{"Tag:One", "tag:two"},
+		[]string{"tag:one", "TAG:TWO"},
+		true,
+	),
+	Entry("tags differing by surrounding whitespace are equal",
+		[]string{" tag:one", "tag:two\t"},
+		[]string{"tag:one", "tag:two"},
+		true,
+	),
+)
+
+var _ = DescribeTable("normalizing tags",
+	func(tag, expected string) {
+		Expect(customtypes.NormalizeTag(tag)).To(Equal(expected))
+	},
+	Entry("canonical tags are unchanged", "tag:one", "tag:one"),
+	Entry("tags are lower-cased", "Tag:ONE", "tag:one"),
+	Entry("surrounding whitespace is trimmed", "  tag:one\n", "tag:one"),
+)
+
+var _ = Describe("tag set type", func() {
+	Specify("null sets are not equal", func(ctx SpecContext) {
+		got, diags := customtypes.NewTagSetNull().SetSemanticEquals(ctx, customtypes.NewTagSetNull())
+		Expect(diags).To(BeEmpty())
+		Expect(got).To(BeFalse())
+	})
+
+	Specify("unknown sets are not equal", func(ctx SpecContext) {
+		unknown := customtypes.TagSet{SetValue: basetypes.NewSetUnknown(basetypes.StringType{})}
+
+		got, diags := unknown.SetSemanticEquals(ctx, mustTagSet([]string{"tag:one"}))
+		Expect(diags).To(BeEmpty())
+		Expect(got).To(BeFalse())
+	})
+
+	Specify("values are converted to tag sets", func(ctx SpecContext) {
+		tags := mustTagSet([]string{"tag:one"})
+
+		val, err := customtypes.NewTagSetType().ValueFromTerraform(ctx, lo.Must(tags.ToTerraformValue(ctx)))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(val).To(Equal(tags))
+		Expect(val.Type(ctx).Equal(customtypes.NewTagSetType())).To(BeTrue())
+	})
+
+	Specify("comparing to other value types fails", func(ctx SpecContext) {
+		_, diags := mustTagSet([]string{"tag:one"}).SetSemanticEquals(ctx, customtypes.NewAddressSetNull())
+		Expect(diags.HasError()).To(BeTrue())
+	})
+})
+
+func mustTagSet(tags []string) customtypes.TagSet {
+	GinkgoHelper()
+
+	set, diags := customtypes.NewTagSetValueFrom(context.Background(), tags)
+	Expect(diags).To(BeEmpty())
+
+	return set
+}
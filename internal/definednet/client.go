@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/samber/lo"
@@ -16,6 +17,13 @@ import (
 
 // NewClient creates a Defined.net HTTP API client.
 func NewClient(endpoint, token string, version string) (Client, error) {
+	return NewClientWithTransport(endpoint, token, version, http.DefaultTransport)
+}
+
+// NewClientWithTransport creates a Defined.net HTTP API client, sending requests with the transport.
+//
+// The transport is used e.g. for recording and replaying HTTP interactions in tests.
+func NewClientWithTransport(endpoint, token string, version string, transport http.RoundTripper) (Client, error) {
 	if lo.IsEmpty(strings.TrimSpace(endpoint)) {
 		return nil, errors.New("endpoint URL must be set")
 	}
@@ -30,9 +38,10 @@ func NewClient(endpoint, token string, version string) (Client, error) {
 	}
 
 	return &client{
-		endpoint: endpointURL,
-		token:    token,
-		version:  version,
+		endpoint:   endpointURL,
+		token:      token,
+		version:    version,
+		httpClient: &http.Client{Transport: transport},
 	}, nil
 }
 
@@ -46,14 +55,75 @@ type Response[D any] struct {
 	Data D `json:"data"`
 }
 
+// Page is a generic data model for Defined.net paginated list responses.
+type Page[D any] struct {
+	Data     []D          `json:"data"`
+	Metadata PageMetadata `json:"metadata"`
+}
+
+// QueryRequest is a request payload encoded into the request URL's query instead of the HTTP body.
+type QueryRequest interface {
+	Query() url.Values
+}
+
+// DefaultPageSize declares the default number of objects retrieved per paginated list request.
+const DefaultPageSize = 500
+
+// listAll retrieves all pages of a Defined.net paginated list endpoint.
+func listAll[D any](ctx context.Context, client Client, path []string, pageSize int) ([]D, error) {
+	req := pageRequest{
+		PageSize: lo.Ternary(pageSize > 0, pageSize, DefaultPageSize),
+	}
+
+	var out []D
+	for {
+		var resp Page[D]
+		if err := client.Do(ctx, http.MethodGet, path, req, &resp); err != nil {
+			return nil, err
+		}
+
+		out = append(out, resp.Data...)
+
+		if !resp.Metadata.HasNextPage || lo.IsEmpty(resp.Metadata.Cursor) {
+			return out, nil
+		}
+
+		req.Cursor = resp.Metadata.Cursor
+	}
+}
+
+type pageRequest struct {
+	Cursor   string
+	PageSize int
+}
+
+func (r pageRequest) Query() url.Values {
+	query := url.Values{}
+	query.Set("pageSize", strconv.Itoa(r.PageSize))
+	if lo.IsNotEmpty(r.Cursor) {
+		query.Set("cursor", r.Cursor)
+	}
+
+	return query
+}
+
 type client struct {
-	endpoint *url.URL
-	token    string
-	version  string
+	endpoint   *url.URL
+	token      string
+	version    string
+	httpClient *http.Client
 }
 
 func (c *client) Do(ctx context.Context, method string, path []string, reqPayload, respPayload any) error {
-	var buf bytes.Buffer
+	var (
+		buf   bytes.Buffer
+		query url.Values
+	)
+
+	if q, ok := reqPayload.(QueryRequest); ok {
+		query = q.Query()
+		reqPayload = nil
+	}
 
 	if reqPayload != nil {
 		if err := json.NewEncoder(&buf).Encode(reqPayload); err != nil {
@@ -61,14 +131,15 @@ func (c *client) Do(ctx context.Context, method string, path []string, reqPayloa
 		}
 	}
 
-	req, err := http.NewRequestWithContext(
-		ctx,
-		method,
-		c.endpoint.JoinPath(lo.Map(path, func(p string, _ int) string {
-			return url.PathEscape(p)
-		})...).String(),
-		&buf,
-	)
+	reqURL := c.endpoint.JoinPath(lo.Map(path, func(p string, _ int) string {
+		return url.PathEscape(p)
+	})...)
+
+	if len(query) > 0 {
+		reqURL.RawQuery = query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL.String(), &buf)
 
 	if err != nil {
 		return fmt.Errorf("error compiling HTTP request: %w", err)
@@ -81,7 +152,7 @@ func (c *client) Do(ctx context.Context, method string, path []string, reqPayloa
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("error executing HTTP request: %w", err)
 	}
@@ -93,7 +164,7 @@ func (c *client) Do(ctx context.Context, method string, path []string, reqPayloa
 			return fmt.Errorf("error reading error response: %w", err)
 		}
 
-		return fmt.Errorf("code=%d reason=%s", resp.StatusCode, buf.String())
+		return newError(resp.StatusCode, buf.Bytes())
 	}
 
 	if respPayload != nil {
@@ -1,7 +1,10 @@
 package definednet_test
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -125,9 +128,29 @@ var _ = Describe("executing API requests", func() {
 
 			Expect(server.ReceivedRequests()).NotTo(BeEmpty(), "assert sanity")
 		})
+
+		Specify("query request payloads are encoded into URL query", func(ctx SpecContext) {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, "/", "cursor=abc&pageSize=10"),
+				ghttp.RespondWith(http.StatusOK, nil),
+			))
+
+			Expect(client.Do(ctx, http.MethodGet, []string{}, queryRequest{
+				"cursor":   {"abc"},
+				"pageSize": {"10"},
+			}, nil)).To(Succeed())
+
+			Expect(server.ReceivedRequests()).NotTo(BeEmpty(), "assert sanity")
+		})
 	})
 })
 
+type queryRequest url.Values
+
+func (q queryRequest) Query() url.Values {
+	return url.Values(q)
+}
+
 var _ = Describe("handling HTTP API success responses", func() {
 	type response struct {
 		Field  string `json:"field"`
@@ -201,4 +224,58 @@ var _ = Describe("handling HTTP API error responses", func() {
 		Expect(client.Do(ctx, http.MethodGet, []string{}, nil, nil)).
 			To(MatchError(`code=400 reason={"server":"response"}`))
 	})
+
+	Specify("API errors are decoded", func(ctx SpecContext) {
+		server.AppendHandlers(ghttp.RespondWithJSONEncoded(
+			http.StatusBadRequest,
+			map[string]any{
+				"errors": []map[string]any{
+					{"code": "ERR_DUPLICATE_VALUE", "message": "value already exists", "path": "name"},
+				},
+			},
+		))
+
+		err := client.Do(ctx, http.MethodGet, []string{}, nil, nil)
+
+		var apiErr *definednet.Error
+		Expect(errors.As(err, &apiErr)).To(BeTrue())
+		Expect(apiErr.StatusCode).To(Equal(http.StatusBadRequest))
+		Expect(apiErr.Errors).To(ConsistOf(definednet.ErrorDetail{
+			Code:    "ERR_DUPLICATE_VALUE",
+			Message: "value already exists",
+			Path:    "name",
+		}))
+	})
 })
+
+var _ = DescribeTable("detecting duplicate value errors",
+	func(err error, path string, expected bool) {
+		_, ok := definednet.DuplicateValue(err, path)
+		Expect(ok).To(Equal(expected))
+	},
+	Entry("duplicate value on the path",
+		&definednet.Error{Errors: []definednet.ErrorDetail{{Code: definednet.ErrCodeDuplicateValue, Path: "name"}}},
+		"name",
+		true,
+	),
+	Entry("wrapped duplicate value on the path",
+		fmt.Errorf("wrapped: %w", &definednet.Error{Errors: []definednet.ErrorDetail{{Code: definednet.ErrCodeDuplicateValue, Path: "name"}}}),
+		"name",
+		true,
+	),
+	Entry("duplicate value on other path",
+		&definednet.Error{Errors: []definednet.ErrorDetail{{Code: definednet.ErrCodeDuplicateValue, Path: "description"}}},
+		"name",
+		false,
+	),
+	Entry("other error on the path",
+		&definednet.Error{Errors: []definednet.ErrorDetail{{Code: "ERR_INVALID_VALUE", Path: "name"}}},
+		"name",
+		false,
+	),
+	Entry("non-API error",
+		errors.New("connection refused"),
+		"name",
+		false,
+	),
+)
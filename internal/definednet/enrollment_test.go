@@ -2,6 +2,7 @@ package definednet_test
 
 import (
 	"net/http"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -53,7 +54,15 @@ var _ = Describe("creating host enrollments", func() {
 		Expect(definednet.CreateEnrollment(ctx, client, definednet.CreateEnrollmentRequest{})).
 			To(PointTo(MatchAllFields(Fields{
 				"Host": MatchAllFields(Fields{
-					"ID":              Equal("host-id"),
+					"ID":             Equal("host-id"),
+					"CreatedAt":      PointTo(Equal(time.Date(2024, 10, 18, 8, 37, 30, 0, time.UTC))),
+					"OrganizationID": Equal("org-id"),
+					"Metadata": PointTo(MatchAllFields(Fields{
+						"LastSeenAt":      PointTo(Equal(time.Date(2023, 1, 25, 18, 15, 27, 0, time.UTC))),
+						"Version":         Equal("0.1.9"),
+						"Platform":        Equal("dnclient"),
+						"UpdateAvailable": BeFalse(),
+					})),
 					"NetworkID":       Equal("network-id"),
 					"RoleID":          Equal("role-id"),
 					"Name":            Equal("host.defined.test"),
@@ -62,6 +71,7 @@ var _ = Describe("creating host enrollments", func() {
 					"ListenPort":      Equal(8484),
 					"IsLighthouse":    BeTrue(),
 					"IsRelay":         BeTrue(),
+					"IsBlocked":       BeFalse(),
 					"Tags":            HaveExactElements("tag:one", "tag:two"),
 					"ConfigOverrides": HaveExactElements(
 						MatchAllFields(Fields{
@@ -0,0 +1,56 @@
+package definednet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrCodeDuplicateValue is the Defined.net HTTP API error code for values, which must be unique.
+const ErrCodeDuplicateValue = "ERR_DUPLICATE_VALUE"
+
+// Error is a Defined.net HTTP API error response.
+type Error struct {
+	// StatusCode is the HTTP response status code.
+	StatusCode int
+	// Body is the raw HTTP response body.
+	Body string
+	// Errors are the errors decoded from the response body, if any.
+	Errors []ErrorDetail
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return fmt.Sprintf("code=%d reason=%s", e.StatusCode, e.Body)
+}
+
+// DuplicateValue returns the duplicate value error reported on the request field path.
+func DuplicateValue(err error, path string) (ErrorDetail, bool) {
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		return ErrorDetail{}, false
+	}
+
+	for _, detail := range apiErr.Errors {
+		if detail.Code == ErrCodeDuplicateValue && detail.Path == path {
+			return detail, true
+		}
+	}
+
+	return ErrorDetail{}, false
+}
+
+func newError(statusCode int, body []byte) *Error {
+	var payload struct {
+		Errors []ErrorDetail `json:"errors"`
+	}
+
+	// Errors are decoded on the best effort basis, the raw body is always kept.
+	_ = json.Unmarshal(body, &payload)
+
+	return &Error{
+		StatusCode: statusCode,
+		Body:       string(body),
+		Errors:     payload.Errors,
+	}
+}
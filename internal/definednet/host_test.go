@@ -2,6 +2,7 @@ package definednet_test
 
 import (
 	"net/http"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -10,6 +11,16 @@ import (
 	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
 )
 
+var _ = Describe("blocking hosts", func() {
+	Specify("hosts are blocked on Defined.net", func(ctx SpecContext) {
+		server.AppendHandlers(ghttp.VerifyRequest(http.MethodPost, "/v1/hosts/host-id/block"))
+		Expect(definednet.BlockHost(ctx, client, definednet.BlockHostRequest{
+			ID: "host-id",
+		})).To(Succeed())
+		Expect(server.ReceivedRequests()).NotTo(BeEmpty(), "assert sanity")
+	})
+})
+
 var _ = Describe("deleting hosts", func() {
 	Specify("hosts are deleted from Defined.net", func(ctx SpecContext) {
 		server.AppendHandlers(ghttp.VerifyRequest(http.MethodDelete, "/v1/hosts/host-id"))
@@ -30,7 +41,15 @@ var _ = Describe("getting hosts", func() {
 		Expect(definednet.GetHost(ctx, client, definednet.GetHostRequest{
 			ID: "host-id",
 		})).To(PointTo(MatchAllFields(Fields{
-			"ID":              Equal("host-id"),
+			"ID":             Equal("host-id"),
+			"CreatedAt":      PointTo(Equal(time.Date(2024, 10, 18, 8, 37, 30, 0, time.UTC))),
+			"OrganizationID": Equal("org-id"),
+			"Metadata": PointTo(MatchAllFields(Fields{
+				"LastSeenAt":      PointTo(Equal(time.Date(2023, 1, 25, 18, 15, 27, 0, time.UTC))),
+				"Version":         Equal("0.1.9"),
+				"Platform":        Equal("dnclient"),
+				"UpdateAvailable": BeFalse(),
+			})),
 			"NetworkID":       Equal("network-id"),
 			"RoleID":          Equal("role-id"),
 			"Name":            Equal("host.defined.test"),
@@ -39,6 +58,7 @@ var _ = Describe("getting hosts", func() {
 			"ListenPort":      Equal(8484),
 			"IsLighthouse":    BeTrue(),
 			"IsRelay":         BeTrue(),
+			"IsBlocked":       BeTrue(),
 			"Tags":            HaveExactElements("tag:one", "tag:two"),
 			"ConfigOverrides": HaveExactElements(
 				MatchAllFields(Fields{
@@ -51,6 +71,34 @@ var _ = Describe("getting hosts", func() {
 	})
 })
 
+var _ = Describe("listing hosts", func() {
+	Specify("Defined.net responses are returned", func(ctx SpecContext) {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest(http.MethodGet, "/v2/hosts", "pageSize=500"),
+			ghttp.RespondWith(http.StatusOK, `{"data": [`+hostJSON+`], "metadata": {"hasNextPage": false}}`),
+		))
+
+		Expect(definednet.ListHosts(ctx, client, definednet.ListHostsRequest{})).To(HaveExactElements(
+			MatchFields(IgnoreExtras, Fields{
+				"ID":        Equal("host-id"),
+				"NetworkID": Equal("network-id"),
+				"Name":      Equal("host.defined.test"),
+			}),
+		))
+		Expect(server.ReceivedRequests()).NotTo(BeEmpty(), "assert sanity")
+	})
+})
+
+var _ = Describe("unblocking hosts", func() {
+	Specify("hosts are unblocked on Defined.net", func(ctx SpecContext) {
+		server.AppendHandlers(ghttp.VerifyRequest(http.MethodPost, "/v1/hosts/host-id/unblock"))
+		Expect(definednet.UnblockHost(ctx, client, definednet.UnblockHostRequest{
+			ID: "host-id",
+		})).To(Succeed())
+		Expect(server.ReceivedRequests()).NotTo(BeEmpty(), "assert sanity")
+	})
+})
+
 var _ = Describe("updating hosts", func() {
 	Specify("hosts are updated on Defined.net", func(ctx SpecContext) {
 		server.AppendHandlers(ghttp.CombineHandlers(
@@ -90,7 +138,15 @@ var _ = Describe("updating hosts", func() {
 
 		Expect(definednet.UpdateHost(ctx, client, definednet.UpdateHostRequest{})).
 			To(PointTo(MatchAllFields(Fields{
-				"ID":              Equal("host-id"),
+				"ID":             Equal("host-id"),
+				"CreatedAt":      PointTo(Equal(time.Date(2024, 10, 18, 8, 37, 30, 0, time.UTC))),
+				"OrganizationID": Equal("org-id"),
+				"Metadata": PointTo(MatchAllFields(Fields{
+					"LastSeenAt":      PointTo(Equal(time.Date(2023, 1, 25, 18, 15, 27, 0, time.UTC))),
+					"Version":         Equal("0.1.9"),
+					"Platform":        Equal("dnclient"),
+					"UpdateAvailable": BeFalse(),
+				})),
 				"NetworkID":       Equal("network-id"),
 				"RoleID":          Equal("role-id"),
 				"Name":            Equal("host.defined.test"),
@@ -99,6 +155,7 @@ var _ = Describe("updating hosts", func() {
 				"ListenPort":      Equal(8484),
 				"IsLighthouse":    BeTrue(),
 				"IsRelay":         BeTrue(),
+				"IsBlocked":       BeTrue(),
 				"Tags":            HaveExactElements("tag:one", "tag:two"),
 				"ConfigOverrides": HaveExactElements(
 					MatchAllFields(Fields{
@@ -112,36 +169,35 @@ var _ = Describe("updating hosts", func() {
 	})
 })
 
-var hostJSONResponse = `{
-  "data": {
-    "createdAt": "2024-10-18T08:37:30Z",
-    "id": "host-id",
-    "ipAddress": "10.0.0.1",
-    "isBlocked": false,
-    "isLighthouse": true,
-    "isRelay": true,
-    "listenPort": 8484,
-    "name": "host.defined.test",
-    "networkID": "network-id",
-    "organizationID": "org-id",
-    "roleID": "role-id",
-    "staticAddresses": [
-      "127.0.0.1:8484",
-      "172.16.0.1:8484"
-    ],
+var hostJSONResponse = `{"data": ` + hostJSON + `, "metadata": {}}`
+
+var hostJSON = `{
+  "createdAt": "2024-10-18T08:37:30Z",
+  "id": "host-id",
+  "ipAddress": "10.0.0.1",
+  "isBlocked": true,
+  "isLighthouse": true,
+  "isRelay": true,
+  "listenPort": 8484,
+  "name": "host.defined.test",
+  "networkID": "network-id",
+  "organizationID": "org-id",
+  "roleID": "role-id",
+  "staticAddresses": [
+    "127.0.0.1:8484",
+    "172.16.0.1:8484"
+  ],
 	"tags": [
 	  "tag:one",
 	  "tag:two"
 	],
-    "metadata": {
-      "lastSeenAt": "2023-01-25T18:15:27Z",
-      "platform": "dnclient",
-      "updateAvailable": false,
-      "version": "0.1.9"
-    },
+  "metadata": {
+    "lastSeenAt": "2023-01-25T18:15:27Z",
+    "platform": "dnclient",
+    "updateAvailable": false,
+    "version": "0.1.9"
+  },
 	"configOverrides": [
 	  {"key": "config.override", "value": "value"}
 	]
-  },
-  "metadata": {}
 }`
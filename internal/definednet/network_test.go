@@ -0,0 +1,57 @@
+package definednet_test
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+)
+
+var _ = Describe("listing networks", func() {
+	Specify("all pages of Defined.net responses are returned", func(ctx SpecContext) {
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, "/v1/networks", "pageSize=1"),
+				ghttp.RespondWith(http.StatusOK, `{
+					"data": [{"id": "network-1", "name": "First network", "cidr": "10.0.0.0/16"}],
+					"metadata": {"hasNextPage": true, "cursor": "next-page"}
+				}`),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, "/v1/networks", "cursor=next-page&pageSize=1"),
+				ghttp.RespondWith(http.StatusOK, `{
+					"data": [{"id": "network-2", "name": "Second network", "cidr": "10.1.0.0/16"}],
+					"metadata": {"hasNextPage": false}
+				}`),
+			),
+		)
+
+		Expect(definednet.ListNetworks(ctx, client, definednet.ListNetworksRequest{
+			PageSize: 1,
+		})).To(HaveExactElements(
+			MatchAllFields(Fields{
+				"ID":                  Equal("network-1"),
+				"OrganizationID":      BeEmpty(),
+				"SigningCAID":         BeEmpty(),
+				"CreatedAt":           BeNil(),
+				"LighthousesAsRelays": BeFalse(),
+				"Name":                Equal("First network"),
+				"CIDR":                Equal("10.0.0.0/16"),
+			}),
+			MatchAllFields(Fields{
+				"ID":                  Equal("network-2"),
+				"OrganizationID":      BeEmpty(),
+				"SigningCAID":         BeEmpty(),
+				"CreatedAt":           BeNil(),
+				"LighthousesAsRelays": BeFalse(),
+				"Name":                Equal("Second network"),
+				"CIDR":                Equal("10.1.0.0/16"),
+			}),
+		))
+
+		Expect(server.ReceivedRequests()).To(HaveLen(2), "assert sanity")
+	})
+})
@@ -0,0 +1,26 @@
+// Package openapi embeds the Defined.net HTTP API's OpenAPI document.
+//
+// The document is transcribed by hand from Defined.net's HTTP API documentation (https://docs.defined.net/api/), and
+// declares only the endpoints and schemas the provider uses. It is not the published document, so validating against
+// it does not detect the API drifting from the transcription. The provider's changes to the document, e.g. the code
+// generator's hints, are kept in overlay.yaml, and applied by Document.
+package openapi
+
+import (
+	_ "embed"
+)
+
+// Transcribed is the transcribed Defined.net HTTP API's OpenAPI document, in YAML.
+//
+//go:embed openapi.yaml
+var Transcribed []byte
+
+// Overlay is the provider's overlay of the transcribed document, in YAML.
+//
+//go:embed overlay.yaml
+var Overlay []byte
+
+// Document returns the transcribed document with the overlay applied, in YAML.
+func Document() ([]byte, error) {
+	return Apply(Transcribed, Overlay)
+}
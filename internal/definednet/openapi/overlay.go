@@ -0,0 +1,72 @@
+package openapi
+
+import (
+	"fmt"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Apply applies the overlay to the YAML document, as a JSON merge patch (RFC 7386).
+//
+// Mappings are merged recursively, retaining the order of the document's keys and appending the overlay's new keys.
+// Null values remove the document's keys, and other values replace the document's values.
+func Apply(document, overlay []byte) ([]byte, error) {
+	var doc, patch yaml.Node
+
+	if err := yaml.Unmarshal(document, &doc); err != nil {
+		return nil, fmt.Errorf("error decoding document: %w", err)
+	}
+
+	if err := yaml.Unmarshal(overlay, &patch); err != nil {
+		return nil, fmt.Errorf("error decoding overlay: %w", err)
+	}
+
+	if len(doc.Content) == 0 || len(patch.Content) == 0 {
+		return document, nil
+	}
+
+	return yaml.Marshal(merge(doc.Content[0], patch.Content[0]))
+}
+
+// merge merges the patch into the target node.
+func merge(target, patch *yaml.Node) *yaml.Node {
+	if patch.Kind != yaml.MappingNode {
+		return patch
+	}
+
+	if target == nil || target.Kind != yaml.MappingNode {
+		target = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	}
+
+	for idx := 0; idx < len(patch.Content); idx += 2 {
+		key, value := patch.Content[idx], patch.Content[idx+1]
+		pos := indexOf(target, key.Value)
+
+		switch {
+		case value.Tag == "!!null" && pos >= 0:
+			target.Content = append(target.Content[:pos], target.Content[pos+2:]...)
+
+		case value.Tag == "!!null":
+			// Removing a missing key is a no-op.
+
+		case pos >= 0:
+			target.Content[pos+1] = merge(target.Content[pos+1], value)
+
+		default:
+			target.Content = append(target.Content, key, merge(nil, value))
+		}
+	}
+
+	return target
+}
+
+// indexOf returns the index of the key in the mapping node's contents, or -1 if the mapping has no such key.
+func indexOf(mapping *yaml.Node, key string) int {
+	for idx := 0; idx < len(mapping.Content); idx += 2 {
+		if mapping.Content[idx].Value == key {
+			return idx
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,53 @@
+package openapi_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet/openapi"
+	"go.yaml.in/yaml/v3"
+)
+
+var _ = Describe("applying the overlay", func() {
+	DescribeTable("documents are merge patched",
+		func(document, overlay, expected string) {
+			Expect(string(lo.Must(openapi.Apply([]byte(document), []byte(overlay))))).To(Equal(expected))
+		},
+		Entry("assert mappings are merged in the document's order",
+			"b: 1\na:\n  x: 1\n  y: 2\n",
+			"a:\n  y: 3\n  z: 4\n",
+			"b: 1\na:\n    x: 1\n    y: 3\n    z: 4\n",
+		),
+		Entry("assert null values remove keys",
+			"a: 1\nb: 2\n",
+			"a: null\nc: null\n",
+			"b: 2\n",
+		),
+		Entry("assert sequences are replaced",
+			"a: [1, 2]\n",
+			"a: [3]\n",
+			"a: [3]\n",
+		),
+		Entry("assert mappings replace scalars",
+			"a: 1\n",
+			"a:\n  b: 2\n  c: null\n",
+			"a:\n    b: 2\n",
+		),
+	)
+
+	Specify("the transcribed document is changed by the overlay only", func() {
+		Expect(string(openapi.Transcribed)).NotTo(ContainSubstring("additionalProperties"))
+		Expect(string(openapi.Transcribed)).NotTo(ContainSubstring("x-go-"))
+		Expect(string(openapi.Transcribed)).NotTo(ContainSubstring("x-omitempty"))
+
+		var doc map[string]any
+		Expect(yaml.Unmarshal(lo.Must(openapi.Document()), &doc)).To(Succeed())
+
+		schemas := doc["components"].(map[string]any)["schemas"].(map[string]any)
+		Expect(schemas["RoleRequest"]).To(HaveKeyWithValue("additionalProperties", false))
+		Expect(schemas["Error"]).To(HaveKeyWithValue("x-go-name", "ErrorDetail"))
+		Expect(schemas["FirewallRule"]).To(HaveKeyWithValue("properties", HaveKeyWithValue("portRange", Equal(map[string]any{
+			"$ref": "#/components/schemas/PortRange",
+		}))))
+	})
+})
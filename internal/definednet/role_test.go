@@ -2,6 +2,7 @@ package definednet_test
 
 import (
 	"net/http"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -46,6 +47,12 @@ var _ = Describe("creating roles", func() {
 							"tag:superuser",
 						},
 					},
+					{
+						"protocol":    "UDP",
+						"description": "Allow routed subnet",
+						"allowedCIDR": "10.128.0.0/16",
+						"localCIDR":   "192.168.100.0/24",
+					},
 				},
 			}),
 			ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]any{}),
@@ -83,6 +90,12 @@ var _ = Describe("creating roles", func() {
 						"tag:superuser",
 					},
 				},
+				{
+					Protocol:    "UDP",
+					Description: "Allow routed subnet",
+					AllowedCIDR: "10.128.0.0/16",
+					LocalCIDR:   "192.168.100.0/24",
+				},
 			},
 		})).Error().NotTo(HaveOccurred())
 	})
@@ -112,12 +125,16 @@ var _ = Describe("getting roles", func() {
 				"ID":          Equal("role-id"),
 				"Name":        Equal("test: Role"),
 				"Description": Equal("Role's description"),
+				"CreatedAt":   PointTo(Equal(time.Date(2023, 2, 15, 13, 59, 9, 0, time.UTC))),
+				"ModifiedAt":  PointTo(Equal(time.Date(2023, 2, 15, 13, 59, 9, 0, time.UTC))),
 				"FirewallRules": HaveExactElements(
 					MatchAllFields(Fields{
 						"Protocol":      Equal("TCP"),
 						"Description":   Equal("Allow SSH access"),
 						"AllowedRoleID": Equal("allowed-role-id"),
 						"AllowedTags":   BeEmpty(),
+						"AllowedCIDR":   BeEmpty(),
+						"LocalCIDR":     BeEmpty(),
 						"PortRange": PointTo(MatchAllFields(Fields{
 							"From": Equal(22),
 							"To":   Equal(22),
@@ -131,6 +148,8 @@ var _ = Describe("getting roles", func() {
 							"tag:one",
 							"tag:two",
 						),
+						"AllowedCIDR": BeEmpty(),
+						"LocalCIDR":   BeEmpty(),
 						"PortRange": PointTo(MatchAllFields(Fields{
 							"From": Equal(32768),
 							"To":   Equal(65535),
@@ -143,7 +162,18 @@ var _ = Describe("getting roles", func() {
 						"AllowedTags": HaveExactElements(
 							"tag:superuser",
 						),
-						"PortRange": BeNil(),
+						"AllowedCIDR": BeEmpty(),
+						"LocalCIDR":   BeEmpty(),
+						"PortRange":   BeNil(),
+					}),
+					MatchAllFields(Fields{
+						"Protocol":      Equal("UDP"),
+						"Description":   Equal("Allow routed subnet"),
+						"AllowedRoleID": BeEmpty(),
+						"AllowedTags":   BeEmpty(),
+						"AllowedCIDR":   Equal("10.128.0.0/16"),
+						"LocalCIDR":     Equal("192.168.100.0/24"),
+						"PortRange":     BeNil(),
 					}),
 				),
 			})))
@@ -152,6 +182,24 @@ var _ = Describe("getting roles", func() {
 	})
 })
 
+var _ = Describe("listing roles", func() {
+	Specify("Defined.net responses are returned", func(ctx SpecContext) {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest(http.MethodGet, "/v1/roles", "pageSize=500"),
+			ghttp.RespondWith(http.StatusOK, `{"data": [`+roleJSON+`], "metadata": {"hasNextPage": false}}`),
+		))
+
+		Expect(definednet.ListRoles(ctx, client, definednet.ListRolesRequest{})).To(HaveExactElements(
+			MatchFields(IgnoreExtras, Fields{
+				"ID":            Equal("role-id"),
+				"Name":          Equal("test: Role"),
+				"FirewallRules": HaveLen(4),
+			}),
+		))
+		Expect(server.ReceivedRequests()).NotTo(BeEmpty(), "assert sanity")
+	})
+})
+
 var _ = Describe("updating roles", func() {
 	Specify("roles are updated on Defined.net", func(ctx SpecContext) {
 		server.AppendHandlers(ghttp.CombineHandlers(
@@ -188,6 +236,12 @@ var _ = Describe("updating roles", func() {
 							"tag:superuser",
 						},
 					},
+					{
+						"protocol":    "UDP",
+						"description": "Allow routed subnet",
+						"allowedCIDR": "10.128.0.0/16",
+						"localCIDR":   "192.168.100.0/24",
+					},
 				},
 			}),
 			ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]any{}),
@@ -226,6 +280,12 @@ var _ = Describe("updating roles", func() {
 						"tag:superuser",
 					},
 				},
+				{
+					Protocol:    "UDP",
+					Description: "Allow routed subnet",
+					AllowedCIDR: "10.128.0.0/16",
+					LocalCIDR:   "192.168.100.0/24",
+				},
 			},
 		})).Error().NotTo(HaveOccurred())
 		Expect(server.ReceivedRequests()).NotTo(BeEmpty(), "assert sanity")
@@ -239,12 +299,16 @@ var _ = Describe("updating roles", func() {
 				"ID":          Equal("role-id"),
 				"Name":        Equal("test: Role"),
 				"Description": Equal("Role's description"),
+				"CreatedAt":   PointTo(Equal(time.Date(2023, 2, 15, 13, 59, 9, 0, time.UTC))),
+				"ModifiedAt":  PointTo(Equal(time.Date(2023, 2, 15, 13, 59, 9, 0, time.UTC))),
 				"FirewallRules": HaveExactElements(
 					MatchAllFields(Fields{
 						"Protocol":      Equal("TCP"),
 						"Description":   Equal("Allow SSH access"),
 						"AllowedRoleID": Equal("allowed-role-id"),
 						"AllowedTags":   BeEmpty(),
+						"AllowedCIDR":   BeEmpty(),
+						"LocalCIDR":     BeEmpty(),
 						"PortRange": PointTo(MatchAllFields(Fields{
 							"From": Equal(22),
 							"To":   Equal(22),
@@ -258,6 +322,8 @@ var _ = Describe("updating roles", func() {
 							"tag:one",
 							"tag:two",
 						),
+						"AllowedCIDR": BeEmpty(),
+						"LocalCIDR":   BeEmpty(),
 						"PortRange": PointTo(MatchAllFields(Fields{
 							"From": Equal(32768),
 							"To":   Equal(65535),
@@ -270,7 +336,18 @@ var _ = Describe("updating roles", func() {
 						"AllowedTags": HaveExactElements(
 							"tag:superuser",
 						),
-						"PortRange": BeNil(),
+						"AllowedCIDR": BeEmpty(),
+						"LocalCIDR":   BeEmpty(),
+						"PortRange":   BeNil(),
+					}),
+					MatchAllFields(Fields{
+						"Protocol":      Equal("UDP"),
+						"Description":   Equal("Allow routed subnet"),
+						"AllowedRoleID": BeEmpty(),
+						"AllowedTags":   BeEmpty(),
+						"AllowedCIDR":   Equal("10.128.0.0/16"),
+						"LocalCIDR":     Equal("192.168.100.0/24"),
+						"PortRange":     BeNil(),
 					}),
 				),
 			})))
@@ -279,43 +356,48 @@ var _ = Describe("updating roles", func() {
 	})
 })
 
-var roleJSONResponse = `{
-  "data": {
-    "id": "role-id",
-    "name": "test: Role",
-    "description": "Role's description",
-    "createdAt": "2023-02-15T13:59:09Z",
-    "modifiedAt": "2023-02-15T13:59:09Z",
-    "firewallRules": [
-      {
-        "protocol": "TCP",
-        "description": "Allow SSH access",
-        "allowedRoleID": "allowed-role-id",
-        "portRange": {
-          "from": 22,
-          "to": 22
-        }
-      },
-      {
-        "protocol": "ANY",
-        "description": "Allow ephemeral ports",
-        "allowedTags": [
-          "tag:one",
-          "tag:two"
-        ],
-        "portRange": {
-          "from": 32768,
-          "to": 65535
-        }
-      },
-      {
-        "protocol": "ANY",
-        "description": "Allow all ports",
-        "allowedTags": [
-          "tag:superuser"
-        ]
+var roleJSONResponse = `{"data": ` + roleJSON + `, "metadata": {}}`
+
+var roleJSON = `{
+  "id": "role-id",
+  "name": "test: Role",
+  "description": "Role's description",
+  "createdAt": "2023-02-15T13:59:09Z",
+  "modifiedAt": "2023-02-15T13:59:09Z",
+  "firewallRules": [
+    {
+      "protocol": "TCP",
+      "description": "Allow SSH access",
+      "allowedRoleID": "allowed-role-id",
+      "portRange": {
+        "from": 22,
+        "to": 22
+      }
+    },
+    {
+      "protocol": "ANY",
+      "description": "Allow ephemeral ports",
+      "allowedTags": [
+        "tag:one",
+        "tag:two"
+      ],
+      "portRange": {
+        "from": 32768,
+        "to": 65535
       }
-    ]
-  },
-  "metadata": {}
+    },
+    {
+      "protocol": "ANY",
+      "description": "Allow all ports",
+      "allowedTags": [
+        "tag:superuser"
+      ]
+    },
+    {
+      "protocol": "UDP",
+      "description": "Allow routed subnet",
+      "allowedCIDR": "10.128.0.0/16",
+      "localCIDR": "192.168.100.0/24"
+    }
+  ]
 }`
@@ -0,0 +1,54 @@
+// Package deletionprotection guards resources against accidental deletion.
+package deletionprotection
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Attribute returns the deletion_protection attribute of the resource managing objects of the kind.
+func Attribute(kind string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		MarkdownDescription: fmt.Sprintf(
+			"Prevent the %s from being deleted. Must be set to `false` in a separate apply, before the %s can be deleted.",
+			kind, kind,
+		),
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+	}
+}
+
+// Default returns the deletion protection setting, disabling it for states without one.
+//
+// Deletion protection is not stored on Defined.net, so it is missing from imported states and states written by
+// earlier provider versions.
+func Default(protected types.Bool) types.Bool {
+	if protected.IsNull() || protected.IsUnknown() {
+		return types.BoolValue(false)
+	}
+
+	return protected
+}
+
+// Check verifies the object of the kind is not protected from deletion.
+func Check(kind, id string, protected types.Bool) (diags diag.Diagnostics) {
+	if !protected.ValueBool() {
+		return diags
+	}
+
+	diags.AddError(
+		"Deletion Protection Enabled",
+		fmt.Sprintf(
+			"The %s %q is protected from deletion. Set deletion_protection to false and apply the change, "+
+				"before deleting the %s.",
+			kind, id, kind,
+		),
+	)
+
+	return diags
+}
@@ -0,0 +1,43 @@
+package deletionprotection_test
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/deletionprotection"
+)
+
+var _ = DescribeTable("checking deletion protection",
+	func(protected types.Bool, expected diag.Diagnostics) {
+		Expect(deletionprotection.Check("lighthouse", "host-id", protected)).To(Equal(expected))
+	},
+	Entry("assert unprotected objects can be deleted",
+		types.BoolValue(false),
+		diag.Diagnostics(nil),
+	),
+	Entry("assert objects without the setting can be deleted",
+		types.BoolNull(),
+		diag.Diagnostics(nil),
+	),
+	Entry("assert protected objects can not be deleted",
+		types.BoolValue(true),
+		diag.Diagnostics{
+			diag.NewErrorDiagnostic(
+				"Deletion Protection Enabled",
+				`The lighthouse "host-id" is protected from deletion. Set deletion_protection to false and apply the `+
+					"change, before deleting the lighthouse.",
+			),
+		},
+	),
+)
+
+var _ = DescribeTable("defaulting deletion protection",
+	func(protected, expected types.Bool) {
+		Expect(deletionprotection.Default(protected)).To(Equal(expected))
+	},
+	Entry("assert missing settings are disabled", types.BoolNull(), types.BoolValue(false)),
+	Entry("assert unknown settings are disabled", types.BoolUnknown(), types.BoolValue(false)),
+	Entry("assert enabled settings are kept", types.BoolValue(true), types.BoolValue(true)),
+	Entry("assert disabled settings are kept", types.BoolValue(false), types.BoolValue(false)),
+)
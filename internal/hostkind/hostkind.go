@@ -0,0 +1,86 @@
+package hostkind
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+)
+
+// resourceTypes maps Defined.net host kinds to resource types managing them.
+var resourceTypes = map[definednet.HostKind]string{
+	definednet.HostKindHost:       "definednet_host",
+	definednet.HostKindLighthouse: "definednet_lighthouse",
+}
+
+// Check verifies the Defined.net host is of the expected kind.
+func Check(host *definednet.Host, want definednet.HostKind) (diags diag.Diagnostics) {
+	got := host.Kind()
+	if got == want {
+		return diags
+	}
+
+	resourceType, ok := resourceTypes[got]
+	if !ok {
+		diags.AddError(
+			"Unexpected Host Kind",
+			fmt.Sprintf("Host %q is a %s, expected a %s. The provider does not support managing %ss.", host.ID, got, want, got),
+		)
+
+		return diags
+	}
+
+	diags.AddError(
+		"Unexpected Host Kind",
+		fmt.Sprintf(
+			"Host %q is a %s, expected a %s. Manage it with a %s resource instead, "+
+				"e.g. by moving the resource with a moved block.",
+			host.ID, got, want, resourceType,
+		),
+	)
+
+	return diags
+}
+
+// CheckMoveSource verifies the state is moved from the resource managing the host kind, at the schema version.
+//
+// States of earlier schema versions are rejected, as they are upgraded by refreshing the source resource first.
+func CheckMoveSource(req resource.MoveStateRequest, from definednet.HostKind, version int64) (diags diag.Diagnostics) {
+	resourceType := resourceTypes[from]
+
+	if req.SourceTypeName != resourceType || !strings.HasSuffix(req.SourceProviderAddress, "/definednet") {
+		diags.AddError(
+			"Unsupported Move Source",
+			fmt.Sprintf(
+				"Moving %s resources of %s is not supported, only %s resources can be moved.",
+				req.SourceTypeName, req.SourceProviderAddress, resourceType,
+			),
+		)
+
+		return diags
+	}
+
+	if req.SourceSchemaVersion != version {
+		diags.AddError(
+			"Unsupported Move Source Version",
+			fmt.Sprintf(
+				"Moving %s resources from schema version %d is not supported, expected version %d. "+
+					"Refresh the %s resource with the current provider version before moving it.",
+				resourceType, req.SourceSchemaVersion, version, resourceType,
+			),
+		)
+
+		return diags
+	}
+
+	if req.SourceState == nil {
+		diags.AddError(
+			"Invalid Move Source",
+			fmt.Sprintf("The %s resource's state could not be read. This is always a problem with the provider.", resourceType),
+		)
+	}
+
+	return diags
+}
@@ -0,0 +1,58 @@
+package hostkind_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/hostkind"
+)
+
+var _ = DescribeTable("checking host kinds",
+	func(host definednet.Host, want definednet.HostKind, matcher OmegaMatcher) {
+		diags := hostkind.Check(&host, want)
+		if matcher == nil {
+			Expect(diags).To(BeEmpty())
+			return
+		}
+
+		Expect(diags).To(HaveLen(1))
+		Expect(diags[0].Summary()).To(Equal("Unexpected Host Kind"))
+		Expect(diags[0].Detail()).To(matcher)
+	},
+	Entry("assert hosts pass the host check",
+		definednet.Host{ID: "host-id"},
+		definednet.HostKindHost,
+		nil,
+	),
+	Entry("assert lighthouses pass the lighthouse check",
+		definednet.Host{ID: "host-id", IsLighthouse: true},
+		definednet.HostKindLighthouse,
+		nil,
+	),
+	Entry("assert lighthouses acting as relays pass the lighthouse check",
+		definednet.Host{ID: "host-id", IsLighthouse: true, IsRelay: true},
+		definednet.HostKindLighthouse,
+		nil,
+	),
+	Entry("assert lighthouses fail the host check",
+		definednet.Host{ID: "host-id", IsLighthouse: true},
+		definednet.HostKindHost,
+		And(
+			ContainSubstring(`Host "host-id" is a lighthouse, expected a host.`),
+			ContainSubstring("definednet_lighthouse"),
+		),
+	),
+	Entry("assert hosts fail the lighthouse check",
+		definednet.Host{ID: "host-id"},
+		definednet.HostKindLighthouse,
+		And(
+			ContainSubstring(`Host "host-id" is a host, expected a lighthouse.`),
+			ContainSubstring("definednet_host"),
+		),
+	),
+	Entry("assert relays fail the host check",
+		definednet.Host{ID: "host-id", IsRelay: true},
+		definednet.HostKindHost,
+		ContainSubstring("The provider does not support managing relays."),
+	),
+)
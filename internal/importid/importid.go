@@ -0,0 +1,97 @@
+package importid
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+)
+
+// ResolveHost resolves host import IDs in the format network/<network-name>/<host-name> to host IDs.
+//
+// Any other import IDs are considered host IDs and returned as is.
+func ResolveHost(ctx context.Context, client definednet.Client, id string) (string, error) {
+	rest, ok := strings.CutPrefix(id, "network/")
+	if !ok {
+		return id, nil
+	}
+
+	networkName, hostName, ok := strings.Cut(rest, "/")
+	if !ok || lo.IsEmpty(networkName) || lo.IsEmpty(hostName) {
+		return "", fmt.Errorf("import ID %q must be in the format network/<network-name>/<host-name>", id)
+	}
+
+	networks, err := definednet.ListNetworks(ctx, client, definednet.ListNetworksRequest{})
+	if err != nil {
+		return "", err
+	}
+
+	network, err := findOne(networks, "network", networkName, func(n definednet.Network) bool {
+		return n.Name == networkName
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	hosts, err := definednet.ListHosts(ctx, client, definednet.ListHostsRequest{})
+	if err != nil {
+		return "", err
+	}
+
+	host, err := findOne(hosts, "host", hostName, func(h definednet.Host) bool {
+		return h.NetworkID == network.ID && h.Name == hostName
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return host.ID, nil
+}
+
+// ResolveRole resolves role import IDs in the format role/<role-name> to role IDs.
+//
+// Any other import IDs are considered role IDs and returned as is.
+func ResolveRole(ctx context.Context, client definednet.Client, id string) (string, error) {
+	roleName, ok := strings.CutPrefix(id, "role/")
+	if !ok {
+		return id, nil
+	}
+
+	if lo.IsEmpty(roleName) {
+		return "", fmt.Errorf("import ID %q must be in the format role/<role-name>", id)
+	}
+
+	roles, err := definednet.ListRoles(ctx, client, definednet.ListRolesRequest{})
+	if err != nil {
+		return "", err
+	}
+
+	role, err := findOne(roles, "role", roleName, func(r definednet.Role) bool {
+		return r.Name == roleName
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return role.ID, nil
+}
+
+func findOne[T any](objects []T, kind, name string, predicate func(T) bool) (T, error) {
+	matches := lo.Filter(objects, func(obj T, _ int) bool {
+		return predicate(obj)
+	})
+
+	switch len(matches) {
+	case 0:
+		return lo.Empty[T](), fmt.Errorf("%s named %q does not exist", kind, name)
+	case 1:
+		return matches[0], nil
+	default:
+		return lo.Empty[T](), fmt.Errorf("%d %ss are named %q", len(matches), kind, name)
+	}
+}
@@ -0,0 +1,64 @@
+package importid_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/importid"
+	fakeserver "github.com/sendsmaily/terraform-provider-definednet/internal/testing/server"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/server/servertest"
+)
+
+var server *fakeserver.Server
+
+var _ = BeforeEach(func() {
+	server = servertest.New()
+	DeferCleanup(server.Close)
+
+	Expect(server.Networks.Add(fakeserver.Network{ID: "network-1", Name: "first"})).To(Succeed())
+	Expect(server.Networks.Add(fakeserver.Network{ID: "network-2", Name: "second"})).To(Succeed())
+	Expect(server.Networks.Add(fakeserver.Network{ID: "network-3", Name: "second"})).To(Succeed())
+
+	Expect(server.Hosts.Add(fakeserver.Host{Host: definednet.Host{ID: "host-1", NetworkID: "network-1", Name: "host.defined.test"}})).To(Succeed())
+	Expect(server.Hosts.Add(fakeserver.Host{Host: definednet.Host{ID: "host-2", NetworkID: "network-1", Name: "other.defined.test"}})).To(Succeed())
+	Expect(server.Hosts.Add(fakeserver.Host{Host: definednet.Host{ID: "host-3", NetworkID: "network-2", Name: "host.defined.test"}})).To(Succeed())
+
+	Expect(server.Roles.Add(fakeserver.Role{ID: "role-1", Name: "first"})).To(Succeed())
+	Expect(server.Roles.Add(fakeserver.Role{ID: "role-2", Name: "second"})).To(Succeed())
+})
+
+var _ = DescribeTable("resolving host import IDs",
+	func(ctx SpecContext, id, expected string) {
+		Expect(importid.ResolveHost(ctx, server.Client(), id)).To(Equal(expected))
+	},
+	Entry("host IDs are returned as is", "host-1", "host-1"),
+	Entry("hosts are resolved by network and host name", "network/first/other.defined.test", "host-2"),
+	Entry("host names are scoped to their network", "network/first/host.defined.test", "host-1"),
+)
+
+var _ = DescribeTable("resolving invalid host import IDs",
+	func(ctx SpecContext, id, message string) {
+		Expect(importid.ResolveHost(ctx, server.Client(), id)).Error().To(MatchError(message))
+	},
+	Entry("missing host name", "network/first", `import ID "network/first" must be in the format network/<network-name>/<host-name>`),
+	Entry("empty network name", "network//host.defined.test", `import ID "network//host.defined.test" must be in the format network/<network-name>/<host-name>`),
+	Entry("unknown network", "network/third/host.defined.test", `network named "third" does not exist`),
+	Entry("ambiguous network", "network/second/host.defined.test", `2 networks are named "second"`),
+	Entry("unknown host", "network/first/unknown.defined.test", `host named "unknown.defined.test" does not exist`),
+)
+
+var _ = DescribeTable("resolving role import IDs",
+	func(ctx SpecContext, id, expected string) {
+		Expect(importid.ResolveRole(ctx, server.Client(), id)).To(Equal(expected))
+	},
+	Entry("role IDs are returned as is", "role-1", "role-1"),
+	Entry("roles are resolved by name", "role/second", "role-2"),
+)
+
+var _ = DescribeTable("resolving invalid role import IDs",
+	func(ctx SpecContext, id, message string) {
+		Expect(importid.ResolveRole(ctx, server.Client(), id)).Error().To(MatchError(message))
+	},
+	Entry("empty role name", "role/", `import ID "role/" must be in the format role/<role-name>`),
+	Entry("unknown role", "role/third", `role named "third" does not exist`),
+)
@@ -3,15 +3,20 @@ package provider
 import (
 	"context"
 	_ "embed"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/samber/lo"
 	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/providerdata"
 	"github.com/sendsmaily/terraform-provider-definednet/internal/resource/host"
 	"github.com/sendsmaily/terraform-provider-definednet/internal/resource/lighthouse"
 	"github.com/sendsmaily/terraform-provider-definednet/internal/resource/role"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/resource/rolefirewallrule"
 )
 
 const (
@@ -40,10 +45,13 @@ type Provider struct {
 
 // Configuration declares the provider's configuration options.
 type Configuration struct {
-	Token types.String `tfsdk:"token"`
+	Token                    types.String `tfsdk:"token"`
+	Endpoint                 types.String `tfsdk:"endpoint"`
+	RequireFirewallRulePorts types.Bool   `tfsdk:"require_firewall_rule_ports"`
 }
 
 var _ provider.Provider = (*Provider)(nil)
+var _ provider.ProviderWithListResources = (*Provider)(nil)
 
 // Metadata returns the provider's metadata.
 func (p *Provider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -65,22 +73,42 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 		return
 	}
 
-	client, err := p.clientFactory(DefinednetAPIEndpoint, config.Token.ValueString(), p.version)
+	endpoint := lo.CoalesceOrEmpty(config.Endpoint.ValueString(), os.Getenv("DEFINEDNET_ENDPOINT"), DefinednetAPIEndpoint)
+
+	client, err := p.clientFactory(endpoint, config.Token.ValueString(), p.version)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid Configuration", err.Error())
 		return
 	}
 
-	resp.ResourceData = client
-	resp.DataSourceData = client
+	data := &providerdata.Data{
+		Client:                   client,
+		RequireFirewallRulePorts: config.RequireFirewallRulePorts.ValueBool(),
+		RoleLocks:                &providerdata.Locks{},
+		References:               providerdata.NewReferences(client),
+	}
+
+	resp.ResourceData = data
+	resp.DataSourceData = data
+	resp.ListResourceData = data
 }
 
 // Resources returns a slice of resources available on the provider.
 func (p *Provider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		lighthouse.NewResource,
-		host.NewResource,
+		func() resource.Resource { return host.NewResource(lighthouse.HostMover()) },
 		role.NewResource,
+		rolefirewallrule.NewResource,
+	}
+}
+
+// ListResources returns a slice of list resources available on the provider.
+func (p *Provider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		lighthouse.NewListResource,
+		host.NewListResource,
+		role.NewListResource,
 	}
 }
 
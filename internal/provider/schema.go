@@ -16,6 +16,15 @@ var Schema = schema.Schema{
 			Required:    true,
 			Sensitive:   true,
 		},
+		"endpoint": schema.StringAttribute{
+			MarkdownDescription: "Defined.net HTTP API endpoint, defaults to the `DEFINEDNET_ENDPOINT` environment variable or `" +
+				DefinednetAPIEndpoint + "`",
+			Optional: true,
+		},
+		"require_firewall_rule_ports": schema.BoolAttribute{
+			Description: "Require TCP and UDP role firewall rules to declare allowed ports",
+			Optional:    true,
+		},
 	},
 }
 
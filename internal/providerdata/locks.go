@@ -0,0 +1,32 @@
+package providerdata
+
+import (
+	"sync"
+)
+
+// Locks provides mutual exclusion of operations on the same Defined.net object.
+//
+// The zero value is ready to use.
+type Locks struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// Lock locks the object identified by the key, and returns a function unlocking it.
+func (l *Locks) Lock(key string) (unlock func()) {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*sync.Mutex)
+	}
+
+	mu, exists := l.locks[key]
+	if !exists {
+		mu = new(sync.Mutex)
+		l.locks[key] = mu
+	}
+	l.mu.Unlock()
+
+	mu.Lock()
+
+	return mu.Unlock
+}
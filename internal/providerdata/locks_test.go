@@ -0,0 +1,48 @@
+package providerdata_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/providerdata"
+)
+
+var _ = Describe("locking objects", func() {
+	Specify("the same object can not be locked concurrently", func() {
+		var locks providerdata.Locks
+
+		unlock := locks.Lock("role-1")
+
+		locked := make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			defer close(locked)
+
+			locks.Lock("role-1")()
+		}()
+
+		Consistently(locked, 100*time.Millisecond).ShouldNot(BeClosed())
+
+		unlock()
+
+		Eventually(locked).Should(BeClosed())
+	})
+
+	Specify("different objects can be locked concurrently", func() {
+		var locks providerdata.Locks
+
+		unlock := locks.Lock("role-1")
+		defer unlock()
+
+		locked := make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			defer close(locked)
+
+			locks.Lock("role-2")()
+		}()
+
+		Eventually(locked).Should(BeClosed())
+	})
+})
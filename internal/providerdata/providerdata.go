@@ -0,0 +1,20 @@
+package providerdata
+
+import (
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+)
+
+// Data is the provider's data shared with its resources.
+type Data struct {
+	// Client is the Defined.net HTTP API client.
+	Client definednet.Client
+
+	// RequireFirewallRulePorts requires TCP and UDP firewall rules to declare allowed ports.
+	RequireFirewallRulePorts bool
+
+	// RoleLocks serializes read-modify-write updates of roles.
+	RoleLocks *Locks
+
+	// References validates references to Defined.net roles and networks.
+	References *References
+}
@@ -0,0 +1,91 @@
+package providerdata
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+)
+
+// References validates references to Defined.net objects.
+//
+// Referenceable objects are listed once per provider instance, and listed again only when a reference is not found,
+// as the object may have been created since. Failing to list the objects skips the validation with a warning, leaving
+// the references to be verified by the API on apply.
+type References struct {
+	client definednet.Client
+
+	mu       sync.Mutex
+	roles    map[string]struct{}
+	networks map[string]struct{}
+}
+
+// NewReferences creates a Defined.net object reference validator.
+func NewReferences(client definednet.Client) *References {
+	return &References{client: client}
+}
+
+// ValidateRole validates the known role ID references an existing role.
+func (r *References) ValidateRole(ctx context.Context, p path.Path, id types.String) (diags diag.Diagnostics) {
+	return r.validate(ctx, p, id, "Role", &r.roles, func(ctx context.Context) ([]string, error) {
+		roles, err := definednet.ListRoles(ctx, r.client, definednet.ListRolesRequest{})
+		return lo.Map(roles, func(role definednet.Role, _ int) string { return role.ID }), err
+	})
+}
+
+// ValidateNetwork validates the known network ID references an existing network.
+func (r *References) ValidateNetwork(ctx context.Context, p path.Path, id types.String) (diags diag.Diagnostics) {
+	return r.validate(ctx, p, id, "Network", &r.networks, func(ctx context.Context) ([]string, error) {
+		networks, err := definednet.ListNetworks(ctx, r.client, definednet.ListNetworksRequest{})
+		return lo.Map(networks, func(network definednet.Network, _ int) string { return network.ID }), err
+	})
+}
+
+func (r *References) validate(
+	ctx context.Context,
+	p path.Path,
+	id types.String,
+	kind string,
+	cache *map[string]struct{},
+	list func(context.Context) ([]string, error),
+) (diags diag.Diagnostics) {
+	if id.IsNull() || id.IsUnknown() {
+		return diags
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := (*cache)[id.ValueString()]; exists {
+		return diags
+	}
+
+	ids, err := list(ctx)
+	if err != nil {
+		diags.AddAttributeWarning(
+			p,
+			"Unvalidated Reference",
+			fmt.Sprintf("%s %q could not be validated, as listing %ss failed: %s", kind, id.ValueString(), strings.ToLower(kind), err),
+		)
+
+		return diags
+	}
+
+	*cache = lo.Keyify(ids)
+
+	if _, exists := (*cache)[id.ValueString()]; !exists {
+		diags.AddAttributeError(
+			p,
+			fmt.Sprintf("Invalid %s Reference", kind),
+			fmt.Sprintf("%s %q does not exist, or is not accessible with the configured API token.", kind, id.ValueString()),
+		)
+	}
+
+	return diags
+}
@@ -0,0 +1,84 @@
+package providerdata_test
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/providerdata"
+	fakeserver "github.com/sendsmaily/terraform-provider-definednet/internal/testing/server"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/server/servertest"
+)
+
+var _ = Describe("validating references", func() {
+	var (
+		server     *fakeserver.Server
+		references *providerdata.References
+	)
+
+	BeforeEach(func() {
+		server = servertest.New()
+		DeferCleanup(server.Close)
+
+		Expect(server.Networks.Add(fakeserver.Network{ID: "network-id", Name: "test-network"})).To(Succeed())
+		Expect(server.Roles.Add(fakeserver.Role{ID: "role-id", Name: "test: Role"})).To(Succeed())
+
+		references = providerdata.NewReferences(server.Client())
+	})
+
+	Specify("existing objects pass validation", func(ctx SpecContext) {
+		Expect(references.ValidateNetwork(ctx, path.Root("network_id"), types.StringValue("network-id"))).To(BeEmpty())
+		Expect(references.ValidateRole(ctx, path.Root("role_id"), types.StringValue("role-id"))).To(BeEmpty())
+	})
+
+	Specify("missing objects fail validation", func(ctx SpecContext) {
+		Expect(references.ValidateNetwork(ctx, path.Root("network_id"), types.StringValue("network-MISSING"))).To(ConsistOf(
+			diag.NewAttributeErrorDiagnostic(
+				path.Root("network_id"),
+				"Invalid Network Reference",
+				`Network "network-MISSING" does not exist, or is not accessible with the configured API token.`,
+			),
+		))
+
+		Expect(references.ValidateRole(ctx, path.Root("role_id"), types.StringValue("role-MISSING"))).To(ConsistOf(
+			diag.NewAttributeErrorDiagnostic(
+				path.Root("role_id"),
+				"Invalid Role Reference",
+				`Role "role-MISSING" does not exist, or is not accessible with the configured API token.`,
+			),
+		))
+	})
+
+	Specify("null and unknown references are skipped", func(ctx SpecContext) {
+		server.Close()
+
+		Expect(references.ValidateRole(ctx, path.Root("role_id"), types.StringNull())).To(BeEmpty())
+		Expect(references.ValidateRole(ctx, path.Root("role_id"), types.StringUnknown())).To(BeEmpty())
+	})
+
+	Specify("found objects are cached", func(ctx SpecContext) {
+		Expect(references.ValidateRole(ctx, path.Root("role_id"), types.StringValue("role-id"))).To(BeEmpty())
+
+		server.Close()
+
+		Expect(references.ValidateRole(ctx, path.Root("role_id"), types.StringValue("role-id"))).To(BeEmpty())
+	})
+
+	Specify("objects created since listing are found", func(ctx SpecContext) {
+		Expect(references.ValidateRole(ctx, path.Root("role_id"), types.StringValue("role-id"))).To(BeEmpty())
+
+		Expect(server.Roles.Add(fakeserver.Role{ID: "role-NEW", Name: "test: New role"})).To(Succeed())
+
+		Expect(references.ValidateRole(ctx, path.Root("role_id"), types.StringValue("role-NEW"))).To(BeEmpty())
+	})
+
+	Specify("request failures skip the validation with a warning", func(ctx SpecContext) {
+		server.Close()
+
+		diags := references.ValidateRole(ctx, path.Root("role_id"), types.StringValue("role-id"))
+		Expect(diags.HasError()).To(BeFalse())
+		Expect(diags.WarningsCount()).To(Equal(1))
+		Expect(diags[0].Summary()).To(Equal("Unvalidated Reference"))
+	})
+})
@@ -0,0 +1,159 @@
+package host_test
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck/queryfilter"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/acc"
+	fakeserver "github.com/sendsmaily/terraform-provider-definednet/internal/testing/server"
+)
+
+var _ = DescribeTable("host discovery",
+	func(query resource.TestStep) {
+		harness.Run(resource.TestCase{
+			TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+				tfversion.SkipBelow(tfversion.Version1_14_0),
+			},
+			Steps: []resource.TestStep{
+				{
+					PreConfig: func() {
+						Expect(harness.Server.Hosts.Add(fakeserver.Host{Host: definednet.Host{
+							ID:        "host-OTHER",
+							NetworkID: "other-network-id",
+							Name:      "other.defined.test",
+							Tags:      []string{"tag:one"},
+						}})).To(Succeed())
+
+						Expect(harness.Server.Hosts.Add(fakeserver.Host{Host: definednet.Host{
+							ID:           "host-LIGHTHOUSE",
+							NetworkID:    "network-id",
+							Name:         "lighthouse.defined.test",
+							IsLighthouse: true,
+						}})).To(Succeed())
+					},
+					ConfigFile: acc.Fixture("host.tf"),
+					ConfigVariables: config.Variables{
+						"name":       config.StringVariable("host.defined.test"),
+						"network_id": config.StringVariable("network-id"),
+						"role_id":    config.StringVariable("role-id"),
+						"tags":       acc.Strings("tag:one", "tag:two"),
+					},
+				},
+				query,
+			},
+		})
+	},
+	Entry("assert hosts are listed without lighthouses",
+		resource.TestStep{
+			Query: true,
+			Config: `
+				provider "definednet" {
+				  token = "supersecret"
+				}
+
+				list "definednet_host" "test" {
+				  provider = definednet
+				}
+			`,
+			QueryResultChecks: []querycheck.QueryResultCheck{
+				querycheck.ExpectLength("definednet_host.test", 2),
+				querycheck.ExpectIdentity("definednet_host.test", map[string]knownvalue.Check{
+					"id": knownvalue.StringExact("host-OTHER"),
+				}),
+			},
+		},
+	),
+	Entry("assert hosts are filtered by network",
+		resource.TestStep{
+			Query: true,
+			Config: `
+				provider "definednet" {
+				  token = "supersecret"
+				}
+
+				list "definednet_host" "test" {
+				  provider = definednet
+
+				  config {
+				    network_id = "network-id"
+				  }
+				}
+			`,
+			QueryResultChecks: []querycheck.QueryResultCheck{
+				querycheck.ExpectLength("definednet_host.test", 1),
+				querycheck.ExpectResourceDisplayName(
+					"definednet_host.test",
+					queryfilter.ByDisplayName(knownvalue.StringExact("host.defined.test")),
+					knownvalue.StringExact("host.defined.test"),
+				),
+			},
+		},
+	),
+	Entry("assert hosts are filtered by role and tags",
+		resource.TestStep{
+			Query: true,
+			Config: `
+				provider "definednet" {
+				  token = "supersecret"
+				}
+
+				list "definednet_host" "by_role" {
+				  provider = definednet
+
+				  config {
+				    role_id = "role-id"
+				  }
+				}
+
+				list "definednet_host" "by_tags" {
+				  provider = definednet
+
+				  config {
+				    tags = ["tag:one", "tag:two"]
+				  }
+				}
+			`,
+			QueryResultChecks: []querycheck.QueryResultCheck{
+				querycheck.ExpectLength("definednet_host.by_role", 1),
+				querycheck.ExpectLength("definednet_host.by_tags", 1),
+			},
+		},
+	),
+	Entry("assert listed hosts include their configuration",
+		resource.TestStep{
+			Query: true,
+			Config: `
+				provider "definednet" {
+				  token = "supersecret"
+				}
+
+				list "definednet_host" "test" {
+				  provider         = definednet
+				  include_resource = true
+				}
+			`,
+			QueryResultChecks: []querycheck.QueryResultCheck{
+				querycheck.ExpectResourceKnownValues(
+					"definednet_host.test",
+					queryfilter.ByResourceIdentity(map[string]knownvalue.Check{
+						"id": knownvalue.StringExact("host-OTHER"),
+					}),
+					[]querycheck.KnownValueCheck{
+						{Path: tfjsonpath.New("name"), KnownValue: knownvalue.StringExact("other.defined.test")},
+						{Path: tfjsonpath.New("network_id"), KnownValue: knownvalue.StringExact("other-network-id")},
+						{Path: tfjsonpath.New("tags"), KnownValue: knownvalue.SetExact([]knownvalue.Check{
+							knownvalue.StringExact("tag:one"),
+						})},
+					},
+				),
+			},
+		},
+	),
+)
@@ -0,0 +1,107 @@
+package host_test
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/resource/host"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/acc"
+)
+
+var _ = Describe("host state moves", func() {
+	move := func(ctx SpecContext, req *tfprotov6.MoveResourceStateRequest) *tfprotov6.MoveResourceStateResponse {
+		srv, err := harness.ProviderFactories()[acc.ProviderName]()
+		Expect(err).NotTo(HaveOccurred())
+
+		req.TargetTypeName = "definednet_host"
+
+		resp, err := srv.MoveResourceState(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		return resp
+	}
+
+	lighthouse := `{
+		"id": "host-id",
+		"network_id": "network-id",
+		"role_id": "role-id",
+		"static_addresses": null,
+		"static_address": [{"host": "127.0.0.1", "port": null}],
+		"listen_port": 4242,
+		"name": "lighthouse.defined.test",
+		"ip_address": "10.0.0.1",
+		"tags": ["tag:one"],
+		"enrollment_code": "enrollment-code",
+		"metrics": {
+			"enabled": true,
+			"listen": "127.0.0.1:8080",
+			"path": "/metrics",
+			"namespace": "nebula",
+			"subsystem": "lighthouse",
+			"enable_extra_metrics": true
+		},
+		"blocked": false,
+		"deletion_protection": true
+	}`
+
+	Specify("lighthouses are moved to hosts", func(ctx SpecContext) {
+		resp := move(ctx, &tfprotov6.MoveResourceStateRequest{
+			SourceProviderAddress: "registry.terraform.io/sendsmaily/definednet",
+			SourceTypeName:        "definednet_lighthouse",
+			SourceSchemaVersion:   1,
+			SourceState:           &tfprotov6.RawState{JSON: []byte(lighthouse)},
+		})
+
+		Expect(resp.Diagnostics).To(BeEmpty())
+
+		raw, err := resp.TargetState.Unmarshal(host.Schema.Type().TerraformType(ctx))
+		Expect(err).NotTo(HaveOccurred())
+
+		var state host.State
+		Expect(tfsdk.State{Schema: host.Schema, Raw: raw}.Get(ctx, &state)).To(BeEmpty())
+
+		Expect(state.ID.ValueString()).To(Equal("host-id"))
+		Expect(state.Name.ValueString()).To(Equal("lighthouse.defined.test"))
+		Expect(state.EnrollmentCode.ValueString()).To(Equal("enrollment-code"))
+		Expect(state.Metrics).NotTo(BeNil())
+		Expect(state.Metrics.EnableExtraMetrics.ValueBool()).To(BeTrue())
+		Expect(state.DeletionProtection.ValueBool()).To(BeTrue())
+	})
+
+	DescribeTable("unexpected sources are rejected",
+		func(ctx SpecContext, req *tfprotov6.MoveResourceStateRequest, summary string) {
+			req.SourceState = &tfprotov6.RawState{JSON: []byte(lighthouse)}
+
+			resp := move(ctx, req)
+
+			Expect(resp.Diagnostics).To(HaveLen(1))
+			Expect(resp.Diagnostics[0].Summary).To(Equal(summary))
+			Expect(resp.TargetState).To(BeNil())
+		},
+		Entry("assert other resource types are rejected",
+			&tfprotov6.MoveResourceStateRequest{
+				SourceProviderAddress: "registry.terraform.io/sendsmaily/definednet",
+				SourceTypeName:        "definednet_role",
+				SourceSchemaVersion:   1,
+			},
+			"Unsupported Move Source",
+		),
+		Entry("assert other providers' resources are rejected",
+			&tfprotov6.MoveResourceStateRequest{
+				SourceProviderAddress: "registry.terraform.io/hashicorp/null",
+				SourceTypeName:        "definednet_lighthouse",
+				SourceSchemaVersion:   1,
+			},
+			"Unsupported Move Source",
+		),
+		Entry("assert earlier schema versions are rejected",
+			&tfprotov6.MoveResourceStateRequest{
+				SourceProviderAddress: "registry.terraform.io/sendsmaily/definednet",
+				SourceTypeName:        "definednet_lighthouse",
+				SourceSchemaVersion:   0,
+			},
+			"Unsupported Move Source Version",
+		),
+	)
+})
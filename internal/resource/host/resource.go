@@ -5,25 +5,42 @@ import (
 	_ "embed"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/conflict"
 	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/deletionprotection"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/hostkind"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/importid"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/providerdata"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/revision"
 )
 
-// NewResource creates a Defined.net Nebula host resource.
-func NewResource() resource.Resource {
-	return &Resource{}
+// NewResource creates a Defined.net Nebula host resource, moving states with the movers.
+//
+// The lighthouse package declares the mover of lighthouses demoted to hosts, as it imports this package for the
+// host resource's schema and state.
+func NewResource(movers ...resource.StateMover) resource.Resource {
+	return &Resource{movers: movers}
 }
 
 // Resource is Defined.net Nebula host resource.
 type Resource struct {
-	client definednet.Client
+	client     definednet.Client
+	references *providerdata.References
+	movers     []resource.StateMover
 }
 
 var _ resource.Resource = (*Resource)(nil)
 var _ resource.ResourceWithConfigure = (*Resource)(nil)
+var _ resource.ResourceWithIdentity = (*Resource)(nil)
 var _ resource.ResourceWithImportState = (*Resource)(nil)
+var _ resource.ResourceWithModifyPlan = (*Resource)(nil)
+var _ resource.ResourceWithMoveState = (*Resource)(nil)
+var _ resource.ResourceWithUpgradeState = (*Resource)(nil)
 
 // Configure configures the resource.
 func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -31,13 +48,14 @@ func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, r
 		return
 	}
 
-	client, ok := req.ProviderData.(definednet.Client)
+	data, ok := req.ProviderData.(*providerdata.Data)
 	if !ok {
-		resp.Diagnostics.AddError("Invalid Configuration", "The provider specifies an invalid client type")
+		resp.Diagnostics.AddError("Invalid Configuration", "The provider specifies an invalid provider data type")
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.references = data.References
 }
 
 // Metadata returns the resource's metadata.
@@ -50,6 +68,28 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 	resp.Schema = Schema
 }
 
+// IdentitySchema returns the resource's identity schema.
+func (r *Resource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = IdentitySchema
+}
+
+// ModifyPlan validates the planned role and network references.
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.references == nil {
+		return
+	}
+
+	var networkID, roleID types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("network_id"), &networkID)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("role_id"), &roleID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.references.ValidateNetwork(ctx, path.Root("network_id"), networkID)...)
+	resp.Diagnostics.Append(r.references.ValidateRole(ctx, path.Root("role_id"), roleID)...)
+}
+
 // Create creates Nebula hosts on Defined.net control plane.
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var state State
@@ -74,34 +114,30 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		IsLighthouse:    false,
 		IsRelay:         false,
 		Tags:            tags,
-		ConfigOverrides: func() []definednet.ConfigOverride {
-			if lo.IsNil(state.Metrics) || !state.Metrics.Enabled.ValueBool() {
-				return nil
-			}
-
-			return []definednet.ConfigOverride{
-				{Key: "stats.type", Value: "prometheus"},
-				{Key: "stats.listen", Value: state.Metrics.Listen.ValueString()},
-				{Key: "stats.path", Value: state.Metrics.Path.ValueString()},
-				{Key: "stats.namespace", Value: state.Metrics.Namespace.ValueString()},
-				{Key: "stats.subsystem", Value: state.Metrics.Subsystem.ValueString()},
-				{Key: "stats.message_metrics", Value: state.Metrics.EnableExtraMetrics.ValueBool()},
-				{Key: "stats.interval", Value: "60s"},
-			}
-		}(),
+		ConfigOverrides: state.Metrics.ConfigOverrides(),
 	})
 
+	if err != nil {
+		resp.Diagnostics.Append(conflict.HostName(ctx, r.client, err, state.NetworkID.ValueString(), state.Name.ValueString())...)
+		return
+	}
+
+	blocked, err := r.setBlocked(ctx, &enrollment.Host, state.Blocked.ValueBool())
 	if err != nil {
 		resp.Diagnostics.AddError("Request Failure", err.Error())
 		return
 	}
 
+	enrollment.Host = *blocked
+
 	resp.Diagnostics.Append(state.ApplyEnrollment(ctx, enrollment)...)
+	resp.Diagnostics.Append(revision.Save(ctx, resp.Private, revision.Host(enrollment.Host))...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, Identity{ID: state.ID})...)
 
 	tflog.Trace(ctx, "enrolled Defined.net host", map[string]any{
 		"id":         state.ID.String(),
@@ -113,10 +149,13 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 }
 
 // Delete deletes Nebula hosts from Defined.net control plane.
+//
+// Deletion is refused, when the host is protected from deletion.
 func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var state State
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(deletionprotection.Check("host", state.ID.ValueString(), state.DeletionProtection)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -129,6 +168,8 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 }
 
 // Read reads Nebula hosts from Defined.net control plane.
+//
+// Reading fails, when the host has been promoted to a lighthouse or a relay.
 func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var state State
 
@@ -146,12 +187,19 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		return
 	}
 
+	resp.Diagnostics.Append(hostkind.Check(host, definednet.HostKindHost)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(state.ApplyHost(ctx, host)...)
+	resp.Diagnostics.Append(revision.Save(ctx, resp.Private, revision.Host(*host))...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, Identity{ID: state.ID})...)
 
 	tflog.Trace(ctx, "refreshed Defined.net host", map[string]any{
 		"id":         state.ID.String(),
@@ -163,6 +211,8 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 }
 
 // Update updates Nebula hosts on Defined.net control plane.
+//
+// The update is refused, when the host has been changed since it was last read.
 func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var state State
 
@@ -177,6 +227,20 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
+	current, err := definednet.GetHost(ctx, r.client, definednet.GetHostRequest{
+		ID: state.ID.ValueString(),
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Request Failure", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(revision.Check(ctx, req.Private, "host", revision.Host(*current))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	host, err := definednet.UpdateHost(ctx, r.client, definednet.UpdateHostRequest{
 		ID:              state.ID.ValueString(),
 		RoleID:          state.RoleID.ValueString(),
@@ -184,34 +248,28 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		StaticAddresses: []string{},
 		ListenPort:      0,
 		Tags:            tags,
-		ConfigOverrides: func() []definednet.ConfigOverride {
-			if lo.IsNil(state.Metrics) || !state.Metrics.Enabled.ValueBool() {
-				return nil
-			}
-
-			return []definednet.ConfigOverride{
-				{Key: "stats.type", Value: "prometheus"},
-				{Key: "stats.listen", Value: state.Metrics.Listen.ValueString()},
-				{Key: "stats.path", Value: state.Metrics.Path.ValueString()},
-				{Key: "stats.namespace", Value: state.Metrics.Namespace.ValueString()},
-				{Key: "stats.subsystem", Value: state.Metrics.Subsystem.ValueString()},
-				{Key: "stats.message_metrics", Value: state.Metrics.EnableExtraMetrics.ValueBool()},
-				{Key: "stats.interval", Value: "60s"},
-			}
-		}(),
+		ConfigOverrides: state.Metrics.ConfigOverrides(),
 	})
 
+	if err != nil {
+		resp.Diagnostics.Append(conflict.HostName(ctx, r.client, err, state.NetworkID.ValueString(), state.Name.ValueString())...)
+		return
+	}
+
+	host, err = r.setBlocked(ctx, host, state.Blocked.ValueBool())
 	if err != nil {
 		resp.Diagnostics.AddError("Request Failure", err.Error())
 		return
 	}
 
 	resp.Diagnostics.Append(state.ApplyHost(ctx, host)...)
+	resp.Diagnostics.Append(revision.Save(ctx, resp.Private, revision.Host(*host))...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, Identity{ID: state.ID})...)
 
 	tflog.Trace(ctx, "updated Defined.net host", map[string]any{
 		"id":         state.ID.String(),
@@ -222,10 +280,47 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 	})
 }
 
+// setBlocked blocks or unblocks the host on Defined.net control plane, returning the host as changed.
+func (r *Resource) setBlocked(ctx context.Context, host *definednet.Host, blocked bool) (*definednet.Host, error) {
+	if host.IsBlocked == blocked {
+		return host, nil
+	}
+
+	var err error
+	if blocked {
+		err = definednet.BlockHost(ctx, r.client, definednet.BlockHostRequest{ID: host.ID})
+	} else {
+		err = definednet.UnblockHost(ctx, r.client, definednet.UnblockHostRequest{ID: host.ID})
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return definednet.GetHost(ctx, r.client, definednet.GetHostRequest{ID: host.ID})
+}
+
 // ImportState imports Nebula hosts from Defined.net control plane.
+//
+// Hosts are imported either by their ID or by their network's and their name, in the format
+// network/<network-name>/<host-name>, or by the ID in their resource identity. Lighthouses and relays are refused.
 func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importID := req.ID
+	if lo.IsEmpty(importID) {
+		resp.Diagnostics.Append(req.Identity.GetAttribute(ctx, path.Root("id"), &importID)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	id, err := importid.ResolveHost(ctx, r.client, importID)
+	if err != nil {
+		resp.Diagnostics.AddError("Import Failure", err.Error())
+		return
+	}
+
 	host, err := definednet.GetHost(ctx, r.client, definednet.GetHostRequest{
-		ID: req.ID,
+		ID: id,
 	})
 
 	if err != nil {
@@ -233,13 +328,20 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 		return
 	}
 
+	resp.Diagnostics.Append(hostkind.Check(host, definednet.HostKindHost)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state State
 	resp.Diagnostics.Append(state.ApplyHost(ctx, host)...)
+	resp.Diagnostics.Append(revision.Save(ctx, resp.Private, revision.Host(*host))...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, Identity{ID: state.ID})...)
 
 	tflog.Trace(ctx, "imported Defined.net host", map[string]any{
 		"id":         state.ID.String(),
@@ -249,3 +351,8 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 		"tags":       state.Tags.String(),
 	})
 }
+
+// MoveState returns the resource's state movers.
+func (r *Resource) MoveState(context.Context) []resource.StateMover {
+	return r.movers
+}
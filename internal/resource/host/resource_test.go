@@ -3,41 +3,33 @@ package host_test
 import (
 	"regexp"
 
-	"github.com/hashicorp/terraform-plugin-framework/providerserver"
-	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/compare"
 	"github.com/hashicorp/terraform-plugin-testing/config"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 	. "github.com/onsi/ginkgo/v2"
-	"github.com/samber/lo"
+	. "github.com/onsi/gomega"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/acc"
+	fakeserver "github.com/sendsmaily/terraform-provider-definednet/internal/testing/server"
 )
 
 var _ = DescribeTable("host resource management",
 	func(steps ...resource.TestStep) {
-		resource.Test(GinkgoT(), resource.TestCase{
-			Steps: lo.Map(steps, func(step resource.TestStep, _ int) resource.TestStep {
-				step.ProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-					"definednet": providerserver.NewProtocol6WithError(providerFactory()),
-				}
-
-				return step
-			}),
-		})
+		harness.Test(steps...)
 	},
 	Entry("assert host is created in expected configuration",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/host.tf"),
+			ConfigFile: acc.Fixture("host.tf"),
 			ConfigVariables: config.Variables{
 				"name":       config.StringVariable("host.defined.test"),
 				"network_id": config.StringVariable("network-id"),
 				"role_id":    config.StringVariable("role-id"),
-				"tags": config.ListVariable(
-					config.StringVariable("tag:one"),
-					config.StringVariable("tag:two"),
-				),
+				"tags":       acc.Strings("tag:one", "tag:two"),
 			},
 			ConfigPlanChecks: resource.ConfigPlanChecks{
 				PreApply: []plancheck.PlanCheck{
@@ -67,34 +59,28 @@ var _ = DescribeTable("host resource management",
 				resource.TestCheckResourceAttr("definednet_host.test", "name", "host.defined.test"),
 				resource.TestCheckResourceAttr("definednet_host.test", "network_id", "network-id"),
 				resource.TestCheckResourceAttr("definednet_host.test", "role_id", "role-id"),
-				resource.TestCheckResourceAttr("definednet_host.test", "tags.0", "tag:one"),
-				resource.TestCheckResourceAttr("definednet_host.test", "tags.1", "tag:two"),
+				resource.TestCheckTypeSetElemAttr("definednet_host.test", "tags.*", "tag:one"),
+				resource.TestCheckTypeSetElemAttr("definednet_host.test", "tags.*", "tag:two"),
 			),
 		},
 	),
 	Entry("assert simple updates are executed in-place",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/host.tf"),
+			ConfigFile: acc.Fixture("host.tf"),
 			ConfigVariables: config.Variables{
 				"name":       config.StringVariable("host.defined.test"),
 				"network_id": config.StringVariable("network-id"),
 				"role_id":    config.StringVariable("role-id"),
-				"tags": config.ListVariable(
-					config.StringVariable("tag:one"),
-					config.StringVariable("tag:two"),
-				),
+				"tags":       acc.Strings("tag:one", "tag:two"),
 			},
 		},
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/host.tf"),
+			ConfigFile: acc.Fixture("host.tf"),
 			ConfigVariables: config.Variables{
 				"name":       config.StringVariable("updated-host.defined.test"),
 				"network_id": config.StringVariable("network-id"),
 				"role_id":    config.StringVariable("updated-role-id"),
-				"tags": config.ListVariable(
-					config.StringVariable("tag:one"),
-					config.StringVariable("tag:three"),
-				),
+				"tags":       acc.Strings("tag:one", "tag:three"),
 			},
 			ConfigPlanChecks: resource.ConfigPlanChecks{
 				PreApply: []plancheck.PlanCheck{
@@ -105,34 +91,28 @@ var _ = DescribeTable("host resource management",
 				resource.TestCheckResourceAttr("definednet_host.test", "name", "updated-host.defined.test"),
 				resource.TestCheckResourceAttr("definednet_host.test", "network_id", "network-id"),
 				resource.TestCheckResourceAttr("definednet_host.test", "role_id", "updated-role-id"),
-				resource.TestCheckResourceAttr("definednet_host.test", "tags.0", "tag:one"),
-				resource.TestCheckResourceAttr("definednet_host.test", "tags.1", "tag:three"),
+				resource.TestCheckTypeSetElemAttr("definednet_host.test", "tags.*", "tag:one"),
+				resource.TestCheckTypeSetElemAttr("definednet_host.test", "tags.*", "tag:three"),
 			),
 		},
 	),
 	Entry("assert updating network_id replaces the host",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/host.tf"),
+			ConfigFile: acc.Fixture("host.tf"),
 			ConfigVariables: config.Variables{
 				"name":       config.StringVariable("host.defined.test"),
 				"network_id": config.StringVariable("network-id"),
 				"role_id":    config.StringVariable("role-id"),
-				"tags": config.ListVariable(
-					config.StringVariable("tag:one"),
-					config.StringVariable("tag:two"),
-				),
+				"tags":       acc.Strings("tag:one", "tag:two"),
 			},
 		},
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/host.tf"),
+			ConfigFile: acc.Fixture("host.tf"),
 			ConfigVariables: config.Variables{
 				"name":       config.StringVariable("host.defined.test"),
 				"network_id": config.StringVariable("updated-network-id"),
 				"role_id":    config.StringVariable("role-id"),
-				"tags": config.ListVariable(
-					config.StringVariable("tag:one"),
-					config.StringVariable("tag:two"),
-				),
+				"tags":       acc.Strings("tag:one", "tag:two"),
 			},
 			ConfigPlanChecks: resource.ConfigPlanChecks{
 				PreApply: []plancheck.PlanCheck{
@@ -144,37 +124,78 @@ var _ = DescribeTable("host resource management",
 	),
 	Entry("assert importing host populates the host",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/host.tf"),
+			ConfigFile: acc.Fixture("host.tf"),
 			ConfigVariables: config.Variables{
 				"name":       config.StringVariable("host.defined.test"),
 				"network_id": config.StringVariable("network-id"),
 				"role_id":    config.StringVariable("role-id"),
-				"tags": config.ListVariable(
-					config.StringVariable("tag:one"),
-					config.StringVariable("tag:two"),
-				),
+				"tags":       acc.Strings("tag:one", "tag:two"),
 			},
 		},
+		acc.ImportStateVerify(resource.TestStep{
+			ConfigFile: acc.Fixture("host.tf"),
+			ConfigVariables: config.Variables{
+				"name":       config.StringVariable("host.defined.test"),
+				"network_id": config.StringVariable("network-id"),
+				"role_id":    config.StringVariable("role-id"),
+				"tags":       acc.Strings("tag:one", "tag:two"),
+			},
+		}, "definednet_host.test", "enrollment_code"),
+	),
+	Entry("assert importing host by name populates the host",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/host.tf"),
+			PreConfig: func() {
+				Expect(harness.Server.Networks.Replace(fakeserver.Network{ID: "network-id", Name: "test-network"})).To(Succeed())
+			},
+			ConfigFile: acc.Fixture("host.tf"),
 			ConfigVariables: config.Variables{
 				"name":       config.StringVariable("host.defined.test"),
 				"network_id": config.StringVariable("network-id"),
 				"role_id":    config.StringVariable("role-id"),
-				"tags": config.ListVariable(
-					config.StringVariable("tag:one"),
-					config.StringVariable("tag:two"),
-				),
+				"tags":       acc.Strings("tag:one"),
+			},
+		},
+		acc.ImportStateVerify(resource.TestStep{
+			ConfigFile: acc.Fixture("host.tf"),
+			ConfigVariables: config.Variables{
+				"name":       config.StringVariable("host.defined.test"),
+				"network_id": config.StringVariable("network-id"),
+				"role_id":    config.StringVariable("role-id"),
+				"tags":       acc.Strings("tag:one"),
+			},
+			ImportStateId: "network/test-network/host.defined.test",
+		}, "definednet_host.test", "enrollment_code"),
+	),
+	Entry("assert importing unknown host by name fails",
+		resource.TestStep{
+			PreConfig: func() {
+				Expect(harness.Server.Networks.Replace(fakeserver.Network{ID: "network-id", Name: "test-network"})).To(Succeed())
+			},
+			ConfigFile: acc.Fixture("host.tf"),
+			ConfigVariables: config.Variables{
+				"name":       config.StringVariable("host.defined.test"),
+				"network_id": config.StringVariable("network-id"),
+				"role_id":    config.StringVariable("role-id"),
+				"tags":       acc.Strings("tag:one"),
+			},
+		},
+		resource.TestStep{
+			ConfigFile: acc.Fixture("host.tf"),
+			ConfigVariables: config.Variables{
+				"name":       config.StringVariable("host.defined.test"),
+				"network_id": config.StringVariable("network-id"),
+				"role_id":    config.StringVariable("role-id"),
+				"tags":       acc.Strings("tag:one"),
 			},
-			ResourceName:            "definednet_host.test",
-			ImportState:             true,
-			ImportStateVerify:       true,
-			ImportStateVerifyIgnore: []string{"enrollment_code"},
+			ResourceName:  "definednet_host.test",
+			ImportState:   true,
+			ImportStateId: "network/test-network/unknown.defined.test",
+			ExpectError:   regexp.MustCompile(`host named "unknown.defined.test" does not exist`),
 		},
 	),
 	Entry("assert optional fields are optional",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/host_minimal.tf"),
+			ConfigFile: acc.Fixture("host_minimal.tf"),
 			ConfigVariables: config.Variables{
 				"name":       config.StringVariable("host.defined.test"),
 				"network_id": config.StringVariable("network-id"),
@@ -184,39 +205,27 @@ var _ = DescribeTable("host resource management",
 				statecheck.ExpectKnownValue("definednet_host.minimal_test", tfjsonpath.New("tags"), knownvalue.Null()),
 			},
 		},
-		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/host_minimal.tf"),
+		acc.ImportStateVerify(resource.TestStep{
+			ConfigFile: acc.Fixture("host_minimal.tf"),
 			ConfigVariables: config.Variables{
 				"name":       config.StringVariable("host.defined.test"),
 				"network_id": config.StringVariable("network-id"),
 			},
-			ResourceName:            "definednet_host.minimal_test",
-			ImportState:             true,
-			ImportStateVerify:       true,
-			ImportStateVerifyIgnore: []string{"enrollment_code"},
 			ConfigStateChecks: []statecheck.StateCheck{
 				statecheck.ExpectKnownValue("definednet_host.minimal_test", tfjsonpath.New("role_id"), knownvalue.Null()),
 				statecheck.ExpectKnownValue("definednet_host.minimal_test", tfjsonpath.New("tags"), knownvalue.Null()),
 			},
-		},
+		}, "definednet_host.minimal_test", "enrollment_code"),
 	),
 )
 
 var _ = DescribeTable("host metrics exporter configuration management",
 	func(steps ...resource.TestStep) {
-		resource.Test(GinkgoT(), resource.TestCase{
-			Steps: lo.Map(steps, func(step resource.TestStep, _ int) resource.TestStep {
-				step.ProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-					"definednet": providerserver.NewProtocol6WithError(providerFactory()),
-				}
-
-				return step
-			}),
-		})
+		harness.Test(steps...)
 	},
 	Entry("assert enabling metrics configures default metrics exporter",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/host_metrics_defaults.tf"),
+			ConfigFile: acc.Fixture("host_metrics_defaults.tf"),
 			ConfigStateChecks: []statecheck.StateCheck{
 				statecheck.ExpectKnownValue("definednet_host.metrics_default_test", tfjsonpath.New("metrics").AtMapKey("listen"), knownvalue.StringExact("127.0.0.1:8080")),
 				statecheck.ExpectKnownValue("definednet_host.metrics_default_test", tfjsonpath.New("metrics").AtMapKey("path"), knownvalue.StringExact("/metrics")),
@@ -228,7 +237,7 @@ var _ = DescribeTable("host metrics exporter configuration management",
 	),
 	Entry("assert metrics exporter is configurable",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/host_metrics.tf"),
+			ConfigFile: acc.Fixture("host_metrics.tf"),
 			ConfigVariables: config.Variables{
 				"metrics_listen":       config.StringVariable("100.64.0.1:9100"),
 				"metrics_path":         config.StringVariable("/-/metrics"),
@@ -247,7 +256,7 @@ var _ = DescribeTable("host metrics exporter configuration management",
 	),
 	Entry("assert metrics configuration updates are executed in-place",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/host_metrics.tf"),
+			ConfigFile: acc.Fixture("host_metrics.tf"),
 			ConfigVariables: config.Variables{
 				"metrics_listen":       config.StringVariable("127.0.0.1:8080"),
 				"metrics_path":         config.StringVariable("/metrics"),
@@ -257,7 +266,7 @@ var _ = DescribeTable("host metrics exporter configuration management",
 			},
 		},
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/host_metrics.tf"),
+			ConfigFile: acc.Fixture("host_metrics.tf"),
 			ConfigVariables: config.Variables{
 				"metrics_listen":       config.StringVariable("100.64.0.1:9100"),
 				"metrics_path":         config.StringVariable("/-/metrics"),
@@ -281,7 +290,7 @@ var _ = DescribeTable("host metrics exporter configuration management",
 	),
 	Entry("assert host import populates metrics configuration",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/host_metrics.tf"),
+			ConfigFile: acc.Fixture("host_metrics.tf"),
 			ConfigVariables: config.Variables{
 				"metrics_listen":       config.StringVariable("100.64.0.1:9100"),
 				"metrics_path":         config.StringVariable("/-/metrics"),
@@ -290,8 +299,8 @@ var _ = DescribeTable("host metrics exporter configuration management",
 				"metrics_enable_extra": config.BoolVariable(true),
 			},
 		},
-		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/host_metrics.tf"),
+		acc.ImportStateVerify(resource.TestStep{
+			ConfigFile: acc.Fixture("host_metrics.tf"),
 			ConfigVariables: config.Variables{
 				"metrics_listen":       config.StringVariable("100.64.0.1:9100"),
 				"metrics_path":         config.StringVariable("/-/metrics"),
@@ -299,10 +308,426 @@ var _ = DescribeTable("host metrics exporter configuration management",
 				"metrics_subsystem":    config.StringVariable("configurable_host"),
 				"metrics_enable_extra": config.BoolVariable(true),
 			},
-			ResourceName:            "definednet_host.metrics_test",
-			ImportState:             true,
-			ImportStateVerify:       true,
-			ImportStateVerifyIgnore: []string{"enrollment_code"},
+		}, "definednet_host.metrics_test", "enrollment_code"),
+	),
+)
+
+var _ = DescribeTable("concurrent host modification",
+	func(steps ...resource.TestStep) {
+		harness.Test(steps...)
+	},
+	Entry("assert hosts changed since they were last read are not overwritten",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("host.tf"),
+			ConfigVariables: config.Variables{
+				"name":       config.StringVariable("host.defined.test"),
+				"network_id": config.StringVariable("network-id"),
+				"role_id":    config.StringVariable("role-id"),
+				"tags":       acc.Strings("tag:one"),
+			},
+		},
+		resource.TestStep{
+			ConfigFile: acc.Fixture("host.tf"),
+			ConfigVariables: config.Variables{
+				"name":       config.StringVariable("host.defined.test"),
+				"network_id": config.StringVariable("network-id"),
+				"role_id":    config.StringVariable("role-id"),
+				"tags":       acc.Strings("tag:two"),
+			},
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					modifyRemotely(func() {
+						for _, host := range harness.Server.Hosts.List() {
+							host.Host.Tags = []string{"tag:concurrent"}
+							Expect(harness.Server.Hosts.Replace(host)).To(Succeed())
+						}
+					}),
+				},
+			},
+			ExpectError: regexp.MustCompile(`(?s)Conflicting Changes.*tags: \["tag:one"\] => \["tag:concurrent"\]`),
+		},
+		resource.TestStep{
+			PreConfig: func() {
+				for _, host := range harness.Server.Hosts.List() {
+					Expect(harness.Server.Hosts.Revision(host.Key())).To(Equal(2), "the host must not have been updated")
+				}
+			},
+			ConfigFile: acc.Fixture("host.tf"),
+			ConfigVariables: config.Variables{
+				"name":       config.StringVariable("host.defined.test"),
+				"network_id": config.StringVariable("network-id"),
+				"role_id":    config.StringVariable("role-id"),
+				"tags":       acc.Strings("tag:two"),
+			},
+			Check: resource.TestCheckTypeSetElemAttr("definednet_host.test", "tags.*", "tag:two"),
+		},
+	),
+)
+
+var _ = DescribeTable("host reference validation",
+	func(steps ...resource.TestStep) {
+		harness.Test(steps...)
+	},
+	Entry("assert unknown roles fail planning",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("host.tf"),
+			ConfigVariables: config.Variables{
+				"name":       config.StringVariable("host.defined.test"),
+				"network_id": config.StringVariable("network-id"),
+				"role_id":    config.StringVariable("role-MISSING"),
+				"tags":       acc.Strings(),
+			},
+			PlanOnly:    true,
+			ExpectError: regexp.MustCompile(`Role "role-MISSING" does not exist`),
+		},
+	),
+	Entry("assert unknown networks fail planning",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("host.tf"),
+			ConfigVariables: config.Variables{
+				"name":       config.StringVariable("host.defined.test"),
+				"network_id": config.StringVariable("network-MISSING"),
+				"role_id":    config.StringVariable("role-id"),
+				"tags":       acc.Strings(),
+			},
+			PlanOnly:    true,
+			ExpectError: regexp.MustCompile(`Network "network-MISSING" does not exist`),
+		},
+	),
+)
+
+var _ = DescribeTable("host name uniqueness",
+	func(steps ...resource.TestStep) {
+		harness.Test(steps...)
+	},
+	Entry("assert hosts can not reuse names in the network",
+		resource.TestStep{
+			PreConfig: func() {
+				Expect(harness.Server.Hosts.Add(fakeserver.Host{Host: definednet.Host{
+					ID:        "host-OTHER",
+					NetworkID: "network-id",
+					Name:      "host.defined.test",
+				}})).To(Succeed())
+			},
+			ConfigFile: acc.Fixture("host.tf"),
+			ConfigVariables: config.Variables{
+				"name":       config.StringVariable("host.defined.test"),
+				"network_id": config.StringVariable("network-id"),
+				"role_id":    config.StringVariable("role-id"),
+				"tags":       acc.Strings(),
+			},
+			ExpectError: regexp.MustCompile(`Name "host.defined.test" is already used by host "host-OTHER"`),
+		},
+	),
+	Entry("assert hosts can not be renamed to names used in the network",
+		resource.TestStep{
+			PreConfig: func() {
+				Expect(harness.Server.Hosts.Add(fakeserver.Host{Host: definednet.Host{
+					ID:           "host-OTHER",
+					NetworkID:    "network-id",
+					Name:         "other.defined.test",
+					IsLighthouse: true,
+				}})).To(Succeed())
+			},
+			ConfigFile: acc.Fixture("host.tf"),
+			ConfigVariables: config.Variables{
+				"name":       config.StringVariable("host.defined.test"),
+				"network_id": config.StringVariable("network-id"),
+				"role_id":    config.StringVariable("role-id"),
+				"tags":       acc.Strings(),
+			},
+		},
+		resource.TestStep{
+			ConfigFile: acc.Fixture("host.tf"),
+			ConfigVariables: config.Variables{
+				"name":       config.StringVariable("other.defined.test"),
+				"network_id": config.StringVariable("network-id"),
+				"role_id":    config.StringVariable("role-id"),
+				"tags":       acc.Strings(),
+			},
+			ExpectError: regexp.MustCompile(`Name "other.defined.test" is already used by lighthouse "host-OTHER"`),
+		},
+	),
+	Entry("assert hosts can reuse names used in other networks",
+		resource.TestStep{
+			PreConfig: func() {
+				Expect(harness.Server.Hosts.Add(fakeserver.Host{Host: definednet.Host{
+					ID:        "host-OTHER",
+					NetworkID: "updated-network-id",
+					Name:      "host.defined.test",
+				}})).To(Succeed())
+			},
+			ConfigFile: acc.Fixture("host.tf"),
+			ConfigVariables: config.Variables{
+				"name":       config.StringVariable("host.defined.test"),
+				"network_id": config.StringVariable("network-id"),
+				"role_id":    config.StringVariable("role-id"),
+				"tags":       acc.Strings(),
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("definednet_host.test", tfjsonpath.New("name"), knownvalue.StringExact("host.defined.test")),
+			},
+		},
+	),
+)
+
+// movedHostID asserts hosts keep their ID, when moved between resource types.
+var movedHostID = statecheck.CompareValue(compare.ValuesSame())
+
+var _ = DescribeTable("host blocking",
+	func(steps ...resource.TestStep) {
+		harness.Test(steps...)
+	},
+	Entry("assert hosts are blocked and unblocked",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("host.tf"),
+			ConfigVariables: config.Variables{
+				"name":       config.StringVariable("host.defined.test"),
+				"network_id": config.StringVariable("network-id"),
+				"role_id":    config.StringVariable("role-id"),
+				"tags":       acc.Strings(),
+				"blocked":    config.BoolVariable(true),
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("definednet_host.test", tfjsonpath.New("blocked"), knownvalue.Bool(true)),
+			},
+			Check: expectBlocked(true),
+		},
+		resource.TestStep{
+			ConfigFile: acc.Fixture("host.tf"),
+			ConfigVariables: config.Variables{
+				"name":       config.StringVariable("host.defined.test"),
+				"network_id": config.StringVariable("network-id"),
+				"role_id":    config.StringVariable("role-id"),
+				"tags":       acc.Strings(),
+				"blocked":    config.BoolVariable(false),
+			},
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction("definednet_host.test", plancheck.ResourceActionUpdate),
+				},
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("definednet_host.test", tfjsonpath.New("blocked"), knownvalue.Bool(false)),
+			},
+			Check: expectBlocked(false),
+		},
+	),
+	Entry("assert hosts blocked on Defined.net are reflected on read",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("host.tf"),
+			ConfigVariables: config.Variables{
+				"name":       config.StringVariable("host.defined.test"),
+				"network_id": config.StringVariable("network-id"),
+				"role_id":    config.StringVariable("role-id"),
+				"tags":       acc.Strings(),
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("definednet_host.test", tfjsonpath.New("blocked"), knownvalue.Bool(false)),
+			},
+		},
+		resource.TestStep{
+			PreConfig: func() {
+				for _, host := range harness.Server.Hosts.List() {
+					host.Host.IsBlocked = true
+					Expect(harness.Server.Hosts.Replace(host)).To(Succeed())
+				}
+			},
+			ConfigFile: acc.Fixture("host.tf"),
+			ConfigVariables: config.Variables{
+				"name":       config.StringVariable("host.defined.test"),
+				"network_id": config.StringVariable("network-id"),
+				"role_id":    config.StringVariable("role-id"),
+				"tags":       acc.Strings(),
+				"blocked":    config.BoolVariable(true),
+			},
+			PlanOnly: true,
+		},
+	),
+)
+
+var _ = DescribeTable("host deletion protection",
+	func(steps ...resource.TestStep) {
+		harness.Test(steps...)
+	},
+	Entry("assert protected hosts can not be deleted",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("host.tf"),
+			ConfigVariables: config.Variables{
+				"name":                config.StringVariable("host.defined.test"),
+				"network_id":          config.StringVariable("network-id"),
+				"role_id":             config.StringVariable("role-id"),
+				"tags":                acc.Strings(),
+				"deletion_protection": config.BoolVariable(true),
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("definednet_host.test", tfjsonpath.New("deletion_protection"), knownvalue.Bool(true)),
+			},
+		},
+		resource.TestStep{
+			ConfigFile: acc.Fixture("host.tf"),
+			ConfigVariables: config.Variables{
+				"name":                config.StringVariable("host.defined.test"),
+				"network_id":          config.StringVariable("network-id"),
+				"role_id":             config.StringVariable("role-id"),
+				"tags":                acc.Strings(),
+				"deletion_protection": config.BoolVariable(true),
+			},
+			Destroy:     true,
+			ExpectError: regexp.MustCompile(`Deletion Protection Enabled`),
+		},
+		resource.TestStep{
+			ConfigFile: acc.Fixture("host.tf"),
+			ConfigVariables: config.Variables{
+				"name":                config.StringVariable("host.defined.test"),
+				"network_id":          config.StringVariable("network-id"),
+				"role_id":             config.StringVariable("role-id"),
+				"tags":                acc.Strings(),
+				"deletion_protection": config.BoolVariable(false),
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("definednet_host.test", tfjsonpath.New("deletion_protection"), knownvalue.Bool(false)),
+			},
+		},
+	),
+	Entry("assert deletion protection is disabled by default",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("host.tf"),
+			ConfigVariables: config.Variables{
+				"name":       config.StringVariable("host.defined.test"),
+				"network_id": config.StringVariable("network-id"),
+				"role_id":    config.StringVariable("role-id"),
+				"tags":       acc.Strings(),
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("definednet_host.test", tfjsonpath.New("deletion_protection"), knownvalue.Bool(false)),
+			},
+		},
+	),
+)
+
+var _ = DescribeTable("host kind validation",
+	func(steps ...resource.TestStep) {
+		harness.Run(resource.TestCase{
+			TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+				// Moving resources between resource types requires Terraform 1.8.0 or later.
+				tfversion.SkipBelow(tfversion.Version1_8_0),
+			},
+			Steps: steps,
+		})
+	},
+	Entry("assert importing lighthouses as hosts fails",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("host.tf"),
+			ConfigVariables: config.Variables{
+				"name":       config.StringVariable("host.defined.test"),
+				"network_id": config.StringVariable("network-id"),
+				"role_id":    config.StringVariable("role-id"),
+				"tags":       acc.Strings("tag:one"),
+			},
+		},
+		resource.TestStep{
+			PreConfig: func() {
+				Expect(harness.Server.Hosts.Add(fakeserver.Host{Host: definednet.Host{
+					ID:           "host-OTHER",
+					NetworkID:    "network-id",
+					Name:         "other.defined.test",
+					IsLighthouse: true,
+				}})).To(Succeed())
+			},
+			ConfigFile: acc.Fixture("host.tf"),
+			ConfigVariables: config.Variables{
+				"name":       config.StringVariable("host.defined.test"),
+				"network_id": config.StringVariable("network-id"),
+				"role_id":    config.StringVariable("role-id"),
+				"tags":       acc.Strings("tag:one"),
+			},
+			ResourceName:  "definednet_host.test",
+			ImportState:   true,
+			ImportStateId: "host-OTHER",
+			ExpectError:   regexp.MustCompile(`Host "host-OTHER" is a lighthouse, expected a host`),
+		},
+	),
+	Entry("assert hosts promoted to lighthouses fail to refresh",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("host.tf"),
+			ConfigVariables: config.Variables{
+				"name":       config.StringVariable("host.defined.test"),
+				"network_id": config.StringVariable("network-id"),
+				"role_id":    config.StringVariable("role-id"),
+				"tags":       acc.Strings("tag:one"),
+			},
+		},
+		resource.TestStep{
+			PreConfig: func() {
+				for _, host := range harness.Server.Hosts.List() {
+					host.Host.IsLighthouse = true
+					host.Host.StaticAddresses = []string{"127.0.0.1:8484"}
+					host.Host.ListenPort = 8484
+					Expect(harness.Server.Hosts.Replace(host)).To(Succeed())
+				}
+			},
+			ConfigFile: acc.Fixture("host.tf"),
+			ConfigVariables: config.Variables{
+				"name":       config.StringVariable("host.defined.test"),
+				"network_id": config.StringVariable("network-id"),
+				"role_id":    config.StringVariable("role-id"),
+				"tags":       acc.Strings("tag:one"),
+			},
+			ExpectError: regexp.MustCompile(`(?s)Unexpected Host Kind.*is a lighthouse, expected a host`),
+		},
+		resource.TestStep{
+			PreConfig: func() {
+				for _, host := range harness.Server.Hosts.List() {
+					host.Host.IsLighthouse = false
+					host.Host.StaticAddresses = nil
+					host.Host.ListenPort = 0
+					Expect(harness.Server.Hosts.Replace(host)).To(Succeed())
+				}
+			},
+			ConfigFile: acc.Fixture("host.tf"),
+			ConfigVariables: config.Variables{
+				"name":       config.StringVariable("host.defined.test"),
+				"network_id": config.StringVariable("network-id"),
+				"role_id":    config.StringVariable("role-id"),
+				"tags":       acc.Strings("tag:one"),
+			},
+		},
+	),
+	Entry("assert lighthouses demoted to hosts are moved",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("lighthouse.tf"),
+			ConfigVariables: config.Variables{
+				"name":           config.StringVariable("host.defined.test"),
+				"network_id":     config.StringVariable("network-id"),
+				"role_id":        config.StringVariable("role-id"),
+				"listen_port":    config.IntegerVariable(8484),
+				"static_address": config.ListVariable(config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")})),
+				"tags":           acc.Strings("tag:one"),
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				movedHostID.AddStateValue("definednet_lighthouse.test", tfjsonpath.New("id")),
+			},
+		},
+		resource.TestStep{
+			PreConfig: func() {
+				for _, host := range harness.Server.Hosts.List() {
+					host.Host.IsLighthouse = false
+					host.Host.StaticAddresses = nil
+					host.Host.ListenPort = 0
+					Expect(harness.Server.Hosts.Replace(host)).To(Succeed())
+				}
+			},
+			ConfigFile: acc.Fixture("host_moved.tf"),
+			ConfigVariables: config.Variables{
+				"name":       config.StringVariable("host.defined.test"),
+				"network_id": config.StringVariable("network-id"),
+				"role_id":    config.StringVariable("role-id"),
+				"tags":       acc.Strings("tag:one"),
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				movedHostID.AddStateValue("definednet_host.test", tfjsonpath.New("id")),
+			},
 		},
 	),
 )
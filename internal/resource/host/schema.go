@@ -3,20 +3,26 @@ package host
 import (
 	_ "embed"
 
-	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/customtypes"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/deletionprotection"
 	"github.com/sendsmaily/terraform-provider-definednet/internal/validation"
 )
 
 // Schema is the host resource's schema.
 var Schema = schema.Schema{
+	Version:             1,
 	MarkdownDescription: resourceDescription,
 	Attributes: map[string]schema.Attribute{
 		"name": schema.StringAttribute{
@@ -33,17 +39,24 @@ var Schema = schema.Schema{
 				stringplanmodifier.RequiresReplace(),
 			},
 		},
+		"blocked": schema.BoolAttribute{
+			Description: "Block the host on Defined.net, revoking its certificate",
+			Optional:    true,
+			Computed:    true,
+			Default:     booldefault.StaticBool(false),
+		},
+		"deletion_protection": deletionprotection.Attribute("host"),
 		"role_id": schema.StringAttribute{
 			Description: "Host's role ID on Defined.net",
 			Optional:    true,
 		},
-		"tags": schema.ListAttribute{
+		"tags": schema.SetAttribute{
 			Description: "Host's tags on Defined.net",
 			ElementType: types.StringType,
+			CustomType:  customtypes.NewTagSetType(),
 			Optional:    true,
-			Validators: []validator.List{
-				listvalidator.UniqueValues(),
-				listvalidator.ValueStringsAre(validation.HostTag()),
+			Validators: []validator.Set{
+				setvalidator.ValueStringsAre(validation.HostTag()),
 			},
 		},
 		"id": schema.StringAttribute{
@@ -126,5 +139,38 @@ var Schema = schema.Schema{
 	},
 }
 
+// IdentitySchema is the host resource's identity schema.
+var IdentitySchema = identityschema.Schema{
+	Attributes: map[string]identityschema.Attribute{
+		"id": identityschema.StringAttribute{
+			Description:       "Host's ID",
+			RequiredForImport: true,
+		},
+	},
+}
+
+// ListSchema is the host list resource's configuration schema.
+var ListSchema = listschema.Schema{
+	MarkdownDescription: listDescription,
+	Attributes: map[string]listschema.Attribute{
+		"network_id": listschema.StringAttribute{
+			Description: "List hosts enrolled in the network",
+			Optional:    true,
+		},
+		"role_id": listschema.StringAttribute{
+			Description: "List hosts having the role",
+			Optional:    true,
+		},
+		"tags": listschema.ListAttribute{
+			Description: "List hosts having all the tags",
+			ElementType: types.StringType,
+			Optional:    true,
+		},
+	},
+}
+
 //go:embed docs/resource.md
 var resourceDescription string
+
+//go:embed docs/list.md
+var listDescription string
@@ -8,19 +8,28 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/customtypes"
 	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/deletionprotection"
 )
 
 // State is the host resource's state.
 type State struct {
-	ID             types.String `tfsdk:"id"`
-	NetworkID      types.String `tfsdk:"network_id"`
-	RoleID         types.String `tfsdk:"role_id"`
-	Name           types.String `tfsdk:"name"`
-	IPAddress      types.String `tfsdk:"ip_address"`
-	Tags           types.List   `tfsdk:"tags"`
-	EnrollmentCode types.String `tfsdk:"enrollment_code"`
-	Metrics        *Metrics     `tfsdk:"metrics"`
+	ID                 types.String       `tfsdk:"id"`
+	NetworkID          types.String       `tfsdk:"network_id"`
+	RoleID             types.String       `tfsdk:"role_id"`
+	Name               types.String       `tfsdk:"name"`
+	IPAddress          types.String       `tfsdk:"ip_address"`
+	Tags               customtypes.TagSet `tfsdk:"tags"`
+	EnrollmentCode     types.String       `tfsdk:"enrollment_code"`
+	Metrics            *Metrics           `tfsdk:"metrics"`
+	Blocked            types.Bool         `tfsdk:"blocked"`
+	DeletionProtection types.Bool         `tfsdk:"deletion_protection"`
+}
+
+// Identity is the host resource's identity.
+type Identity struct {
+	ID types.String `tfsdk:"id"`
 }
 
 // Metrics is the host metrics exporter's state.
@@ -33,6 +42,25 @@ type Metrics struct {
 	EnableExtraMetrics types.Bool   `tfsdk:"enable_extra_metrics"`
 }
 
+// ConfigOverrides encodes the metrics exporter's configuration as Defined.net config overrides.
+//
+// Disabled metrics exporters have no config overrides.
+func (m *Metrics) ConfigOverrides() []definednet.ConfigOverride {
+	if lo.IsNil(m) || !m.Enabled.ValueBool() {
+		return nil
+	}
+
+	return []definednet.ConfigOverride{
+		{Key: "stats.type", Value: "prometheus"},
+		{Key: "stats.listen", Value: m.Listen.ValueString()},
+		{Key: "stats.path", Value: m.Path.ValueString()},
+		{Key: "stats.namespace", Value: m.Namespace.ValueString()},
+		{Key: "stats.subsystem", Value: m.Subsystem.ValueString()},
+		{Key: "stats.message_metrics", Value: m.EnableExtraMetrics.ValueBool()},
+		{Key: "stats.interval", Value: "60s"},
+	}
+}
+
 // ApplyEnrollment applies Defined.net host enrollment information to the state.
 func (s *State) ApplyEnrollment(ctx context.Context, enrollment *definednet.Enrollment) (diags diag.Diagnostics) {
 	diags.Append(s.ApplyHost(ctx, &enrollment.Host)...)
@@ -44,6 +72,8 @@ func (s *State) ApplyEnrollment(ctx context.Context, enrollment *definednet.Enro
 // ApplyHost applies Defined.net host information to the state.
 func (s *State) ApplyHost(ctx context.Context, host *definednet.Host) (diags diag.Diagnostics) {
 	s.ID = types.StringValue(host.ID)
+	s.DeletionProtection = deletionprotection.Default(s.DeletionProtection)
+	s.Blocked = types.BoolValue(host.IsBlocked)
 	s.IPAddress = types.StringValue(host.IPAddress)
 	s.Name = types.StringValue(host.Name)
 	s.NetworkID = types.StringValue(host.NetworkID)
@@ -53,9 +83,9 @@ func (s *State) ApplyHost(ctx context.Context, host *definednet.Host) (diags dia
 		s.RoleID = types.StringValue(host.RoleID)
 	}
 
-	s.Tags = types.ListNull(types.StringType)
+	s.Tags = customtypes.NewTagSetNull()
 	if len(host.Tags) > 0 {
-		s.Tags, diags = types.ListValueFrom(ctx, types.StringType, host.Tags)
+		s.Tags, diags = customtypes.NewTagSetValueFrom(ctx, host.Tags)
 	}
 
 	metricsConfig := lo.Reduce(host.ConfigOverrides, func(m Metrics, o definednet.ConfigOverride, _ int) Metrics {
@@ -0,0 +1,77 @@
+package host_test
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/resource/host"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/generate"
+	"pgregory.net/rapid"
+)
+
+var _ = Describe("host state mapping", func() {
+	// decode round-trips the host through JSON, as config override values are decoded from the HTTP API's responses.
+	decode := func(t *rapid.T, h definednet.Host) *definednet.Host {
+		data, err := json.Marshal(h)
+		NewWithT(t).Expect(err).NotTo(HaveOccurred())
+
+		var out definednet.Host
+		NewWithT(t).Expect(json.Unmarshal(data, &out)).To(Succeed())
+
+		return &out
+	}
+
+	Specify("hosts round-trip through the state", func() {
+		rapid.Check(GinkgoT(), func(t *rapid.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			h := generate.Host().Draw(t, "host")
+
+			var state host.State
+			g.Expect(state.ApplyHost(ctx, decode(t, h))).To(BeEmpty())
+
+			var tags []string
+			g.Expect(state.Tags.ElementsAs(ctx, &tags, false)).To(BeEmpty())
+
+			g.Expect(state.ID.ValueString()).To(Equal(h.ID))
+			g.Expect(state.NetworkID.ValueString()).To(Equal(h.NetworkID))
+			g.Expect(state.RoleID.ValueString()).To(Equal(h.RoleID))
+			g.Expect(state.Name.ValueString()).To(Equal(h.Name))
+			g.Expect(state.IPAddress.ValueString()).To(Equal(h.IPAddress))
+			g.Expect(state.Blocked.ValueBool()).To(Equal(h.IsBlocked))
+			g.Expect(tags).To(ConsistOf(h.Tags))
+			g.Expect(state.Metrics.ConfigOverrides()).To(Equal(h.ConfigOverrides))
+		})
+	})
+
+	Specify("metrics round-trip through config overrides", func() {
+		rapid.Check(GinkgoT(), func(t *rapid.T) {
+			g := NewWithT(t)
+			metrics := host.Metrics{
+				Enabled:            types.BoolValue(true),
+				Listen:             types.StringValue(rapid.String().Draw(t, "listen")),
+				Path:               types.StringValue(rapid.String().Draw(t, "path")),
+				Namespace:          types.StringValue(rapid.String().Draw(t, "namespace")),
+				Subsystem:          types.StringValue(rapid.String().Draw(t, "subsystem")),
+				EnableExtraMetrics: types.BoolValue(rapid.Bool().Draw(t, "enableExtraMetrics")),
+			}
+
+			var state host.State
+			g.Expect(state.ApplyHost(context.Background(), decode(t, definednet.Host{
+				ConfigOverrides: metrics.ConfigOverrides(),
+			}))).To(BeEmpty())
+
+			g.Expect(state.Metrics).To(PointTo(Equal(metrics)))
+		})
+	})
+
+	Specify("disabled metrics have no config overrides", func() {
+		Expect((*host.Metrics)(nil).ConfigOverrides()).To(BeNil())
+		Expect((&host.Metrics{Enabled: types.BoolValue(false)}).ConfigOverrides()).To(BeNil())
+	})
+})
@@ -1,31 +1,52 @@
 package host_test
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
-	tfprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
-	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
-	"github.com/sendsmaily/terraform-provider-definednet/internal/provider"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/acc"
 	fakeserver "github.com/sendsmaily/terraform-provider-definednet/internal/testing/server"
 )
 
-var providerFactory func() tfprovider.Provider
+var harness *acc.Harness
 
 var _ = BeforeEach(func() {
-	server := fakeserver.New()
-	DeferCleanup(server.Close)
-
-	providerFactory = provider.New(
-		func(string, string, string) (definednet.Client, error) {
-			return server.Client(), nil
-		},
-		"test",
-	)
+	harness = acc.New()
+
+	// Seed the networks and roles referenced by the tests.
+	Expect(harness.Server.Networks.Add(fakeserver.Network{ID: "network-id", Name: "test-network"})).To(Succeed())
+	Expect(harness.Server.Networks.Add(fakeserver.Network{ID: "updated-network-id", Name: "updated-test-network"})).To(Succeed())
+	Expect(harness.Server.Roles.Add(fakeserver.Role{ID: "role-id", Name: "test: Role"})).To(Succeed())
+	Expect(harness.Server.Roles.Add(fakeserver.Role{ID: "updated-role-id", Name: "test: Updated role"})).To(Succeed())
 })
 
 func TestSuite(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "internal/resource/host")
 }
+
+// modifyRemotely is a plan check modifying the fake server's objects between planning and applying.
+type modifyRemotely func()
+
+func (f modifyRemotely) CheckPlan(context.Context, plancheck.CheckPlanRequest, *plancheck.CheckPlanResponse) {
+	f()
+}
+
+// expectBlocked asserts the fake server's hosts are blocked or unblocked.
+func expectBlocked(blocked bool) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		for _, host := range harness.Server.Hosts.List() {
+			if host.Host.IsBlocked != blocked {
+				return fmt.Errorf("host %q: expected blocked=%t, got %t", host.Host.ID, blocked, host.Host.IsBlocked)
+			}
+		}
+
+		return nil
+	}
+}
@@ -18,9 +18,22 @@ variable "tags" {
   type = list(string)
 }
 
+variable "blocked" {
+  type    = bool
+  default = null
+}
+
+variable "deletion_protection" {
+  type    = bool
+  default = null
+}
+
 resource "definednet_host" "test" {
   name       = var.name
   network_id = var.network_id
   role_id    = var.role_id
   tags       = var.tags
+  blocked    = var.blocked
+
+  deletion_protection = var.deletion_protection
 }
@@ -0,0 +1,31 @@
+provider "definednet" {
+  token = "supersecret"
+}
+
+variable "name" {
+  type = string
+}
+
+variable "network_id" {
+  type = string
+}
+
+variable "role_id" {
+  type = string
+}
+
+variable "tags" {
+  type = list(string)
+}
+
+moved {
+  from = definednet_lighthouse.test
+  to   = definednet_host.test
+}
+
+resource "definednet_host" "test" {
+  name       = var.name
+  network_id = var.network_id
+  role_id    = var.role_id
+  tags       = var.tags
+}
@@ -0,0 +1,84 @@
+package host
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/customtypes"
+)
+
+// schemaV0 is the host resource's schema version 0, declaring tags as a list.
+var schemaV0 = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"name":            schema.StringAttribute{Required: true},
+		"network_id":      schema.StringAttribute{Required: true},
+		"role_id":         schema.StringAttribute{Optional: true},
+		"tags":            schema.ListAttribute{ElementType: types.StringType, Optional: true},
+		"id":              schema.StringAttribute{Computed: true},
+		"ip_address":      schema.StringAttribute{Computed: true},
+		"enrollment_code": schema.StringAttribute{Computed: true, Sensitive: true},
+	},
+	Blocks: map[string]schema.Block{
+		"metrics": schema.SingleNestedBlock{
+			Attributes: map[string]schema.Attribute{
+				"enabled":              schema.BoolAttribute{Optional: true},
+				"listen":               schema.StringAttribute{Optional: true, Computed: true},
+				"path":                 schema.StringAttribute{Optional: true, Computed: true},
+				"namespace":            schema.StringAttribute{Optional: true, Computed: true},
+				"subsystem":            schema.StringAttribute{Optional: true, Computed: true},
+				"enable_extra_metrics": schema.BoolAttribute{Optional: true, Computed: true},
+			},
+		},
+	},
+}
+
+// stateV0 is the host resource's state in schema version 0.
+type stateV0 struct {
+	ID             types.String `tfsdk:"id"`
+	NetworkID      types.String `tfsdk:"network_id"`
+	RoleID         types.String `tfsdk:"role_id"`
+	Name           types.String `tfsdk:"name"`
+	IPAddress      types.String `tfsdk:"ip_address"`
+	Tags           types.List   `tfsdk:"tags"`
+	EnrollmentCode types.String `tfsdk:"enrollment_code"`
+	Metrics        *Metrics     `tfsdk:"metrics"`
+}
+
+// UpgradeState returns the resource's state upgraders keyed by the schema version they upgrade from.
+func (r *Resource) UpgradeState(context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   &schemaV0,
+			StateUpgrader: upgradeStateV0,
+		},
+	}
+}
+
+// upgradeStateV0 upgrades the state from schema version 0, converting tags from a list to a set.
+func upgradeStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior stateV0
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tags, diags := customtypes.ListToSet(ctx, prior.Tags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, State{
+		ID:             prior.ID,
+		NetworkID:      prior.NetworkID,
+		RoleID:         prior.RoleID,
+		Name:           prior.Name,
+		IPAddress:      prior.IPAddress,
+		Tags:           customtypes.TagSet{SetValue: tags},
+		EnrollmentCode: prior.EnrollmentCode,
+		Metrics:        prior.Metrics,
+	})...)
+}
@@ -0,0 +1,104 @@
+package host_test
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/resource/host"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/acc"
+)
+
+var _ = DescribeTable("host state upgrades",
+	func(ctx SpecContext, prior string, expect func(host.State)) {
+		srv, err := harness.ProviderFactories()[acc.ProviderName]()
+		Expect(err).NotTo(HaveOccurred())
+
+		resp, err := srv.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+			TypeName: "definednet_host",
+			Version:  0,
+			RawState: &tfprotov6.RawState{JSON: []byte(prior)},
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Diagnostics).To(BeEmpty())
+
+		raw, err := resp.UpgradedState.Unmarshal(host.Schema.Type().TerraformType(ctx))
+		Expect(err).NotTo(HaveOccurred())
+
+		var state host.State
+		Expect(tfsdk.State{Schema: host.Schema, Raw: raw}.Get(ctx, &state)).To(BeEmpty())
+
+		expect(state)
+	},
+	Entry("assert tags are converted to a set",
+		`{
+			"id": "host-id",
+			"network_id": "network-id",
+			"role_id": "role-id",
+			"name": "host.defined.test",
+			"ip_address": "10.0.0.1",
+			"tags": ["tag:one", "tag:two"],
+			"enrollment_code": "enrollment-code",
+			"metrics": {
+				"enabled": true,
+				"listen": "127.0.0.1:8080",
+				"path": "/metrics",
+				"namespace": "nebula",
+				"subsystem": "host",
+				"enable_extra_metrics": false
+			}
+		}`,
+		func(state host.State) {
+			Expect(state.ID.ValueString()).To(Equal("host-id"))
+			Expect(state.NetworkID.ValueString()).To(Equal("network-id"))
+			Expect(state.RoleID.ValueString()).To(Equal("role-id"))
+			Expect(state.Name.ValueString()).To(Equal("host.defined.test"))
+			Expect(state.IPAddress.ValueString()).To(Equal("10.0.0.1"))
+			Expect(state.EnrollmentCode.ValueString()).To(Equal("enrollment-code"))
+			Expect(state.Metrics).NotTo(BeNil())
+			Expect(state.Metrics.Enabled.ValueBool()).To(BeTrue())
+			Expect(state.Metrics.Subsystem.ValueString()).To(Equal("host"))
+			Expect(state.Tags.SetValue.Equal(types.SetValueMust(types.StringType, []attr.Value{
+				types.StringValue("tag:two"),
+				types.StringValue("tag:one"),
+			}))).To(BeTrue())
+		},
+	),
+	Entry("assert duplicate tags are dropped",
+		`{
+			"id": "host-id",
+			"network_id": "network-id",
+			"role_id": null,
+			"name": "host.defined.test",
+			"ip_address": "10.0.0.1",
+			"tags": ["tag:one", "tag:one"],
+			"enrollment_code": "enrollment-code",
+			"metrics": null
+		}`,
+		func(state host.State) {
+			Expect(state.Tags.SetValue.Equal(types.SetValueMust(types.StringType, []attr.Value{
+				types.StringValue("tag:one"),
+			}))).To(BeTrue())
+		},
+	),
+	Entry("assert missing tags remain null",
+		`{
+			"id": "host-id",
+			"network_id": "network-id",
+			"role_id": null,
+			"name": "host.defined.test",
+			"ip_address": "10.0.0.1",
+			"tags": null,
+			"enrollment_code": "enrollment-code",
+			"metrics": null
+		}`,
+		func(state host.State) {
+			Expect(state.Tags.IsNull()).To(BeTrue())
+			Expect(state.RoleID.IsNull()).To(BeTrue())
+			Expect(state.Metrics).To(BeNil())
+		},
+	),
+)
@@ -0,0 +1,122 @@
+package lighthouse
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/providerdata"
+)
+
+// NewListResource creates a Defined.net Nebula lighthouse list resource.
+func NewListResource() list.ListResource {
+	return &ListResource{}
+}
+
+// ListResource is Defined.net Nebula lighthouse list resource.
+type ListResource struct {
+	client definednet.Client
+}
+
+var _ list.ListResource = (*ListResource)(nil)
+var _ list.ListResourceWithConfigure = (*ListResource)(nil)
+
+// ListFilter is the lighthouse list resource's configuration.
+type ListFilter struct {
+	NetworkID types.String `tfsdk:"network_id"`
+	RoleID    types.String `tfsdk:"role_id"`
+	Tags      types.List   `tfsdk:"tags"`
+}
+
+// Configure configures the list resource.
+func (r *ListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerdata.Data)
+	if !ok {
+		resp.Diagnostics.AddError("Invalid Configuration", "The provider specifies an invalid provider data type")
+		return
+	}
+
+	r.client = data.Client
+}
+
+// Metadata returns the list resource's metadata.
+func (r *ListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_lighthouse", req.ProviderTypeName)
+}
+
+// ListResourceConfigSchema returns the list resource's configuration schema.
+func (r *ListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = ListSchema
+}
+
+// List lists Nebula lighthouses on Defined.net control plane matching the filter.
+func (r *ListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var filter ListFilter
+
+	if diags := req.Config.Get(ctx, &filter); diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var tags []string
+	if diags := filter.Tags.ElementsAs(ctx, &tags, false); diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	lighthouses, err := definednet.ListHosts(ctx, r.client, definednet.ListHostsRequest{})
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Request Failure", err.Error())
+
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	lighthouses = lo.Filter(lighthouses, func(lighthouse definednet.Host, _ int) bool {
+		return lighthouse.Kind() == definednet.HostKindLighthouse &&
+			(filter.NetworkID.IsNull() || lighthouse.NetworkID == filter.NetworkID.ValueString()) &&
+			(filter.RoleID.IsNull() || lighthouse.RoleID == filter.RoleID.ValueString()) &&
+			lo.Every(lighthouse.Tags, tags)
+	})
+
+	if req.Limit > 0 && int64(len(lighthouses)) > req.Limit {
+		lighthouses = lighthouses[:req.Limit]
+	}
+
+	tflog.Trace(ctx, "listed Defined.net lighthouses", map[string]any{
+		"network_id": filter.NetworkID.String(),
+		"role_id":    filter.RoleID.String(),
+		"tags":       filter.Tags.String(),
+		"count":      len(lighthouses),
+	})
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, lighthouse := range lighthouses {
+			result := req.NewListResult(ctx)
+			result.DisplayName = lighthouse.Name
+
+			result.Diagnostics.Append(result.Identity.Set(ctx, Identity{ID: types.StringValue(lighthouse.ID)})...)
+
+			if req.IncludeResource {
+				var state State
+				result.Diagnostics.Append(state.ApplyHost(ctx, &lighthouse)...)
+				result.Diagnostics.Append(result.Resource.Set(ctx, state)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package lighthouse_test
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck/queryfilter"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/acc"
+	fakeserver "github.com/sendsmaily/terraform-provider-definednet/internal/testing/server"
+)
+
+var _ = DescribeTable("lighthouse discovery",
+	func(query resource.TestStep) {
+		harness.Run(resource.TestCase{
+			TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+				tfversion.SkipBelow(tfversion.Version1_14_0),
+			},
+			Steps: []resource.TestStep{
+				{
+					PreConfig: func() {
+						Expect(harness.Server.Hosts.Add(fakeserver.Host{Host: definednet.Host{
+							ID:              "host-OTHER",
+							NetworkID:       "other-network-id",
+							Name:            "other.defined.test",
+							StaticAddresses: []string{"172.16.0.2:4242"},
+							ListenPort:      4242,
+							IsLighthouse:    true,
+							Tags:            []string{"tag:one"},
+						}})).To(Succeed())
+
+						Expect(harness.Server.Hosts.Add(fakeserver.Host{Host: definednet.Host{
+							ID:        "host-HOST",
+							NetworkID: "network-id",
+							Name:      "host.defined.test",
+						}})).To(Succeed())
+					},
+					ConfigFile: acc.Fixture("lighthouse.tf"),
+					ConfigVariables: config.Variables{
+						"name":        config.StringVariable("lighthouse.defined.test"),
+						"network_id":  config.StringVariable("network-id"),
+						"role_id":     config.StringVariable("role-id"),
+						"listen_port": config.IntegerVariable(8484),
+						"static_address": config.ListVariable(
+							config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")}),
+						),
+						"tags": acc.Strings("tag:one", "tag:two"),
+					},
+				},
+				query,
+			},
+		})
+	},
+	Entry("assert lighthouses are listed without hosts",
+		resource.TestStep{
+			Query: true,
+			Config: `
+				provider "definednet" {
+				  token = "supersecret"
+				}
+
+				list "definednet_lighthouse" "test" {
+				  provider = definednet
+				}
+			`,
+			QueryResultChecks: []querycheck.QueryResultCheck{
+				querycheck.ExpectLength("definednet_lighthouse.test", 2),
+				querycheck.ExpectIdentity("definednet_lighthouse.test", map[string]knownvalue.Check{
+					"id": knownvalue.StringExact("host-OTHER"),
+				}),
+			},
+		},
+	),
+	Entry("assert lighthouses are filtered by network, role and tags",
+		resource.TestStep{
+			Query: true,
+			Config: `
+				provider "definednet" {
+				  token = "supersecret"
+				}
+
+				list "definednet_lighthouse" "test" {
+				  provider = definednet
+
+				  config {
+				    network_id = "network-id"
+				    role_id    = "role-id"
+				    tags       = ["tag:two"]
+				  }
+				}
+			`,
+			QueryResultChecks: []querycheck.QueryResultCheck{
+				querycheck.ExpectLength("definednet_lighthouse.test", 1),
+				querycheck.ExpectResourceDisplayName(
+					"definednet_lighthouse.test",
+					queryfilter.ByDisplayName(knownvalue.StringExact("lighthouse.defined.test")),
+					knownvalue.StringExact("lighthouse.defined.test"),
+				),
+			},
+		},
+	),
+	Entry("assert listed lighthouses include their configuration",
+		resource.TestStep{
+			Query: true,
+			Config: `
+				provider "definednet" {
+				  token = "supersecret"
+				}
+
+				list "definednet_lighthouse" "test" {
+				  provider         = definednet
+				  include_resource = true
+				}
+			`,
+			QueryResultChecks: []querycheck.QueryResultCheck{
+				querycheck.ExpectResourceKnownValues(
+					"definednet_lighthouse.test",
+					queryfilter.ByResourceIdentity(map[string]knownvalue.Check{
+						"id": knownvalue.StringExact("host-OTHER"),
+					}),
+					[]querycheck.KnownValueCheck{
+						{Path: tfjsonpath.New("name"), KnownValue: knownvalue.StringExact("other.defined.test")},
+						{Path: tfjsonpath.New("listen_port"), KnownValue: knownvalue.Int32Exact(4242)},
+						{Path: tfjsonpath.New("static_address"), KnownValue: knownvalue.SetExact([]knownvalue.Check{
+							knownvalue.ObjectExact(map[string]knownvalue.Check{
+								"host": knownvalue.StringExact("172.16.0.2"),
+								"port": knownvalue.Null(),
+							}),
+						})},
+					},
+				),
+			},
+		},
+	),
+)
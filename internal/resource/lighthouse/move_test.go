@@ -0,0 +1,99 @@
+package lighthouse_test
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/resource/host"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/resource/lighthouse"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/acc"
+)
+
+var _ = Describe("lighthouse state moves", func() {
+	move := func(ctx SpecContext, req *tfprotov6.MoveResourceStateRequest) *tfprotov6.MoveResourceStateResponse {
+		srv, err := harness.ProviderFactories()[acc.ProviderName]()
+		Expect(err).NotTo(HaveOccurred())
+
+		req.TargetTypeName = "definednet_lighthouse"
+
+		resp, err := srv.MoveResourceState(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		return resp
+	}
+
+	source := `{
+		"id": "host-id",
+		"network_id": "network-id",
+		"role_id": "role-id",
+		"name": "host.defined.test",
+		"ip_address": "10.0.0.1",
+		"tags": ["tag:one"],
+		"enrollment_code": "enrollment-code",
+		"metrics": null,
+		"blocked": true,
+		"deletion_protection": false
+	}`
+
+	Specify("hosts are moved to lighthouses", func(ctx SpecContext) {
+		resp := move(ctx, &tfprotov6.MoveResourceStateRequest{
+			SourceProviderAddress: "registry.terraform.io/sendsmaily/definednet",
+			SourceTypeName:        "definednet_host",
+			SourceSchemaVersion:   host.Schema.Version,
+			SourceState:           &tfprotov6.RawState{JSON: []byte(source)},
+		})
+
+		Expect(resp.Diagnostics).To(BeEmpty())
+
+		raw, err := resp.TargetState.Unmarshal(lighthouse.Schema.Type().TerraformType(ctx))
+		Expect(err).NotTo(HaveOccurred())
+
+		var state lighthouse.State
+		Expect(tfsdk.State{Schema: lighthouse.Schema, Raw: raw}.Get(ctx, &state)).To(BeEmpty())
+
+		Expect(state.ID.ValueString()).To(Equal("host-id"))
+		Expect(state.Name.ValueString()).To(Equal("host.defined.test"))
+		Expect(state.Blocked.ValueBool()).To(BeTrue())
+		Expect(state.Metrics).To(BeNil())
+		Expect(state.StaticAddresses.IsNull()).To(BeTrue())
+		Expect(state.StaticAddress).To(BeEmpty())
+		Expect(state.ListenPort.IsNull()).To(BeTrue())
+	})
+
+	Specify("the source schema matches the host resource's schema", func(ctx SpecContext) {
+		movers := lighthouse.NewResource().(*lighthouse.Resource).MoveState(ctx)
+		Expect(movers).To(HaveLen(1))
+
+		Expect(movers[0].SourceSchema.Type().Equal(host.Schema.Type())).To(BeTrue())
+		Expect(movers[0].SourceSchema.Version).To(Equal(host.Schema.Version))
+	})
+
+	DescribeTable("unexpected sources are rejected",
+		func(ctx SpecContext, req *tfprotov6.MoveResourceStateRequest, summary string) {
+			req.SourceState = &tfprotov6.RawState{JSON: []byte(source)}
+
+			resp := move(ctx, req)
+
+			Expect(resp.Diagnostics).To(HaveLen(1))
+			Expect(resp.Diagnostics[0].Summary).To(Equal(summary))
+			Expect(resp.TargetState).To(BeNil())
+		},
+		Entry("assert other resource types are rejected",
+			&tfprotov6.MoveResourceStateRequest{
+				SourceProviderAddress: "registry.terraform.io/sendsmaily/definednet",
+				SourceTypeName:        "definednet_role",
+				SourceSchemaVersion:   1,
+			},
+			"Unsupported Move Source",
+		),
+		Entry("assert earlier schema versions are rejected",
+			&tfprotov6.MoveResourceStateRequest{
+				SourceProviderAddress: "registry.terraform.io/sendsmaily/definednet",
+				SourceTypeName:        "definednet_host",
+				SourceSchemaVersion:   0,
+			},
+			"Unsupported Move Source Version",
+		),
+	)
+})
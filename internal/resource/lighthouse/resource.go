@@ -5,10 +5,20 @@ import (
 	_ "embed"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/conflict"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/customtypes"
 	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/deletionprotection"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/hostkind"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/importid"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/providerdata"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/resource/host"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/revision"
 )
 
 // NewResource creates a Defined.net Nebula lighthouse resource.
@@ -18,12 +28,17 @@ func NewResource() resource.Resource {
 
 // Resource is Defined.net Nebula lighthouse resource.
 type Resource struct {
-	client definednet.Client
+	client     definednet.Client
+	references *providerdata.References
 }
 
 var _ resource.Resource = (*Resource)(nil)
 var _ resource.ResourceWithConfigure = (*Resource)(nil)
+var _ resource.ResourceWithIdentity = (*Resource)(nil)
 var _ resource.ResourceWithImportState = (*Resource)(nil)
+var _ resource.ResourceWithModifyPlan = (*Resource)(nil)
+var _ resource.ResourceWithMoveState = (*Resource)(nil)
+var _ resource.ResourceWithUpgradeState = (*Resource)(nil)
 
 // Configure configures the resource.
 func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -31,13 +46,14 @@ func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, r
 		return
 	}
 
-	client, ok := req.ProviderData.(definednet.Client)
+	data, ok := req.ProviderData.(*providerdata.Data)
 	if !ok {
-		resp.Diagnostics.AddError("Invalid Configuration", "The provider specifies an invalid client type")
+		resp.Diagnostics.AddError("Invalid Configuration", "The provider specifies an invalid provider data type")
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.references = data.References
 }
 
 // Metadata returns the resource's metadata.
@@ -50,6 +66,28 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 	resp.Schema = Schema
 }
 
+// IdentitySchema returns the resource's identity schema.
+func (r *Resource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = IdentitySchema
+}
+
+// ModifyPlan validates the planned role and network references.
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.references == nil {
+		return
+	}
+
+	var networkID, roleID types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("network_id"), &networkID)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("role_id"), &roleID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.references.ValidateNetwork(ctx, path.Root("network_id"), networkID)...)
+	resp.Diagnostics.Append(r.references.ValidateRole(ctx, path.Root("role_id"), roleID)...)
+}
+
 // Create creates Nebula lighthouses on Defined.net control plane.
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var state State
@@ -59,8 +97,8 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
-	var staticAddrs []string
-	resp.Diagnostics.Append(state.StaticAddresses.ElementsAs(ctx, &staticAddrs, false)...)
+	staticAddrs, diags := state.AdvertisedAddresses(ctx)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -72,44 +110,38 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 	}
 
 	enrollment, err := definednet.CreateEnrollment(ctx, r.client, definednet.CreateEnrollmentRequest{
-		NetworkID: state.NetworkID.ValueString(),
-		RoleID:    state.RoleID.ValueString(),
-		Name:      state.Name.ValueString(),
-		StaticAddresses: lo.Map(staticAddrs, func(addr string, _ int) string {
-			return fmt.Sprintf("%s:%d", addr, state.ListenPort.ValueInt32())
-		}),
-		ListenPort:   int(state.ListenPort.ValueInt32()),
-		IsLighthouse: true,
-		IsRelay:      false,
-		Tags:         tags,
-		ConfigOverrides: func() []definednet.ConfigOverride {
-			if lo.IsNil(state.Metrics) || !state.Metrics.Enabled.ValueBool() {
-				return nil
-			}
-
-			return []definednet.ConfigOverride{
-				{Key: "stats.type", Value: "prometheus"},
-				{Key: "stats.listen", Value: state.Metrics.Listen.ValueString()},
-				{Key: "stats.path", Value: state.Metrics.Path.ValueString()},
-				{Key: "stats.namespace", Value: state.Metrics.Namespace.ValueString()},
-				{Key: "stats.subsystem", Value: state.Metrics.Subsystem.ValueString()},
-				{Key: "stats.lighthouse_metrics", Value: state.Metrics.EnableExtraMetrics.ValueBool()},
-				{Key: "stats.interval", Value: "60s"},
-			}
-		}(),
+		NetworkID:       state.NetworkID.ValueString(),
+		RoleID:          state.RoleID.ValueString(),
+		Name:            state.Name.ValueString(),
+		StaticAddresses: staticAddrs,
+		ListenPort:      int(state.ListenPort.ValueInt32()),
+		IsLighthouse:    true,
+		IsRelay:         false,
+		Tags:            tags,
+		ConfigOverrides: state.Metrics.ConfigOverrides(),
 	})
 
+	if err != nil {
+		resp.Diagnostics.Append(conflict.HostName(ctx, r.client, err, state.NetworkID.ValueString(), state.Name.ValueString())...)
+		return
+	}
+
+	blocked, err := r.setBlocked(ctx, &enrollment.Host, state.Blocked.ValueBool())
 	if err != nil {
 		resp.Diagnostics.AddError("Request Failure", err.Error())
 		return
 	}
 
+	enrollment.Host = *blocked
+
 	resp.Diagnostics.Append(state.ApplyEnrollment(ctx, enrollment)...)
+	resp.Diagnostics.Append(revision.Save(ctx, resp.Private, revision.Host(enrollment.Host))...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, Identity{ID: state.ID})...)
 
 	tflog.Trace(ctx, "created Defined.net lighthouse", map[string]any{
 		"id":               state.ID.String(),
@@ -117,16 +149,19 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		"role_id":          state.RoleID.String(),
 		"name":             state.Name.String(),
 		"listen_port":      state.ListenPort.String(),
-		"static_addresses": state.StaticAddresses.String(),
+		"static_addresses": enrollment.Host.StaticAddresses,
 		"tags":             state.Tags.String(),
 	})
 }
 
 // Delete deletes Nebula lighthouses from Defined.net control plane.
+//
+// Deletion is refused, when the lighthouse is protected from deletion.
 func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var state State
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(deletionprotection.Check("lighthouse", state.ID.ValueString(), state.DeletionProtection)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -139,6 +174,8 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 }
 
 // Read reads Nebula lighthouses from Defined.net control plane.
+//
+// Reading fails, when the lighthouse has been demoted to a host or a relay.
 func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var state State
 
@@ -156,12 +193,19 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		return
 	}
 
+	resp.Diagnostics.Append(hostkind.Check(host, definednet.HostKindLighthouse)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(state.ApplyHost(ctx, host)...)
+	resp.Diagnostics.Append(revision.Save(ctx, resp.Private, revision.Host(*host))...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, Identity{ID: state.ID})...)
 
 	tflog.Trace(ctx, "refreshed Defined.net lighthouse", map[string]any{
 		"id":               state.ID.String(),
@@ -169,12 +213,14 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		"role_id":          state.RoleID.String(),
 		"name":             state.Name.String(),
 		"listen_port":      state.ListenPort.String(),
-		"static_addresses": state.StaticAddresses.String(),
+		"static_addresses": host.StaticAddresses,
 		"tags":             state.Tags.String(),
 	})
 }
 
 // Update updates Nebula lighthouses on Defined.net control plane.
+//
+// The update is refused, when the lighthouse has been changed since it was last read.
 func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var state State
 
@@ -183,8 +229,8 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
-	var staticAddrs []string
-	resp.Diagnostics.Append(state.StaticAddresses.ElementsAs(ctx, &staticAddrs, false)...)
+	staticAddrs, diags := state.AdvertisedAddresses(ctx)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -195,43 +241,49 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
-	host, err := definednet.UpdateHost(ctx, r.client, definednet.UpdateHostRequest{
-		ID:     state.ID.ValueString(),
-		RoleID: state.RoleID.ValueString(),
-		Name:   state.Name.ValueString(),
-		StaticAddresses: lo.Map(staticAddrs, func(addr string, _ int) string {
-			return fmt.Sprintf("%s:%d", addr, state.ListenPort.ValueInt32())
-		}),
-		ListenPort: int(state.ListenPort.ValueInt32()),
-		Tags:       tags,
-		ConfigOverrides: func() []definednet.ConfigOverride {
-			if lo.IsNil(state.Metrics) || !state.Metrics.Enabled.ValueBool() {
-				return nil
-			}
+	current, err := definednet.GetHost(ctx, r.client, definednet.GetHostRequest{
+		ID: state.ID.ValueString(),
+	})
 
-			return []definednet.ConfigOverride{
-				{Key: "stats.type", Value: "prometheus"},
-				{Key: "stats.listen", Value: state.Metrics.Listen.ValueString()},
-				{Key: "stats.path", Value: state.Metrics.Path.ValueString()},
-				{Key: "stats.namespace", Value: state.Metrics.Namespace.ValueString()},
-				{Key: "stats.subsystem", Value: state.Metrics.Subsystem.ValueString()},
-				{Key: "stats.lighthouse_metrics", Value: state.Metrics.EnableExtraMetrics.ValueBool()},
-				{Key: "stats.interval", Value: "60s"},
-			}
-		}(),
+	if err != nil {
+		resp.Diagnostics.AddError("Request Failure", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(revision.Check(ctx, req.Private, "lighthouse", revision.Host(*current))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	host, err := definednet.UpdateHost(ctx, r.client, definednet.UpdateHostRequest{
+		ID:              state.ID.ValueString(),
+		RoleID:          state.RoleID.ValueString(),
+		Name:            state.Name.ValueString(),
+		StaticAddresses: staticAddrs,
+		ListenPort:      int(state.ListenPort.ValueInt32()),
+		Tags:            tags,
+		ConfigOverrides: state.Metrics.ConfigOverrides(),
 	})
 
+	if err != nil {
+		resp.Diagnostics.Append(conflict.HostName(ctx, r.client, err, state.NetworkID.ValueString(), state.Name.ValueString())...)
+		return
+	}
+
+	host, err = r.setBlocked(ctx, host, state.Blocked.ValueBool())
 	if err != nil {
 		resp.Diagnostics.AddError("Request Failure", err.Error())
 		return
 	}
 
 	resp.Diagnostics.Append(state.ApplyHost(ctx, host)...)
+	resp.Diagnostics.Append(revision.Save(ctx, resp.Private, revision.Host(*host))...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, Identity{ID: state.ID})...)
 
 	tflog.Trace(ctx, "updated Defined.net lighthouse", map[string]any{
 		"id":               state.ID.String(),
@@ -239,15 +291,52 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		"role_id":          state.RoleID.String(),
 		"name":             state.Name.String(),
 		"listen_port":      state.ListenPort.String(),
-		"static_addresses": state.StaticAddresses.String(),
+		"static_addresses": host.StaticAddresses,
 		"tags":             state.Tags.String(),
 	})
 }
 
+// setBlocked blocks or unblocks the lighthouse on Defined.net control plane, returning the lighthouse as changed.
+func (r *Resource) setBlocked(ctx context.Context, host *definednet.Host, blocked bool) (*definednet.Host, error) {
+	if host.IsBlocked == blocked {
+		return host, nil
+	}
+
+	var err error
+	if blocked {
+		err = definednet.BlockHost(ctx, r.client, definednet.BlockHostRequest{ID: host.ID})
+	} else {
+		err = definednet.UnblockHost(ctx, r.client, definednet.UnblockHostRequest{ID: host.ID})
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return definednet.GetHost(ctx, r.client, definednet.GetHostRequest{ID: host.ID})
+}
+
 // ImportState imports Nebula lighthouses from Defined.net control plane.
+//
+// Lighthouses are imported either by their ID or by their network's and their name, in the format
+// network/<network-name>/<host-name>, or by the ID in their resource identity. Hosts and relays are refused.
 func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importID := req.ID
+	if lo.IsEmpty(importID) {
+		resp.Diagnostics.Append(req.Identity.GetAttribute(ctx, path.Root("id"), &importID)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	id, err := importid.ResolveHost(ctx, r.client, importID)
+	if err != nil {
+		resp.Diagnostics.AddError("Import Failure", err.Error())
+		return
+	}
+
 	host, err := definednet.GetHost(ctx, r.client, definednet.GetHostRequest{
-		ID: req.ID,
+		ID: id,
 	})
 
 	if err != nil {
@@ -255,13 +344,20 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 		return
 	}
 
+	resp.Diagnostics.Append(hostkind.Check(host, definednet.HostKindLighthouse)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var state State
 	resp.Diagnostics.Append(state.ApplyHost(ctx, host)...)
+	resp.Diagnostics.Append(revision.Save(ctx, resp.Private, revision.Host(*host))...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, Identity{ID: state.ID})...)
 
 	tflog.Trace(ctx, "imported Defined.net lighthouse", map[string]any{
 		"id":               state.ID.String(),
@@ -269,7 +365,97 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 		"role_id":          state.RoleID.String(),
 		"name":             state.Name.String(),
 		"listen_port":      state.ListenPort.String(),
-		"static_addresses": state.StaticAddresses.String(),
+		"static_addresses": host.StaticAddresses,
 		"tags":             state.Tags.String(),
 	})
 }
+
+// MoveState returns the resource's state movers.
+//
+// Hosts promoted to lighthouses are moved from definednet_host resources, without static addresses and listen port.
+// The moved state is verified to be a lighthouse, when it is refreshed.
+func (r *Resource) MoveState(context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{
+			SourceSchema: &host.Schema,
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				resp.Diagnostics.Append(hostkind.CheckMoveSource(req, definednet.HostKindHost, host.Schema.Version)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				var source host.State
+
+				resp.Diagnostics.Append(req.SourceState.Get(ctx, &source)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				state := State{
+					ID:                 source.ID,
+					NetworkID:          source.NetworkID,
+					RoleID:             source.RoleID,
+					StaticAddresses:    customtypes.NewAddressSetNull(),
+					ListenPort:         types.Int32Null(),
+					Name:               source.Name,
+					IPAddress:          source.IPAddress,
+					Tags:               source.Tags,
+					EnrollmentCode:     source.EnrollmentCode,
+					Metrics:            (*Metrics)(source.Metrics),
+					Blocked:            source.Blocked,
+					DeletionProtection: source.DeletionProtection,
+				}
+
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, state)...)
+				resp.Diagnostics.Append(resp.TargetIdentity.Set(ctx, Identity{ID: state.ID})...)
+
+				tflog.Trace(ctx, "moved Defined.net host to lighthouse", map[string]any{
+					"id": state.ID.String(),
+				})
+			},
+		},
+	}
+}
+
+// HostMover returns the host resource's state mover of lighthouses demoted to hosts.
+//
+// Lighthouses are moved from definednet_lighthouse resources, dropping their static addresses and listen port. The
+// moved state is verified to be a host, when it is refreshed.
+func HostMover() resource.StateMover {
+	return resource.StateMover{
+		SourceSchema: &Schema,
+		StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+			resp.Diagnostics.Append(hostkind.CheckMoveSource(req, definednet.HostKindLighthouse, Schema.Version)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			var source State
+
+			resp.Diagnostics.Append(req.SourceState.Get(ctx, &source)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			state := host.State{
+				ID:                 source.ID,
+				NetworkID:          source.NetworkID,
+				RoleID:             source.RoleID,
+				Name:               source.Name,
+				IPAddress:          source.IPAddress,
+				Tags:               source.Tags,
+				EnrollmentCode:     source.EnrollmentCode,
+				Metrics:            (*host.Metrics)(source.Metrics),
+				Blocked:            source.Blocked,
+				DeletionProtection: source.DeletionProtection,
+			}
+
+			resp.Diagnostics.Append(resp.TargetState.Set(ctx, state)...)
+			resp.Diagnostics.Append(resp.TargetIdentity.Set(ctx, host.Identity{ID: state.ID})...)
+
+			tflog.Trace(ctx, "moved Defined.net lighthouse to host", map[string]any{
+				"id": state.ID.String(),
+			})
+		},
+	}
+}
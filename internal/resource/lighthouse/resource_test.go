@@ -3,46 +3,38 @@ package lighthouse_test
 import (
 	"regexp"
 
-	"github.com/hashicorp/terraform-plugin-framework/providerserver"
-	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/compare"
 	"github.com/hashicorp/terraform-plugin-testing/config"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 	. "github.com/onsi/ginkgo/v2"
-	"github.com/samber/lo"
+	. "github.com/onsi/gomega"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/acc"
+	fakeserver "github.com/sendsmaily/terraform-provider-definednet/internal/testing/server"
 )
 
 var _ = DescribeTable("lighthouse resource management",
 	func(steps ...resource.TestStep) {
-		resource.Test(GinkgoT(), resource.TestCase{
-			Steps: lo.Map(steps, func(step resource.TestStep, _ int) resource.TestStep {
-				step.ProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-					"definednet": providerserver.NewProtocol6WithError(providerFactory()),
-				}
-
-				return step
-			}),
-		})
+		harness.Test(steps...)
 	},
 	Entry("assert lighthouse is created in expected configuration",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/lighthouse.tf"),
+			ConfigFile: acc.Fixture("lighthouse.tf"),
 			ConfigVariables: config.Variables{
 				"name":        config.StringVariable("lighthouse.defined.test"),
 				"network_id":  config.StringVariable("network-id"),
 				"role_id":     config.StringVariable("role-id"),
 				"listen_port": config.IntegerVariable(8484),
-				"static_addresses": config.ListVariable(
-					config.StringVariable("127.0.0.1"),
-					config.StringVariable("172.16.0.1"),
-				),
-				"tags": config.ListVariable(
-					config.StringVariable("tag:one"),
-					config.StringVariable("tag:two"),
+				"static_address": config.ListVariable(
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")}),
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("172.16.0.1")}),
 				),
+				"tags": acc.Strings("tag:one", "tag:two"),
 			},
 			ConfigPlanChecks: resource.ConfigPlanChecks{
 				PreApply: []plancheck.PlanCheck{
@@ -73,46 +65,40 @@ var _ = DescribeTable("lighthouse resource management",
 				resource.TestCheckResourceAttr("definednet_lighthouse.test", "network_id", "network-id"),
 				resource.TestCheckResourceAttr("definednet_lighthouse.test", "role_id", "role-id"),
 				resource.TestCheckResourceAttr("definednet_lighthouse.test", "listen_port", "8484"),
-				resource.TestCheckResourceAttr("definednet_lighthouse.test", "static_addresses.0", "127.0.0.1"),
-				resource.TestCheckResourceAttr("definednet_lighthouse.test", "static_addresses.1", "172.16.0.1"),
-				resource.TestCheckResourceAttr("definednet_lighthouse.test", "tags.0", "tag:one"),
-				resource.TestCheckResourceAttr("definednet_lighthouse.test", "tags.1", "tag:two"),
+				resource.TestCheckTypeSetElemNestedAttrs("definednet_lighthouse.test", "static_address.*", map[string]string{"host": "127.0.0.1"}),
+				resource.TestCheckTypeSetElemNestedAttrs("definednet_lighthouse.test", "static_address.*", map[string]string{"host": "172.16.0.1"}),
+				resource.TestCheckTypeSetElemAttr("definednet_lighthouse.test", "tags.*", "tag:one"),
+				resource.TestCheckTypeSetElemAttr("definednet_lighthouse.test", "tags.*", "tag:two"),
 			),
 		},
 	),
 	Entry("assert simple updates are executed in-place",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/lighthouse.tf"),
+			ConfigFile: acc.Fixture("lighthouse.tf"),
 			ConfigVariables: config.Variables{
 				"name":        config.StringVariable("lighthouse.defined.test"),
 				"network_id":  config.StringVariable("network-id"),
 				"role_id":     config.StringVariable("role-id"),
 				"listen_port": config.IntegerVariable(8484),
-				"static_addresses": config.ListVariable(
-					config.StringVariable("127.0.0.1"),
-					config.StringVariable("172.16.0.1"),
-				),
-				"tags": config.ListVariable(
-					config.StringVariable("tag:one"),
-					config.StringVariable("tag:two"),
+				"static_address": config.ListVariable(
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")}),
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("172.16.0.1")}),
 				),
+				"tags": acc.Strings("tag:one", "tag:two"),
 			},
 		},
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/lighthouse.tf"),
+			ConfigFile: acc.Fixture("lighthouse.tf"),
 			ConfigVariables: config.Variables{
 				"name":        config.StringVariable("updated-lighthouse.defined.test"),
 				"network_id":  config.StringVariable("network-id"),
 				"role_id":     config.StringVariable("updated-role-id"),
 				"listen_port": config.IntegerVariable(6363),
-				"static_addresses": config.ListVariable(
-					config.StringVariable("127.0.0.1"),
-					config.StringVariable("172.16.0.1"),
-				),
-				"tags": config.ListVariable(
-					config.StringVariable("tag:one"),
-					config.StringVariable("tag:three"),
+				"static_address": config.ListVariable(
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")}),
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("172.16.0.1")}),
 				),
+				"tags": acc.Strings("tag:one", "tag:three"),
 			},
 			ConfigPlanChecks: resource.ConfigPlanChecks{
 				PreApply: []plancheck.PlanCheck{
@@ -121,49 +107,43 @@ var _ = DescribeTable("lighthouse resource management",
 			},
 			Check: resource.ComposeAggregateTestCheckFunc(
 				resource.TestCheckResourceAttr("definednet_lighthouse.test", "listen_port", "6363"),
-				resource.TestCheckResourceAttr("definednet_lighthouse.test", "static_addresses.0", "127.0.0.1"),
-				resource.TestCheckResourceAttr("definednet_lighthouse.test", "static_addresses.1", "172.16.0.1"),
+				resource.TestCheckTypeSetElemNestedAttrs("definednet_lighthouse.test", "static_address.*", map[string]string{"host": "127.0.0.1"}),
+				resource.TestCheckTypeSetElemNestedAttrs("definednet_lighthouse.test", "static_address.*", map[string]string{"host": "172.16.0.1"}),
 				resource.TestCheckResourceAttr("definednet_lighthouse.test", "name", "updated-lighthouse.defined.test"),
 				resource.TestCheckResourceAttr("definednet_lighthouse.test", "network_id", "network-id"),
 				resource.TestCheckResourceAttr("definednet_lighthouse.test", "role_id", "updated-role-id"),
-				resource.TestCheckResourceAttr("definednet_lighthouse.test", "tags.0", "tag:one"),
-				resource.TestCheckResourceAttr("definednet_lighthouse.test", "tags.1", "tag:three"),
+				resource.TestCheckTypeSetElemAttr("definednet_lighthouse.test", "tags.*", "tag:one"),
+				resource.TestCheckTypeSetElemAttr("definednet_lighthouse.test", "tags.*", "tag:three"),
 			),
 		},
 	),
 	Entry("assert updating network_id replaces the lighthouse",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/lighthouse.tf"),
+			ConfigFile: acc.Fixture("lighthouse.tf"),
 			ConfigVariables: config.Variables{
 				"name":        config.StringVariable("lighthouse.defined.test"),
 				"network_id":  config.StringVariable("network-id"),
 				"role_id":     config.StringVariable("role-id"),
 				"listen_port": config.IntegerVariable(8484),
-				"static_addresses": config.ListVariable(
-					config.StringVariable("127.0.0.1"),
-					config.StringVariable("172.16.0.1"),
-				),
-				"tags": config.ListVariable(
-					config.StringVariable("tag:one"),
-					config.StringVariable("tag:two"),
+				"static_address": config.ListVariable(
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")}),
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("172.16.0.1")}),
 				),
+				"tags": acc.Strings("tag:one", "tag:two"),
 			},
 		},
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/lighthouse.tf"),
+			ConfigFile: acc.Fixture("lighthouse.tf"),
 			ConfigVariables: config.Variables{
 				"name":        config.StringVariable("lighthouse.defined.test"),
 				"network_id":  config.StringVariable("updated-network-id"),
 				"role_id":     config.StringVariable("role-id"),
 				"listen_port": config.IntegerVariable(8484),
-				"static_addresses": config.ListVariable(
-					config.StringVariable("127.0.0.1"),
-					config.StringVariable("172.16.0.1"),
-				),
-				"tags": config.ListVariable(
-					config.StringVariable("tag:one"),
-					config.StringVariable("tag:two"),
+				"static_address": config.ListVariable(
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")}),
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("172.16.0.1")}),
 				),
+				"tags": acc.Strings("tag:one", "tag:two"),
 			},
 			ConfigPlanChecks: resource.ConfigPlanChecks{
 				PreApply: []plancheck.PlanCheck{
@@ -175,54 +155,76 @@ var _ = DescribeTable("lighthouse resource management",
 	),
 	Entry("assert importing lighthouse populates the lighthouse",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/lighthouse.tf"),
+			ConfigFile: acc.Fixture("lighthouse.tf"),
 			ConfigVariables: config.Variables{
 				"name":        config.StringVariable("lighthouse.defined.test"),
 				"network_id":  config.StringVariable("network-id"),
 				"role_id":     config.StringVariable("role-id"),
 				"listen_port": config.IntegerVariable(8484),
-				"static_addresses": config.ListVariable(
-					config.StringVariable("127.0.0.1"),
-					config.StringVariable("172.16.0.1"),
-				),
-				"tags": config.ListVariable(
-					config.StringVariable("tag:one"),
-					config.StringVariable("tag:two"),
+				"static_address": config.ListVariable(
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")}),
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("172.16.0.1")}),
 				),
+				"tags": acc.Strings("tag:one", "tag:two"),
 			},
 		},
-		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/lighthouse.tf"),
+		acc.ImportStateVerify(resource.TestStep{
+			ConfigFile: acc.Fixture("lighthouse.tf"),
 			ConfigVariables: config.Variables{
 				"name":        config.StringVariable("lighthouse.defined.test"),
 				"network_id":  config.StringVariable("network-id"),
 				"role_id":     config.StringVariable("role-id"),
 				"listen_port": config.IntegerVariable(8484),
-				"static_addresses": config.ListVariable(
-					config.StringVariable("127.0.0.1"),
-					config.StringVariable("172.16.0.1"),
+				"static_address": config.ListVariable(
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")}),
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("172.16.0.1")}),
 				),
-				"tags": config.ListVariable(
-					config.StringVariable("tag:one"),
-					config.StringVariable("tag:two"),
+				"tags": acc.Strings("tag:one", "tag:two"),
+			},
+		}, "definednet_lighthouse.test", "enrollment_code"),
+	),
+	Entry("assert importing lighthouse by name populates the lighthouse",
+		resource.TestStep{
+			PreConfig: func() {
+				Expect(harness.Server.Networks.Replace(fakeserver.Network{ID: "network-id", Name: "test-network"})).To(Succeed())
+			},
+			ConfigFile: acc.Fixture("lighthouse.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("lighthouse.defined.test"),
+				"network_id":  config.StringVariable("network-id"),
+				"role_id":     config.StringVariable("role-id"),
+				"listen_port": config.IntegerVariable(8484),
+				"static_address": config.ListVariable(
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")}),
 				),
+				"tags": acc.Strings("tag:one"),
 			},
-			ResourceName:            "definednet_lighthouse.test",
-			ImportState:             true,
-			ImportStateVerify:       true,
-			ImportStateVerifyIgnore: []string{"enrollment_code"},
 		},
+		acc.ImportStateVerify(resource.TestStep{
+			ConfigFile: acc.Fixture("lighthouse.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("lighthouse.defined.test"),
+				"network_id":  config.StringVariable("network-id"),
+				"role_id":     config.StringVariable("role-id"),
+				"listen_port": config.IntegerVariable(8484),
+				"static_address": config.ListVariable(
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")}),
+				),
+				"tags": acc.Strings("tag:one"),
+			},
+			ImportStateId: "network/test-network/lighthouse.defined.test",
+		}, "definednet_lighthouse.test", "enrollment_code"),
 	),
 	Entry("assert optional fields are optional",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/lighthouse_minimal.tf"),
+			ConfigFile: acc.Fixture("lighthouse_minimal.tf"),
 			ConfigVariables: config.Variables{
 				"name":        config.StringVariable("lighthouse.defined.test"),
 				"network_id":  config.StringVariable("network-id"),
 				"listen_port": config.IntegerVariable(8484),
-				"static_addresses": config.ListVariable(
-					config.StringVariable("127.0.0.1"),
-					config.StringVariable("172.16.0.1"),
+				"static_address": config.ListVariable(
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")}),
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("172.16.0.1")}),
 				),
 			},
 			ConfigStateChecks: []statecheck.StateCheck{
@@ -230,44 +232,32 @@ var _ = DescribeTable("lighthouse resource management",
 				statecheck.ExpectKnownValue("definednet_lighthouse.minimal_test", tfjsonpath.New("tags"), knownvalue.Null()),
 			},
 		},
-		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/lighthouse_minimal.tf"),
+		acc.ImportStateVerify(resource.TestStep{
+			ConfigFile: acc.Fixture("lighthouse_minimal.tf"),
 			ConfigVariables: config.Variables{
 				"name":        config.StringVariable("lighthouse.defined.test"),
 				"network_id":  config.StringVariable("network-id"),
 				"listen_port": config.IntegerVariable(8484),
-				"static_addresses": config.ListVariable(
-					config.StringVariable("127.0.0.1"),
-					config.StringVariable("172.16.0.1"),
+				"static_address": config.ListVariable(
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")}),
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("172.16.0.1")}),
 				),
 			},
-			ResourceName:            "definednet_lighthouse.minimal_test",
-			ImportState:             true,
-			ImportStateVerify:       true,
-			ImportStateVerifyIgnore: []string{"enrollment_code"},
 			ConfigStateChecks: []statecheck.StateCheck{
 				statecheck.ExpectKnownValue("definednet_lighthouse.minimal_test", tfjsonpath.New("role_id"), knownvalue.Null()),
 				statecheck.ExpectKnownValue("definednet_lighthouse.minimal_test", tfjsonpath.New("tags"), knownvalue.Null()),
 			},
-		},
+		}, "definednet_lighthouse.minimal_test", "enrollment_code"),
 	),
 )
 
 var _ = DescribeTable("host metrics exporter configuration management",
 	func(steps ...resource.TestStep) {
-		resource.Test(GinkgoT(), resource.TestCase{
-			Steps: lo.Map(steps, func(step resource.TestStep, _ int) resource.TestStep {
-				step.ProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-					"definednet": providerserver.NewProtocol6WithError(providerFactory()),
-				}
-
-				return step
-			}),
-		})
+		harness.Test(steps...)
 	},
 	Entry("assert enabling metrics configures default metrics exporter",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/lighthouse_metrics_defaults.tf"),
+			ConfigFile: acc.Fixture("lighthouse_metrics_defaults.tf"),
 			ConfigStateChecks: []statecheck.StateCheck{
 				statecheck.ExpectKnownValue("definednet_lighthouse.metrics_default_test", tfjsonpath.New("metrics").AtMapKey("listen"), knownvalue.StringExact("127.0.0.1:8080")),
 				statecheck.ExpectKnownValue("definednet_lighthouse.metrics_default_test", tfjsonpath.New("metrics").AtMapKey("path"), knownvalue.StringExact("/metrics")),
@@ -279,7 +269,7 @@ var _ = DescribeTable("host metrics exporter configuration management",
 	),
 	Entry("assert metrics exporter is configurable",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/lighthouse_metrics.tf"),
+			ConfigFile: acc.Fixture("lighthouse_metrics.tf"),
 			ConfigVariables: config.Variables{
 				"metrics_listen":       config.StringVariable("100.64.0.1:9100"),
 				"metrics_path":         config.StringVariable("/-/metrics"),
@@ -298,7 +288,7 @@ var _ = DescribeTable("host metrics exporter configuration management",
 	),
 	Entry("assert metrics configuration updates are executed in-place",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/lighthouse_metrics.tf"),
+			ConfigFile: acc.Fixture("lighthouse_metrics.tf"),
 			ConfigVariables: config.Variables{
 				"metrics_listen":       config.StringVariable("127.0.0.1:8080"),
 				"metrics_path":         config.StringVariable("/metrics"),
@@ -308,7 +298,7 @@ var _ = DescribeTable("host metrics exporter configuration management",
 			},
 		},
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/lighthouse_metrics.tf"),
+			ConfigFile: acc.Fixture("lighthouse_metrics.tf"),
 			ConfigVariables: config.Variables{
 				"metrics_listen":       config.StringVariable("100.64.0.1:9100"),
 				"metrics_path":         config.StringVariable("/-/metrics"),
@@ -332,7 +322,7 @@ var _ = DescribeTable("host metrics exporter configuration management",
 	),
 	Entry("assert host import populates metrics configuration",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/lighthouse_metrics.tf"),
+			ConfigFile: acc.Fixture("lighthouse_metrics.tf"),
 			ConfigVariables: config.Variables{
 				"metrics_listen":       config.StringVariable("100.64.0.1:9100"),
 				"metrics_path":         config.StringVariable("/-/metrics"),
@@ -341,8 +331,8 @@ var _ = DescribeTable("host metrics exporter configuration management",
 				"metrics_enable_extra": config.BoolVariable(true),
 			},
 		},
-		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/lighthouse_metrics.tf"),
+		acc.ImportStateVerify(resource.TestStep{
+			ConfigFile: acc.Fixture("lighthouse_metrics.tf"),
 			ConfigVariables: config.Variables{
 				"metrics_listen":       config.StringVariable("100.64.0.1:9100"),
 				"metrics_path":         config.StringVariable("/-/metrics"),
@@ -350,10 +340,590 @@ var _ = DescribeTable("host metrics exporter configuration management",
 				"metrics_subsystem":    config.StringVariable("configurable_host"),
 				"metrics_enable_extra": config.BoolVariable(true),
 			},
-			ResourceName:            "definednet_lighthouse.metrics_test",
-			ImportState:             true,
-			ImportStateVerify:       true,
-			ImportStateVerifyIgnore: []string{"enrollment_code"},
+		}, "definednet_lighthouse.metrics_test", "enrollment_code"),
+	),
+)
+
+var _ = DescribeTable("lighthouse static address management",
+	func(steps ...resource.TestStep) {
+		harness.Test(steps...)
+	},
+	Entry("assert static addresses are advertised with their hosts and ports",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("lighthouse.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("lighthouse.defined.test"),
+				"network_id":  config.StringVariable("network-id"),
+				"role_id":     config.StringVariable("role-id"),
+				"listen_port": config.IntegerVariable(8484),
+				"static_address": config.ListVariable(
+					config.ObjectVariable(map[string]config.Variable{
+						"host": config.StringVariable("lighthouse.defined.test"),
+						"port": config.IntegerVariable(4242),
+					}),
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")}),
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("fd00::1")}),
+				),
+				"tags": acc.Strings(),
+			},
+			Check: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckTypeSetElemNestedAttrs("definednet_lighthouse.test", "static_address.*", map[string]string{
+					"host": "lighthouse.defined.test",
+					"port": "4242",
+				}),
+				resource.TestCheckTypeSetElemNestedAttrs("definednet_lighthouse.test", "static_address.*", map[string]string{
+					"host": "127.0.0.1",
+				}),
+				resource.TestCheckTypeSetElemNestedAttrs("definednet_lighthouse.test", "static_address.*", map[string]string{
+					"host": "fd00::1",
+				}),
+				resource.TestCheckResourceAttrWith("definednet_lighthouse.test", "id", func(id string) error {
+					host, err := harness.Server.Hosts.Get(id)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(host.Host.StaticAddresses).To(ConsistOf(
+						"lighthouse.defined.test:4242",
+						"127.0.0.1:8484",
+						"[fd00::1]:8484",
+					))
+
+					return nil
+				}),
+			),
+		},
+	),
+	Entry("assert static address ports are updated in-place",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("lighthouse.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("lighthouse.defined.test"),
+				"network_id":  config.StringVariable("network-id"),
+				"role_id":     config.StringVariable("role-id"),
+				"listen_port": config.IntegerVariable(8484),
+				"static_address": config.ListVariable(
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("lighthouse.defined.test")}),
+				),
+				"tags": acc.Strings(),
+			},
+		},
+		resource.TestStep{
+			ConfigFile: acc.Fixture("lighthouse.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("lighthouse.defined.test"),
+				"network_id":  config.StringVariable("network-id"),
+				"role_id":     config.StringVariable("role-id"),
+				"listen_port": config.IntegerVariable(8484),
+				"static_address": config.ListVariable(
+					config.ObjectVariable(map[string]config.Variable{
+						"host": config.StringVariable("lighthouse.defined.test"),
+						"port": config.IntegerVariable(8484),
+					}),
+				),
+				"tags": acc.Strings(),
+			},
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction("definednet_lighthouse.test", plancheck.ResourceActionUpdate),
+				},
+			},
+			Check: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckTypeSetElemNestedAttrs("definednet_lighthouse.test", "static_address.*", map[string]string{
+					"host": "lighthouse.defined.test",
+					"port": "8484",
+				}),
+			),
+		},
+		resource.TestStep{
+			ConfigFile: acc.Fixture("lighthouse.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("lighthouse.defined.test"),
+				"network_id":  config.StringVariable("network-id"),
+				"role_id":     config.StringVariable("role-id"),
+				"listen_port": config.IntegerVariable(8484),
+				"static_address": config.ListVariable(
+					config.ObjectVariable(map[string]config.Variable{
+						"host": config.StringVariable("lighthouse.defined.test"),
+						"port": config.IntegerVariable(4242),
+					}),
+				),
+				"tags": acc.Strings(),
+			},
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction("definednet_lighthouse.test", plancheck.ResourceActionUpdate),
+				},
+			},
+			Check: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckTypeSetElemNestedAttrs("definednet_lighthouse.test", "static_address.*", map[string]string{
+					"host": "lighthouse.defined.test",
+					"port": "4242",
+				}),
+			),
+		},
+	),
+	Entry("assert importing lighthouse preserves static addresses",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("lighthouse.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("lighthouse.defined.test"),
+				"network_id":  config.StringVariable("network-id"),
+				"role_id":     config.StringVariable("role-id"),
+				"listen_port": config.IntegerVariable(8484),
+				"static_address": config.ListVariable(
+					config.ObjectVariable(map[string]config.Variable{
+						"host": config.StringVariable("lighthouse.defined.test"),
+						"port": config.IntegerVariable(4242),
+					}),
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")}),
+				),
+				"tags": acc.Strings(),
+			},
+		},
+		acc.ImportStateVerify(resource.TestStep{
+			ConfigFile: acc.Fixture("lighthouse.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("lighthouse.defined.test"),
+				"network_id":  config.StringVariable("network-id"),
+				"role_id":     config.StringVariable("role-id"),
+				"listen_port": config.IntegerVariable(8484),
+				"static_address": config.ListVariable(
+					config.ObjectVariable(map[string]config.Variable{
+						"host": config.StringVariable("lighthouse.defined.test"),
+						"port": config.IntegerVariable(4242),
+					}),
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")}),
+				),
+				"tags": acc.Strings(),
+			},
+		}, "definednet_lighthouse.test", "enrollment_code"),
+	),
+	Entry("assert invalid static address hosts fail validation",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("lighthouse.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("lighthouse.defined.test"),
+				"network_id":  config.StringVariable("network-id"),
+				"role_id":     config.StringVariable("role-id"),
+				"listen_port": config.IntegerVariable(8484),
+				"static_address": config.ListVariable(
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1:4242")}),
+				),
+				"tags": acc.Strings(),
+			},
+			ExpectError: regexp.MustCompile(`must be an IP address or a DNS name`),
+		},
+	),
+)
+
+var _ = DescribeTable("deprecated lighthouse static addresses management",
+	func(steps ...resource.TestStep) {
+		harness.Test(steps...)
+	},
+	Entry("assert equivalent static addresses and tags produce no changes",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("lighthouse_static_addresses.tf"),
+			ConfigVariables: config.Variables{
+				"name":             config.StringVariable("lighthouse.defined.test"),
+				"network_id":       config.StringVariable("network-id"),
+				"role_id":          config.StringVariable("role-id"),
+				"listen_port":      config.IntegerVariable(8484),
+				"static_addresses": acc.Strings("0:0::1", "172.16.0.1"),
+				"tags":             acc.Strings("tag:one", "tag:two"),
+			},
+			Check: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckTypeSetElemAttr("definednet_lighthouse.test", "static_addresses.*", "0:0::1"),
+				resource.TestCheckTypeSetElemAttr("definednet_lighthouse.test", "static_addresses.*", "172.16.0.1"),
+				resource.TestCheckNoResourceAttr("definednet_lighthouse.test", "static_address.#"),
+			),
+		},
+		acc.ExpectEmptyPlan(resource.TestStep{
+			ConfigFile: acc.Fixture("lighthouse_static_addresses.tf"),
+			ConfigVariables: config.Variables{
+				"name":             config.StringVariable("lighthouse.defined.test"),
+				"network_id":       config.StringVariable("network-id"),
+				"role_id":          config.StringVariable("role-id"),
+				"listen_port":      config.IntegerVariable(8484),
+				"static_addresses": acc.Strings("172.16.0.1", "0:0::1"),
+				"tags":             acc.Strings("tag:two", "tag:one"),
+			},
+		}),
+	),
+	Entry("assert switching to static_address executes in-place",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("lighthouse_static_addresses.tf"),
+			ConfigVariables: config.Variables{
+				"name":             config.StringVariable("lighthouse.defined.test"),
+				"network_id":       config.StringVariable("network-id"),
+				"role_id":          config.StringVariable("role-id"),
+				"listen_port":      config.IntegerVariable(8484),
+				"static_addresses": acc.Strings("127.0.0.1"),
+				"tags":             acc.Strings(),
+			},
+		},
+		resource.TestStep{
+			ConfigFile: acc.Fixture("lighthouse.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("lighthouse.defined.test"),
+				"network_id":  config.StringVariable("network-id"),
+				"role_id":     config.StringVariable("role-id"),
+				"listen_port": config.IntegerVariable(8484),
+				"static_address": config.ListVariable(
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")}),
+				),
+				"tags": acc.Strings(),
+			},
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction("definednet_lighthouse.test", plancheck.ResourceActionUpdate),
+				},
+			},
+			Check: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckNoResourceAttr("definednet_lighthouse.test", "static_addresses.#"),
+				resource.TestCheckTypeSetElemNestedAttrs("definednet_lighthouse.test", "static_address.*", map[string]string{
+					"host": "127.0.0.1",
+				}),
+			),
+		},
+	),
+)
+
+var _ = DescribeTable("lighthouse reference validation",
+	func(steps ...resource.TestStep) {
+		harness.Test(steps...)
+	},
+	Entry("assert unknown roles fail planning",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("lighthouse.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("lighthouse.defined.test"),
+				"network_id":  config.StringVariable("network-id"),
+				"role_id":     config.StringVariable("role-MISSING"),
+				"listen_port": config.IntegerVariable(8484),
+				"static_address": config.ListVariable(
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")}),
+				),
+				"tags": acc.Strings(),
+			},
+			PlanOnly:    true,
+			ExpectError: regexp.MustCompile(`Role "role-MISSING" does not exist`),
+		},
+	),
+	Entry("assert unknown networks fail planning",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("lighthouse.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("lighthouse.defined.test"),
+				"network_id":  config.StringVariable("network-MISSING"),
+				"role_id":     config.StringVariable("role-id"),
+				"listen_port": config.IntegerVariable(8484),
+				"static_address": config.ListVariable(
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")}),
+				),
+				"tags": acc.Strings(),
+			},
+			PlanOnly:    true,
+			ExpectError: regexp.MustCompile(`Network "network-MISSING" does not exist`),
+		},
+	),
+)
+
+// movedHostID asserts hosts keep their ID, when moved between resource types.
+var movedHostID = statecheck.CompareValue(compare.ValuesSame())
+
+var _ = DescribeTable("lighthouse blocking",
+	func(steps ...resource.TestStep) {
+		harness.Test(steps...)
+	},
+	Entry("assert lighthouses are blocked and unblocked",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("lighthouse.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("lighthouse.defined.test"),
+				"network_id":  config.StringVariable("network-id"),
+				"role_id":     config.StringVariable("role-id"),
+				"listen_port": config.IntegerVariable(4242),
+				"static_address": config.ListVariable(
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")}),
+				),
+				"tags":    acc.Strings(),
+				"blocked": config.BoolVariable(true),
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("definednet_lighthouse.test", tfjsonpath.New("blocked"), knownvalue.Bool(true)),
+			},
+			Check: expectBlocked(true),
+		},
+		resource.TestStep{
+			ConfigFile: acc.Fixture("lighthouse.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("lighthouse.defined.test"),
+				"network_id":  config.StringVariable("network-id"),
+				"role_id":     config.StringVariable("role-id"),
+				"listen_port": config.IntegerVariable(4242),
+				"static_address": config.ListVariable(
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")}),
+				),
+				"tags":    acc.Strings(),
+				"blocked": config.BoolVariable(false),
+			},
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction("definednet_lighthouse.test", plancheck.ResourceActionUpdate),
+				},
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("definednet_lighthouse.test", tfjsonpath.New("blocked"), knownvalue.Bool(false)),
+			},
+			Check: expectBlocked(false),
+		},
+	),
+	Entry("assert lighthouses blocked on Defined.net are reflected on read",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("lighthouse.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("lighthouse.defined.test"),
+				"network_id":  config.StringVariable("network-id"),
+				"role_id":     config.StringVariable("role-id"),
+				"listen_port": config.IntegerVariable(4242),
+				"static_address": config.ListVariable(
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")}),
+				),
+				"tags": acc.Strings(),
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("definednet_lighthouse.test", tfjsonpath.New("blocked"), knownvalue.Bool(false)),
+			},
+		},
+		resource.TestStep{
+			PreConfig: func() {
+				for _, host := range harness.Server.Hosts.List() {
+					host.Host.IsBlocked = true
+					Expect(harness.Server.Hosts.Replace(host)).To(Succeed())
+				}
+			},
+			ConfigFile: acc.Fixture("lighthouse.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("lighthouse.defined.test"),
+				"network_id":  config.StringVariable("network-id"),
+				"role_id":     config.StringVariable("role-id"),
+				"listen_port": config.IntegerVariable(4242),
+				"static_address": config.ListVariable(
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")}),
+				),
+				"tags":    acc.Strings(),
+				"blocked": config.BoolVariable(true),
+			},
+			PlanOnly: true,
+		},
+	),
+)
+
+var _ = DescribeTable("lighthouse deletion protection",
+	func(steps ...resource.TestStep) {
+		harness.Test(steps...)
+	},
+	Entry("assert protected lighthouses can not be deleted",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("lighthouse.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("lighthouse.defined.test"),
+				"network_id":  config.StringVariable("network-id"),
+				"role_id":     config.StringVariable("role-id"),
+				"listen_port": config.IntegerVariable(4242),
+				"static_address": config.ListVariable(
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")}),
+				),
+				"tags":                acc.Strings(),
+				"deletion_protection": config.BoolVariable(true),
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("definednet_lighthouse.test", tfjsonpath.New("deletion_protection"), knownvalue.Bool(true)),
+			},
+		},
+		resource.TestStep{
+			ConfigFile: acc.Fixture("lighthouse.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("lighthouse.defined.test"),
+				"network_id":  config.StringVariable("network-id"),
+				"role_id":     config.StringVariable("role-id"),
+				"listen_port": config.IntegerVariable(4242),
+				"static_address": config.ListVariable(
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")}),
+				),
+				"tags":                acc.Strings(),
+				"deletion_protection": config.BoolVariable(true),
+			},
+			Destroy:     true,
+			ExpectError: regexp.MustCompile(`Deletion Protection Enabled`),
+		},
+		resource.TestStep{
+			ConfigFile: acc.Fixture("lighthouse.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("lighthouse.defined.test"),
+				"network_id":  config.StringVariable("network-id"),
+				"role_id":     config.StringVariable("role-id"),
+				"listen_port": config.IntegerVariable(4242),
+				"static_address": config.ListVariable(
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")}),
+				),
+				"tags":                acc.Strings(),
+				"deletion_protection": config.BoolVariable(false),
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("definednet_lighthouse.test", tfjsonpath.New("deletion_protection"), knownvalue.Bool(false)),
+			},
+		},
+	),
+	Entry("assert deletion protection is disabled by default",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("lighthouse.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("lighthouse.defined.test"),
+				"network_id":  config.StringVariable("network-id"),
+				"role_id":     config.StringVariable("role-id"),
+				"listen_port": config.IntegerVariable(4242),
+				"static_address": config.ListVariable(
+					config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")}),
+				),
+				"tags": acc.Strings(),
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("definednet_lighthouse.test", tfjsonpath.New("deletion_protection"), knownvalue.Bool(false)),
+			},
+		},
+	),
+)
+
+var _ = DescribeTable("lighthouse kind validation",
+	func(steps ...resource.TestStep) {
+		harness.Run(resource.TestCase{
+			TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+				// Moving resources between resource types requires Terraform 1.8.0 or later.
+				tfversion.SkipBelow(tfversion.Version1_8_0),
+			},
+			Steps: steps,
+		})
+	},
+	Entry("assert importing hosts as lighthouses fails",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("lighthouse.tf"),
+			ConfigVariables: config.Variables{
+				"name":           config.StringVariable("host.defined.test"),
+				"network_id":     config.StringVariable("network-id"),
+				"role_id":        config.StringVariable("role-id"),
+				"listen_port":    config.IntegerVariable(8484),
+				"static_address": config.ListVariable(config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")})),
+				"tags":           acc.Strings("tag:one"),
+			},
+		},
+		resource.TestStep{
+			PreConfig: func() {
+				Expect(harness.Server.Hosts.Add(fakeserver.Host{Host: definednet.Host{
+					ID:        "host-OTHER",
+					NetworkID: "network-id",
+					Name:      "other.defined.test",
+				}})).To(Succeed())
+			},
+			ConfigFile: acc.Fixture("lighthouse.tf"),
+			ConfigVariables: config.Variables{
+				"name":           config.StringVariable("host.defined.test"),
+				"network_id":     config.StringVariable("network-id"),
+				"role_id":        config.StringVariable("role-id"),
+				"listen_port":    config.IntegerVariable(8484),
+				"static_address": config.ListVariable(config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")})),
+				"tags":           acc.Strings("tag:one"),
+			},
+			ResourceName:  "definednet_lighthouse.test",
+			ImportState:   true,
+			ImportStateId: "host-OTHER",
+			ExpectError:   regexp.MustCompile(`Host "host-OTHER" is a host, expected a lighthouse`),
+		},
+	),
+	Entry("assert lighthouses demoted to hosts fail to refresh",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("lighthouse.tf"),
+			ConfigVariables: config.Variables{
+				"name":           config.StringVariable("host.defined.test"),
+				"network_id":     config.StringVariable("network-id"),
+				"role_id":        config.StringVariable("role-id"),
+				"listen_port":    config.IntegerVariable(8484),
+				"static_address": config.ListVariable(config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")})),
+				"tags":           acc.Strings("tag:one"),
+			},
+		},
+		resource.TestStep{
+			PreConfig: func() {
+				for _, host := range harness.Server.Hosts.List() {
+					host.Host.IsLighthouse = false
+					host.Host.StaticAddresses = nil
+					host.Host.ListenPort = 0
+					Expect(harness.Server.Hosts.Replace(host)).To(Succeed())
+				}
+			},
+			ConfigFile: acc.Fixture("lighthouse.tf"),
+			ConfigVariables: config.Variables{
+				"name":           config.StringVariable("host.defined.test"),
+				"network_id":     config.StringVariable("network-id"),
+				"role_id":        config.StringVariable("role-id"),
+				"listen_port":    config.IntegerVariable(8484),
+				"static_address": config.ListVariable(config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")})),
+				"tags":           acc.Strings("tag:one"),
+			},
+			ExpectError: regexp.MustCompile(`(?s)Unexpected Host Kind.*is a host, expected a lighthouse`),
+		},
+		resource.TestStep{
+			PreConfig: func() {
+				for _, host := range harness.Server.Hosts.List() {
+					host.Host.IsLighthouse = true
+					host.Host.StaticAddresses = []string{"127.0.0.1:8484"}
+					host.Host.ListenPort = 8484
+					Expect(harness.Server.Hosts.Replace(host)).To(Succeed())
+				}
+			},
+			ConfigFile: acc.Fixture("lighthouse.tf"),
+			ConfigVariables: config.Variables{
+				"name":           config.StringVariable("host.defined.test"),
+				"network_id":     config.StringVariable("network-id"),
+				"role_id":        config.StringVariable("role-id"),
+				"listen_port":    config.IntegerVariable(8484),
+				"static_address": config.ListVariable(config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")})),
+				"tags":           acc.Strings("tag:one"),
+			},
+		},
+	),
+	Entry("assert hosts promoted to lighthouses are moved",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("host.tf"),
+			ConfigVariables: config.Variables{
+				"name":       config.StringVariable("host.defined.test"),
+				"network_id": config.StringVariable("network-id"),
+				"role_id":    config.StringVariable("role-id"),
+				"tags":       acc.Strings("tag:one"),
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				movedHostID.AddStateValue("definednet_host.test", tfjsonpath.New("id")),
+			},
+		},
+		resource.TestStep{
+			PreConfig: func() {
+				for _, host := range harness.Server.Hosts.List() {
+					host.Host.IsLighthouse = true
+					host.Host.StaticAddresses = []string{"127.0.0.1:8484"}
+					host.Host.ListenPort = 8484
+					Expect(harness.Server.Hosts.Replace(host)).To(Succeed())
+				}
+			},
+			ConfigFile: acc.Fixture("lighthouse_moved.tf"),
+			ConfigVariables: config.Variables{
+				"name":           config.StringVariable("host.defined.test"),
+				"network_id":     config.StringVariable("network-id"),
+				"role_id":        config.StringVariable("role-id"),
+				"listen_port":    config.IntegerVariable(8484),
+				"static_address": config.ListVariable(config.ObjectVariable(map[string]config.Variable{"host": config.StringVariable("127.0.0.1")})),
+				"tags":           acc.Strings("tag:one"),
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				movedHostID.AddStateValue("definednet_lighthouse.test", tfjsonpath.New("id")),
+			},
 		},
 	),
 )
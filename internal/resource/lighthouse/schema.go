@@ -3,20 +3,28 @@ package lighthouse
 import (
 	_ "embed"
 
-	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/customtypes"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/deletionprotection"
 	"github.com/sendsmaily/terraform-provider-definednet/internal/validation"
 )
 
 // Schema is the lighthouse resource's schema.
 var Schema = schema.Schema{
+	Version:             1,
 	MarkdownDescription: resourceDescription,
 	Attributes: map[string]schema.Attribute{
 		"name": schema.StringAttribute{
@@ -33,30 +41,62 @@ var Schema = schema.Schema{
 				stringplanmodifier.RequiresReplace(),
 			},
 		},
+		"blocked": schema.BoolAttribute{
+			Description: "Block the lighthouse on Defined.net, revoking its certificate",
+			Optional:    true,
+			Computed:    true,
+			Default:     booldefault.StaticBool(false),
+		},
+		"deletion_protection": deletionprotection.Attribute("lighthouse"),
 		"role_id": schema.StringAttribute{
 			Description: "Lighthouse's role ID on Defined.net",
 			Optional:    true,
 		},
-		"static_addresses": schema.ListAttribute{
-			Description: "Lighthouse's static IP addresses",
-			ElementType: types.StringType,
-			Required:    true,
-			Validators: []validator.List{
-				listvalidator.UniqueValues(),
-				listvalidator.ValueStringsAre(validation.IPAddress()),
+		"static_addresses": schema.SetAttribute{
+			Description:        "Lighthouse's static IP addresses, advertised on the listen port",
+			DeprecationMessage: "Use static_address instead.",
+			ElementType:        types.StringType,
+			CustomType:         customtypes.NewAddressSetType(),
+			Optional:           true,
+			Validators: []validator.Set{
+				setvalidator.ValueStringsAre(validation.IPAddress()),
+				setvalidator.ExactlyOneOf(path.MatchRoot("static_address")),
+			},
+		},
+		"static_address": schema.SetNestedAttribute{
+			Description: "Lighthouse's static addresses",
+			Optional:    true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"host": schema.StringAttribute{
+						Description: "IP address or DNS name",
+						CustomType:  customtypes.NewAddressType(),
+						Required:    true,
+						Validators: []validator.String{
+							validation.HostAddress(),
+						},
+					},
+					"port": schema.Int32Attribute{
+						Description: "Port, defaults to the listen port",
+						Optional:    true,
+						Validators: []validator.Int32{
+							int32validator.Between(1, 65535),
+						},
+					},
+				},
 			},
 		},
 		"listen_port": schema.Int32Attribute{
 			Description: "Lighthouse's listen port",
 			Required:    true,
 		},
-		"tags": schema.ListAttribute{
+		"tags": schema.SetAttribute{
 			Description: "Lighthouse's tags on Defined.net",
 			ElementType: types.StringType,
+			CustomType:  customtypes.NewTagSetType(),
 			Optional:    true,
-			Validators: []validator.List{
-				listvalidator.UniqueValues(),
-				listvalidator.ValueStringsAre(validation.HostTag()),
+			Validators: []validator.Set{
+				setvalidator.ValueStringsAre(validation.HostTag()),
 			},
 		},
 		"id": schema.StringAttribute{
@@ -139,5 +179,38 @@ var Schema = schema.Schema{
 	},
 }
 
+// IdentitySchema is the lighthouse resource's identity schema.
+var IdentitySchema = identityschema.Schema{
+	Attributes: map[string]identityschema.Attribute{
+		"id": identityschema.StringAttribute{
+			Description:       "Lighthouse's ID",
+			RequiredForImport: true,
+		},
+	},
+}
+
+// ListSchema is the lighthouse list resource's configuration schema.
+var ListSchema = listschema.Schema{
+	MarkdownDescription: listDescription,
+	Attributes: map[string]listschema.Attribute{
+		"network_id": listschema.StringAttribute{
+			Description: "List lighthouses enrolled in the network",
+			Optional:    true,
+		},
+		"role_id": listschema.StringAttribute{
+			Description: "List lighthouses having the role",
+			Optional:    true,
+		},
+		"tags": listschema.ListAttribute{
+			Description: "List lighthouses having all the tags",
+			ElementType: types.StringType,
+			Optional:    true,
+		},
+	},
+}
+
 //go:embed docs/resource.md
 var resourceDescription string
+
+//go:embed docs/list.md
+var listDescription string
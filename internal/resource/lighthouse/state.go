@@ -4,26 +4,44 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/netip"
+	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/customtypes"
 	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/deletionprotection"
 )
 
 // State is the lighthouse resource's state.
 type State struct {
-	ID              types.String `tfsdk:"id"`
-	NetworkID       types.String `tfsdk:"network_id"`
-	RoleID          types.String `tfsdk:"role_id"`
-	StaticAddresses types.List   `tfsdk:"static_addresses"`
-	ListenPort      types.Int32  `tfsdk:"listen_port"`
-	Name            types.String `tfsdk:"name"`
-	IPAddress       types.String `tfsdk:"ip_address"`
-	Tags            types.List   `tfsdk:"tags"`
-	EnrollmentCode  types.String `tfsdk:"enrollment_code"`
-	Metrics         *Metrics     `tfsdk:"metrics"`
+	ID                 types.String           `tfsdk:"id"`
+	NetworkID          types.String           `tfsdk:"network_id"`
+	RoleID             types.String           `tfsdk:"role_id"`
+	StaticAddresses    customtypes.AddressSet `tfsdk:"static_addresses"`
+	StaticAddress      []StaticAddress        `tfsdk:"static_address"`
+	ListenPort         types.Int32            `tfsdk:"listen_port"`
+	Name               types.String           `tfsdk:"name"`
+	IPAddress          types.String           `tfsdk:"ip_address"`
+	Tags               customtypes.TagSet     `tfsdk:"tags"`
+	EnrollmentCode     types.String           `tfsdk:"enrollment_code"`
+	Metrics            *Metrics               `tfsdk:"metrics"`
+	Blocked            types.Bool             `tfsdk:"blocked"`
+	DeletionProtection types.Bool             `tfsdk:"deletion_protection"`
+}
+
+// Identity is the lighthouse resource's identity.
+type Identity struct {
+	ID types.String `tfsdk:"id"`
+}
+
+// StaticAddress is the lighthouse's static address' state.
+type StaticAddress struct {
+	Host customtypes.Address `tfsdk:"host"`
+	Port types.Int32         `tfsdk:"port"`
 }
 
 // Metrics is the host metrics exporter's state.
@@ -36,6 +54,25 @@ type Metrics struct {
 	EnableExtraMetrics types.Bool   `tfsdk:"enable_extra_metrics"`
 }
 
+// ConfigOverrides encodes the metrics exporter's configuration as Defined.net config overrides.
+//
+// Disabled metrics exporters have no config overrides.
+func (m *Metrics) ConfigOverrides() []definednet.ConfigOverride {
+	if lo.IsNil(m) || !m.Enabled.ValueBool() {
+		return nil
+	}
+
+	return []definednet.ConfigOverride{
+		{Key: "stats.type", Value: "prometheus"},
+		{Key: "stats.listen", Value: m.Listen.ValueString()},
+		{Key: "stats.path", Value: m.Path.ValueString()},
+		{Key: "stats.namespace", Value: m.Namespace.ValueString()},
+		{Key: "stats.subsystem", Value: m.Subsystem.ValueString()},
+		{Key: "stats.lighthouse_metrics", Value: m.EnableExtraMetrics.ValueBool()},
+		{Key: "stats.interval", Value: "60s"},
+	}
+}
+
 // ApplyEnrollment applies Defined.net host enrollment information to the state.
 func (s *State) ApplyEnrollment(ctx context.Context, enrollment *definednet.Enrollment) (diags diag.Diagnostics) {
 	diags.Append(s.ApplyHost(ctx, &enrollment.Host)...)
@@ -46,34 +83,25 @@ func (s *State) ApplyEnrollment(ctx context.Context, enrollment *definednet.Enro
 
 // ApplyHost applies Defined.net lighthouse information to the state.
 func (s *State) ApplyHost(ctx context.Context, lighthouse *definednet.Host) (diags diag.Diagnostics) {
-	staticAddrs, d := types.ListValueFrom(ctx, types.StringType, lo.Map(lighthouse.StaticAddresses, func(addr string, idx int) string {
-		a, err := net.ResolveTCPAddr("tcp", addr)
-		if err != nil {
-			// TODO: what is the correct path to return here? The state? The HTTP API response?
-			diags.AddAttributeError(
-				path.Empty().AtMapKey("static_addresses").AtListIndex(idx),
-				fmt.Sprintf("Invalid Address %q", addr),
-				err.Error(),
-			)
-
-			return "<nil>"
-		}
-
-		return a.IP.String()
-	}))
-
-	diags.Append(d...)
+	// Static addresses are applied to the attribute they are configured with, defaulting to static_address.
+	if s.StaticAddresses.IsNull() {
+		diags.Append(s.applyStaticAddress(lighthouse)...)
+	} else {
+		diags.Append(s.applyStaticAddresses(ctx, lighthouse)...)
+	}
 
-	tags := types.ListNull(types.StringType)
+	tags := customtypes.NewTagSetNull()
 	if len(lighthouse.Tags) > 0 {
-		tags, d = types.ListValueFrom(ctx, types.StringType, lighthouse.Tags)
+		var d diag.Diagnostics
+		tags, d = customtypes.NewTagSetValueFrom(ctx, lighthouse.Tags)
 		diags.Append(d...)
 	}
 
 	s.ID = types.StringValue(lighthouse.ID)
+	s.DeletionProtection = deletionprotection.Default(s.DeletionProtection)
+	s.Blocked = types.BoolValue(lighthouse.IsBlocked)
 	s.Name = types.StringValue(lighthouse.Name)
 	s.NetworkID = types.StringValue(lighthouse.NetworkID)
-	s.StaticAddresses = staticAddrs
 	s.ListenPort = types.Int32Value(int32(lighthouse.ListenPort))
 	s.IPAddress = types.StringValue(lighthouse.IPAddress)
 	s.Tags = tags
@@ -143,6 +171,84 @@ func (s *State) ApplyHost(ctx context.Context, lighthouse *definednet.Host) (dia
 	return diags
 }
 
+// applyStaticAddress applies Defined.net lighthouse's static addresses as they are returned by the API.
+//
+// Ports matching the listen port are left unset, unless they are set in the state.
+func (s *State) applyStaticAddress(lighthouse *definednet.Host) (diags diag.Diagnostics) {
+	prior := s.StaticAddress
+
+	s.StaticAddress = lo.Map(lighthouse.StaticAddresses, func(addr string, _ int) StaticAddress {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return StaticAddress{Host: customtypes.NewAddressValue(addr), Port: types.Int32Null()}
+		}
+
+		p, err := strconv.ParseInt(port, 10, 32)
+		if err != nil {
+			diags.AddAttributeError(path.Root("static_address"), fmt.Sprintf("Invalid Address %q", addr), err.Error())
+			return StaticAddress{Host: customtypes.NewAddressValue(host), Port: types.Int32Null()}
+		}
+
+		implicitPort := int(p) == lighthouse.ListenPort && !lo.ContainsBy(prior, func(a StaticAddress) bool {
+			return customtypes.NormalizeAddress(a.Host.ValueString()) == customtypes.NormalizeAddress(host) && !a.Port.IsNull()
+		})
+
+		if implicitPort {
+			return StaticAddress{Host: customtypes.NewAddressValue(host), Port: types.Int32Null()}
+		}
+
+		return StaticAddress{Host: customtypes.NewAddressValue(host), Port: types.Int32Value(int32(p))}
+	})
+
+	return diags
+}
+
+// applyStaticAddresses applies Defined.net lighthouse's static IP addresses, dropping the ports.
+func (s *State) applyStaticAddresses(ctx context.Context, lighthouse *definednet.Host) (diags diag.Diagnostics) {
+	staticAddrs, d := customtypes.NewAddressSetValueFrom(ctx, lo.Map(lighthouse.StaticAddresses, func(addr string, _ int) string {
+		a, err := netip.ParseAddrPort(addr)
+		if err != nil {
+			// TODO: what is the correct path to return here? The state? The HTTP API response?
+			diags.AddAttributeError(
+				path.Root("static_addresses").AtSetValue(types.StringValue(addr)),
+				fmt.Sprintf("Invalid Address %q", addr),
+				err.Error(),
+			)
+
+			return "<nil>"
+		}
+
+		return a.Addr().String()
+	}))
+
+	diags.Append(d...)
+	s.StaticAddresses = staticAddrs
+
+	return diags
+}
+
+// AdvertisedAddresses returns the lighthouse's static addresses in the host-port format expected by Defined.net.
+func (s *State) AdvertisedAddresses(ctx context.Context) ([]string, diag.Diagnostics) {
+	listenPort := strconv.Itoa(int(s.ListenPort.ValueInt32()))
+
+	if !s.StaticAddresses.IsNull() {
+		var staticAddrs []string
+		diags := s.StaticAddresses.ElementsAs(ctx, &staticAddrs, false)
+
+		return lo.Map(staticAddrs, func(addr string, _ int) string {
+			return net.JoinHostPort(addr, listenPort)
+		}), diags
+	}
+
+	return lo.Map(s.StaticAddress, func(addr StaticAddress, _ int) string {
+		if addr.Port.IsNull() {
+			return net.JoinHostPort(addr.Host.ValueString(), listenPort)
+		}
+
+		return net.JoinHostPort(addr.Host.ValueString(), strconv.Itoa(int(addr.Port.ValueInt32())))
+	}), nil
+}
+
 func convert[T any](val any) (T, error) {
 	if val, ok := val.(T); ok {
 		return val, nil
@@ -0,0 +1,128 @@
+package lighthouse_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/customtypes"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/resource/lighthouse"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/generate"
+	"pgregory.net/rapid"
+)
+
+var _ = Describe("lighthouse state mapping", func() {
+	// decode round-trips the lighthouse through JSON, as config override values are decoded from the HTTP API's responses.
+	decode := func(t *rapid.T, h definednet.Host) *definednet.Host {
+		data, err := json.Marshal(h)
+		NewWithT(t).Expect(err).NotTo(HaveOccurred())
+
+		var out definednet.Host
+		NewWithT(t).Expect(json.Unmarshal(data, &out)).To(Succeed())
+
+		return &out
+	}
+
+	Specify("lighthouses round-trip through the state", func() {
+		rapid.Check(GinkgoT(), func(t *rapid.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			h := generate.Lighthouse().Draw(t, "lighthouse")
+
+			var state lighthouse.State
+			g.Expect(state.ApplyHost(ctx, decode(t, h))).To(BeEmpty())
+
+			var tags []string
+			g.Expect(state.Tags.ElementsAs(ctx, &tags, false)).To(BeEmpty())
+
+			addrs, diags := state.AdvertisedAddresses(ctx)
+			g.Expect(diags).To(BeEmpty())
+
+			g.Expect(state.ID.ValueString()).To(Equal(h.ID))
+			g.Expect(state.NetworkID.ValueString()).To(Equal(h.NetworkID))
+			g.Expect(state.RoleID.ValueString()).To(Equal(h.RoleID))
+			g.Expect(state.Name.ValueString()).To(Equal(h.Name))
+			g.Expect(state.IPAddress.ValueString()).To(Equal(h.IPAddress))
+			g.Expect(state.ListenPort.ValueInt32()).To(BeEquivalentTo(h.ListenPort))
+			g.Expect(state.Blocked.ValueBool()).To(Equal(h.IsBlocked))
+			g.Expect(tags).To(ConsistOf(h.Tags))
+			g.Expect(addrs).To(Equal(h.StaticAddresses))
+			g.Expect(state.Metrics.ConfigOverrides()).To(Equal(h.ConfigOverrides))
+		})
+	})
+
+	Specify("static addresses on the listen port are implicit", func() {
+		rapid.Check(GinkgoT(), func(t *rapid.T) {
+			g := NewWithT(t)
+			h := generate.Lighthouse().Draw(t, "lighthouse")
+
+			var state lighthouse.State
+			g.Expect(state.ApplyHost(context.Background(), decode(t, h))).To(BeEmpty())
+
+			g.Expect(state.StaticAddress).To(HaveLen(len(h.StaticAddresses)))
+
+			for idx, addr := range state.StaticAddress {
+				_, port, err := net.SplitHostPort(h.StaticAddresses[idx])
+				g.Expect(err).NotTo(HaveOccurred())
+
+				g.Expect(addr.Port.IsNull()).To(Equal(port == strconv.Itoa(h.ListenPort)))
+			}
+		})
+	})
+
+	Specify("static IP addresses round-trip through the state", func() {
+		rapid.Check(GinkgoT(), func(t *rapid.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			h := generate.Lighthouse().Draw(t, "lighthouse")
+
+			// Static IP addresses are advertised on the listen port only.
+			ips := rapid.SliceOfNDistinct(generate.IPAddress(), 1, 4, rapid.ID).Draw(t, "ips")
+			h.StaticAddresses = lo.Map(ips, func(ip string, _ int) string {
+				return net.JoinHostPort(ip, strconv.Itoa(h.ListenPort))
+			})
+
+			prior, diags := customtypes.NewAddressSetValueFrom(ctx, []string{})
+			g.Expect(diags).To(BeEmpty())
+
+			state := lighthouse.State{StaticAddresses: prior}
+			g.Expect(state.ApplyHost(ctx, decode(t, h))).To(BeEmpty())
+
+			var staticIPs []string
+			g.Expect(state.StaticAddresses.ElementsAs(ctx, &staticIPs, false)).To(BeEmpty())
+			g.Expect(staticIPs).To(ConsistOf(ips))
+
+			addrs, diags := state.AdvertisedAddresses(ctx)
+			g.Expect(diags).To(BeEmpty())
+			g.Expect(addrs).To(ConsistOf(h.StaticAddresses))
+		})
+	})
+
+	Specify("metrics round-trip through config overrides", func() {
+		rapid.Check(GinkgoT(), func(t *rapid.T) {
+			g := NewWithT(t)
+			metrics := lighthouse.Metrics{
+				Enabled:            types.BoolValue(true),
+				Listen:             types.StringValue(rapid.String().Draw(t, "listen")),
+				Path:               types.StringValue(rapid.String().Draw(t, "path")),
+				Namespace:          types.StringValue(rapid.String().Draw(t, "namespace")),
+				Subsystem:          types.StringValue(rapid.String().Draw(t, "subsystem")),
+				EnableExtraMetrics: types.BoolValue(rapid.Bool().Draw(t, "enableExtraMetrics")),
+			}
+
+			var state lighthouse.State
+			g.Expect(state.ApplyHost(context.Background(), decode(t, definednet.Host{
+				ConfigOverrides: metrics.ConfigOverrides(),
+			}))).To(BeEmpty())
+
+			g.Expect(state.Metrics).To(PointTo(Equal(metrics)))
+		})
+	})
+})
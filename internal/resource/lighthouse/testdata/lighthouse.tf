@@ -18,19 +18,35 @@ variable "listen_port" {
   type = number
 }
 
-variable "static_addresses" {
-  type = list(string)
+variable "static_address" {
+  type = list(object({
+    host = string
+    port = optional(number)
+  }))
 }
 
 variable "tags" {
   type = list(string)
 }
 
+variable "blocked" {
+  type    = bool
+  default = null
+}
+
+variable "deletion_protection" {
+  type    = bool
+  default = null
+}
+
 resource "definednet_lighthouse" "test" {
   name             = var.name
   network_id       = var.network_id
   role_id          = var.role_id
   listen_port      = var.listen_port
-  static_addresses = var.static_addresses
+  static_address   = var.static_address
   tags             = var.tags
+  blocked          = var.blocked
+
+  deletion_protection = var.deletion_protection
 }
@@ -14,13 +14,16 @@ variable "listen_port" {
   type = number
 }
 
-variable "static_addresses" {
-  type = list(string)
+variable "static_address" {
+  type = list(object({
+    host = string
+    port = optional(number)
+  }))
 }
 
 resource "definednet_lighthouse" "minimal_test" {
   name             = var.name
   network_id       = var.network_id
   listen_port      = var.listen_port
-  static_addresses = var.static_addresses
+  static_address   = var.static_address
 }
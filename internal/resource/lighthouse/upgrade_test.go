@@ -0,0 +1,117 @@
+package lighthouse_test
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/resource/lighthouse"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/acc"
+)
+
+var _ = DescribeTable("lighthouse state upgrades",
+	func(ctx SpecContext, prior string, expect func(lighthouse.State)) {
+		srv, err := harness.ProviderFactories()[acc.ProviderName]()
+		Expect(err).NotTo(HaveOccurred())
+
+		resp, err := srv.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+			TypeName: "definednet_lighthouse",
+			Version:  0,
+			RawState: &tfprotov6.RawState{JSON: []byte(prior)},
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Diagnostics).To(BeEmpty())
+
+		raw, err := resp.UpgradedState.Unmarshal(lighthouse.Schema.Type().TerraformType(ctx))
+		Expect(err).NotTo(HaveOccurred())
+
+		var state lighthouse.State
+		Expect(tfsdk.State{Schema: lighthouse.Schema, Raw: raw}.Get(ctx, &state)).To(BeEmpty())
+
+		expect(state)
+	},
+	Entry("assert static addresses and tags are converted to sets",
+		`{
+			"id": "host-id",
+			"network_id": "network-id",
+			"role_id": "role-id",
+			"name": "lighthouse.defined.test",
+			"ip_address": "10.0.0.1",
+			"static_addresses": ["127.0.0.1", "172.16.0.1"],
+			"listen_port": 8484,
+			"tags": ["tag:one", "tag:two"],
+			"enrollment_code": "enrollment-code",
+			"metrics": {
+				"enabled": true,
+				"listen": "127.0.0.1:8080",
+				"path": "/metrics",
+				"namespace": "nebula",
+				"subsystem": "lighthouse",
+				"enable_extra_metrics": false
+			}
+		}`,
+		func(state lighthouse.State) {
+			Expect(state.ID.ValueString()).To(Equal("host-id"))
+			Expect(state.NetworkID.ValueString()).To(Equal("network-id"))
+			Expect(state.RoleID.ValueString()).To(Equal("role-id"))
+			Expect(state.Name.ValueString()).To(Equal("lighthouse.defined.test"))
+			Expect(state.IPAddress.ValueString()).To(Equal("10.0.0.1"))
+			Expect(state.ListenPort.ValueInt32()).To(BeEquivalentTo(8484))
+			Expect(state.EnrollmentCode.ValueString()).To(Equal("enrollment-code"))
+			Expect(state.Metrics).NotTo(BeNil())
+			Expect(state.Metrics.Subsystem.ValueString()).To(Equal("lighthouse"))
+			Expect(state.StaticAddresses.SetValue.Equal(types.SetValueMust(types.StringType, []attr.Value{
+				types.StringValue("172.16.0.1"),
+				types.StringValue("127.0.0.1"),
+			}))).To(BeTrue())
+			Expect(state.Tags.SetValue.Equal(types.SetValueMust(types.StringType, []attr.Value{
+				types.StringValue("tag:two"),
+				types.StringValue("tag:one"),
+			}))).To(BeTrue())
+		},
+	),
+	Entry("assert duplicate static addresses and tags are dropped",
+		`{
+			"id": "host-id",
+			"network_id": "network-id",
+			"role_id": null,
+			"name": "lighthouse.defined.test",
+			"ip_address": "10.0.0.1",
+			"static_addresses": ["127.0.0.1", "127.0.0.1"],
+			"listen_port": 8484,
+			"tags": ["tag:one", "tag:one"],
+			"enrollment_code": "enrollment-code",
+			"metrics": null
+		}`,
+		func(state lighthouse.State) {
+			Expect(state.StaticAddresses.SetValue.Equal(types.SetValueMust(types.StringType, []attr.Value{
+				types.StringValue("127.0.0.1"),
+			}))).To(BeTrue())
+			Expect(state.Tags.SetValue.Equal(types.SetValueMust(types.StringType, []attr.Value{
+				types.StringValue("tag:one"),
+			}))).To(BeTrue())
+		},
+	),
+	Entry("assert missing tags remain null",
+		`{
+			"id": "host-id",
+			"network_id": "network-id",
+			"role_id": null,
+			"name": "lighthouse.defined.test",
+			"ip_address": "10.0.0.1",
+			"static_addresses": ["127.0.0.1"],
+			"listen_port": 8484,
+			"tags": null,
+			"enrollment_code": "enrollment-code",
+			"metrics": null
+		}`,
+		func(state lighthouse.State) {
+			Expect(state.Tags.IsNull()).To(BeTrue())
+			Expect(state.RoleID.IsNull()).To(BeTrue())
+			Expect(state.Metrics).To(BeNil())
+		},
+	),
+)
@@ -0,0 +1,36 @@
+package role_test
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/acc"
+)
+
+var _ = Describe("role resource management against recorded Defined.net HTTP API interactions", func() {
+	Specify("role is created and deleted", func() {
+		acc.NewCassette("role").Test(resource.TestStep{
+			ConfigFile: acc.Fixture("role.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("test: Role"),
+				"description": config.StringVariable("Role's description"),
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue(
+					"definednet_role.test",
+					tfjsonpath.New("id"),
+					knownvalue.StringRegexp(regexp.MustCompile(`^role-[A-Z0-9]+$`)),
+				),
+			},
+			Check: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("definednet_role.test", "name", "test: Role"),
+				resource.TestCheckResourceAttr("definednet_role.test", "description", "Role's description"),
+			),
+		})
+	})
+})
@@ -0,0 +1,91 @@
+package role
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/providerdata"
+)
+
+// NewListResource creates a Defined.net role list resource.
+func NewListResource() list.ListResource {
+	return &ListResource{}
+}
+
+// ListResource is Defined.net role list resource.
+type ListResource struct {
+	client definednet.Client
+}
+
+var _ list.ListResource = (*ListResource)(nil)
+var _ list.ListResourceWithConfigure = (*ListResource)(nil)
+
+// Configure configures the list resource.
+func (r *ListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerdata.Data)
+	if !ok {
+		resp.Diagnostics.AddError("Invalid Configuration", "The provider specifies an invalid provider data type")
+		return
+	}
+
+	r.client = data.Client
+}
+
+// Metadata returns the list resource's metadata.
+func (r *ListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_role", req.ProviderTypeName)
+}
+
+// ListResourceConfigSchema returns the list resource's configuration schema.
+func (r *ListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = ListSchema
+}
+
+// List lists roles on Defined.net control plane.
+func (r *ListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	roles, err := definednet.ListRoles(ctx, r.client, definednet.ListRolesRequest{})
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Request Failure", err.Error())
+
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	if req.Limit > 0 && int64(len(roles)) > req.Limit {
+		roles = roles[:req.Limit]
+	}
+
+	tflog.Trace(ctx, "listed Defined.net roles", map[string]any{
+		"count": len(roles),
+	})
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, role := range roles {
+			result := req.NewListResult(ctx)
+			result.DisplayName = role.Name
+
+			result.Diagnostics.Append(result.Identity.Set(ctx, Identity{ID: types.StringValue(role.ID)})...)
+
+			if req.IncludeResource {
+				var state State
+				result.Diagnostics.Append(state.Apply(ctx, &role)...)
+				result.Diagnostics.Append(result.Resource.Set(ctx, state)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package role_test
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck/queryfilter"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/acc"
+	fakeserver "github.com/sendsmaily/terraform-provider-definednet/internal/testing/server"
+)
+
+var _ = DescribeTable("role discovery",
+	func(query resource.TestStep) {
+		harness.Run(resource.TestCase{
+			TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+				tfversion.SkipBelow(tfversion.Version1_14_0),
+			},
+			Steps: []resource.TestStep{
+				{
+					PreConfig: func() {
+						Expect(harness.Server.Roles.Add(fakeserver.Role{
+							ID:          "role-OTHER",
+							Name:        "test: Other role",
+							Description: "Other role's description",
+						})).To(Succeed())
+					},
+					ConfigFile: acc.Fixture("role.tf"),
+					ConfigVariables: config.Variables{
+						"name":        config.StringVariable("test: Role"),
+						"description": config.StringVariable("Role's description"),
+					},
+				},
+				query,
+			},
+		})
+	},
+	Entry("assert roles are listed",
+		resource.TestStep{
+			Query: true,
+			Config: `
+				provider "definednet" {
+				  token = "supersecret"
+				}
+
+				list "definednet_role" "test" {
+				  provider = definednet
+				}
+			`,
+			QueryResultChecks: []querycheck.QueryResultCheck{
+				// The managed role, the other role, and the roles seeded for firewall rule references.
+				querycheck.ExpectLength("definednet_role.test", 4),
+				querycheck.ExpectResourceDisplayName(
+					"definednet_role.test",
+					queryfilter.ByResourceIdentity(map[string]knownvalue.Check{
+						"id": knownvalue.StringExact("role-OTHER"),
+					}),
+					knownvalue.StringExact("test: Other role"),
+				),
+			},
+		},
+	),
+	Entry("assert listed roles include their configuration",
+		resource.TestStep{
+			Query: true,
+			Config: `
+				provider "definednet" {
+				  token = "supersecret"
+				}
+
+				list "definednet_role" "test" {
+				  provider         = definednet
+				  include_resource = true
+				}
+			`,
+			QueryResultChecks: []querycheck.QueryResultCheck{
+				querycheck.ExpectResourceKnownValues(
+					"definednet_role.test",
+					queryfilter.ByResourceIdentity(map[string]knownvalue.Check{
+						"id": knownvalue.StringExact("role-OTHER"),
+					}),
+					[]querycheck.KnownValueCheck{
+						{Path: tfjsonpath.New("name"), KnownValue: knownvalue.StringExact("test: Other role")},
+						{Path: tfjsonpath.New("description"), KnownValue: knownvalue.StringExact("Other role's description")},
+					},
+				),
+			},
+		},
+	),
+)
@@ -4,11 +4,21 @@ import (
 	"context"
 	_ "embed"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/conflict"
 	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/deletionprotection"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/importid"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/providerdata"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/revision"
 )
 
 // NewResource creates a Defined.net Nebula host resource.
@@ -18,12 +28,17 @@ func NewResource() resource.Resource {
 
 // Resource is Defined.net Nebula host resource.
 type Resource struct {
-	client definednet.Client
+	client       definednet.Client
+	locks        *providerdata.Locks
+	references   *providerdata.References
+	requirePorts bool
 }
 
 var _ resource.Resource = (*Resource)(nil)
 var _ resource.ResourceWithConfigure = (*Resource)(nil)
+var _ resource.ResourceWithIdentity = (*Resource)(nil)
 var _ resource.ResourceWithImportState = (*Resource)(nil)
+var _ resource.ResourceWithModifyPlan = (*Resource)(nil)
 
 // Configure configures the resource.
 func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -31,13 +46,16 @@ func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, r
 		return
 	}
 
-	client, ok := req.ProviderData.(definednet.Client)
+	data, ok := req.ProviderData.(*providerdata.Data)
 	if !ok {
-		resp.Diagnostics.AddError("Invalid Configuration", "The provider specifies an invalid client type")
+		resp.Diagnostics.AddError("Invalid Configuration", "The provider specifies an invalid provider data type")
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.locks = data.RoleLocks
+	r.references = data.References
+	r.requirePorts = data.RequireFirewallRulePorts
 }
 
 // Metadata returns the resource's metadata.
@@ -50,6 +68,59 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 	resp.Schema = Schema
 }
 
+// IdentitySchema returns the resource's identity schema.
+func (r *Resource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = IdentitySchema
+}
+
+// ModifyPlan validates the planned rules' role references, and enforces the provider's firewall rule policy on them.
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var rules types.Set
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rule"), &rules)...)
+	if resp.Diagnostics.HasError() || rules.IsUnknown() {
+		return
+	}
+
+	for _, elem := range rules.Elements() {
+		rule, ok := elem.(types.Object)
+		if !ok || rule.IsUnknown() {
+			continue
+		}
+
+		attrs := rule.Attributes()
+
+		if r.references != nil {
+			allowedRoleID, _ := attrs["allowed_role_id"].(types.String)
+			resp.Diagnostics.Append(r.references.ValidateRole(
+				ctx,
+				path.Root("rule").AtSetValue(elem).AtName("allowed_role_id"),
+				allowedRoleID,
+			)...)
+		}
+
+		if !r.requirePorts {
+			continue
+		}
+
+		protocol, ok := attrs["protocol"].(types.String)
+		if !ok || !slices.Contains([]string{"TCP", "UDP"}, protocol.ValueString()) {
+			continue
+		}
+
+		if attrs["port"].IsNull() && attrs["port_range"].IsNull() && attrs["ports"].IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("rule").AtSetValue(elem),
+				"Missing Ports",
+				fmt.Sprintf("The provider requires %s firewall rules to declare allowed ports.", protocol.ValueString()),
+			)
+		}
+	}
+}
+
 // Create creates Nebula hosts on Defined.net control plane.
 func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var state State
@@ -59,43 +130,31 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
+	rules, diags := ExpandRules(ctx, state.FirewallRules)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	role, err := definednet.CreateRole(ctx, r.client, definednet.CreateRoleRequest{
-		Name:        state.Name.ValueString(),
-		Description: state.Description.ValueString(),
-		FirewallRules: lo.Map(state.FirewallRules, func(rule FirewallRule, _ int) (out definednet.FirewallRule) {
-			out.Protocol = rule.Protocol.ValueString()
-			out.Description = rule.Description.ValueString()
-			out.AllowedRoleID = rule.AllowedRoleID.ValueString()
-
-			if lo.IsNotNil(rule.PortRange) {
-				out.PortRange = &definednet.PortRange{
-					From: int(rule.PortRange.From.ValueInt32()),
-					To:   int(rule.PortRange.To.ValueInt32()),
-				}
-			} else if !rule.Port.IsNull() {
-				out.PortRange = &definednet.PortRange{
-					From: int(rule.Port.ValueInt32()),
-					To:   int(rule.Port.ValueInt32()),
-				}
-			}
-
-			resp.Diagnostics.Append(rule.AllowedTags.ElementsAs(ctx, &out.AllowedTags, false)...)
-
-			return out
-		}),
+		Name:          state.Name.ValueString(),
+		Description:   state.Description.ValueString(),
+		FirewallRules: rules,
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("Request Failure", err.Error())
+		resp.Diagnostics.Append(conflict.RoleName(ctx, r.client, err, state.Name.ValueString())...)
 		return
 	}
 
 	resp.Diagnostics.Append(state.Apply(ctx, role)...)
+	resp.Diagnostics.Append(revision.Save(ctx, resp.Private, revision.Role(*role))...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, Identity{ID: state.ID})...)
 
 	tflog.Trace(ctx, "created Defined.net role", map[string]any{
 		"id":   state.ID.String(),
@@ -103,15 +162,40 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 	})
 }
 
-// Delete deletes Nebula hosts from Defined.net control plane.
+// Delete deletes Nebula roles from Defined.net control plane.
+//
+// Deletion is refused, when the role is protected from deletion or hosts are still assigned the role.
 func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var state State
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(deletionprotection.Check("role", state.ID.ValueString(), state.DeletionProtection)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	hosts, err := definednet.ListHosts(ctx, r.client, definednet.ListHostsRequest{})
+	if err != nil {
+		resp.Diagnostics.AddError("Request Failure", err.Error())
+		return
+	}
+
+	assigned := lo.FilterMap(hosts, func(h definednet.Host, _ int) (string, bool) {
+		return strconv.Quote(h.ID), h.RoleID == state.ID.ValueString()
+	})
+
+	if len(assigned) > 0 {
+		resp.Diagnostics.AddError(
+			"Role In Use",
+			fmt.Sprintf(
+				"The role %q is assigned to hosts %s. Assign the hosts another role, before deleting the role.",
+				state.ID.ValueString(), strings.Join(assigned, ", "),
+			),
+		)
+
+		return
+	}
+
 	if err := definednet.DeleteRole(ctx, r.client, definednet.DeleteRoleRequest{
 		ID: state.ID.ValueString(),
 	}); err != nil {
@@ -137,12 +221,24 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		return
 	}
 
+	if state.IgnoreUnmanagedRules.ValueBool() {
+		managed, diags := ExpandRules(ctx, state.FirewallRules)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		role.FirewallRules, _ = PartitionRules(role.FirewallRules, managed)
+	}
+
 	resp.Diagnostics.Append(state.Apply(ctx, role)...)
+	resp.Diagnostics.Append(revision.Save(ctx, resp.Private, revision.Role(*role))...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, Identity{ID: state.ID})...)
 
 	tflog.Trace(ctx, "refreshed Defined.net role", map[string]any{
 		"id":   state.ID.String(),
@@ -151,39 +247,34 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 }
 
 // Update updates Nebula hosts on Defined.net control plane.
+//
+// Unless the role ignores unmanaged firewall rules, the role's firewall rules are replaced by the declared ones.
+// Otherwise, the firewall rules not declared on the role (e.g. ones managed by role firewall rule resources) are
+// retained.
+//
+// The update is refused, when the role has been changed since it was last read.
 func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var state State
+	var state, prior State
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	role, err := definednet.UpdateRole(ctx, r.client, definednet.UpdateRoleRequest{
-		ID:          state.ID.ValueString(),
-		Name:        state.Name.ValueString(),
-		Description: state.Description.ValueString(),
-		FirewallRules: lo.Map(state.FirewallRules, func(rule FirewallRule, _ int) (out definednet.FirewallRule) {
-			out.Protocol = rule.Protocol.ValueString()
-			out.Description = rule.Description.ValueString()
-			out.AllowedRoleID = rule.AllowedRoleID.ValueString()
-
-			if lo.IsNotNil(rule.PortRange) {
-				out.PortRange = &definednet.PortRange{
-					From: int(rule.PortRange.From.ValueInt32()),
-					To:   int(rule.PortRange.To.ValueInt32()),
-				}
-			} else if !rule.Port.IsNull() {
-				out.PortRange = &definednet.PortRange{
-					From: int(rule.Port.ValueInt32()),
-					To:   int(rule.Port.ValueInt32()),
-				}
-			}
-
-			resp.Diagnostics.Append(rule.AllowedTags.ElementsAs(ctx, &out.AllowedTags, false)...)
-
-			return out
-		}),
+	declared, diags := ExpandRules(ctx, state.FirewallRules)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rules := declared
+
+	unlock := r.locks.Lock(state.ID.ValueString())
+	defer unlock()
+
+	current, err := definednet.GetRole(ctx, r.client, definednet.GetRoleRequest{
+		ID: state.ID.ValueString(),
 	})
 
 	if err != nil {
@@ -191,12 +282,53 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
+	managed, diags := ExpandRules(ctx, prior.FirewallRules)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	owned, unmanaged := PartitionRules(current.FirewallRules, managed)
+
+	// Firewall rules not declared on the role are disregarded, when the role ignores unmanaged rules.
+	seen := *current
+	if prior.IgnoreUnmanagedRules.ValueBool() {
+		seen.FirewallRules = owned
+	}
+
+	resp.Diagnostics.Append(revision.Check(ctx, req.Private, "role", revision.Role(seen))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.IgnoreUnmanagedRules.ValueBool() {
+		rules = append(unmanaged, rules...)
+	}
+
+	role, err := definednet.UpdateRole(ctx, r.client, definednet.UpdateRoleRequest{
+		ID:            state.ID.ValueString(),
+		Name:          state.Name.ValueString(),
+		Description:   state.Description.ValueString(),
+		FirewallRules: rules,
+	})
+
+	if err != nil {
+		resp.Diagnostics.Append(conflict.RoleName(ctx, r.client, err, state.Name.ValueString())...)
+		return
+	}
+
+	if state.IgnoreUnmanagedRules.ValueBool() {
+		role.FirewallRules, _ = PartitionRules(role.FirewallRules, declared)
+	}
+
 	resp.Diagnostics.Append(state.Apply(ctx, role)...)
+	resp.Diagnostics.Append(revision.Save(ctx, resp.Private, revision.Role(*role))...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, Identity{ID: state.ID})...)
 
 	tflog.Trace(ctx, "updated Defined.net role", map[string]any{
 		"id":   state.ID.String(),
@@ -205,9 +337,26 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 }
 
 // ImportState imports Nebula hosts from Defined.net control plane.
+//
+// Roles are imported either by their ID or by their name, in the format role/<role-name>, or by the ID in their
+// resource identity.
 func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importID := req.ID
+	if lo.IsEmpty(importID) {
+		resp.Diagnostics.Append(req.Identity.GetAttribute(ctx, path.Root("id"), &importID)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	id, err := importid.ResolveRole(ctx, r.client, importID)
+	if err != nil {
+		resp.Diagnostics.AddError("Import Failure", err.Error())
+		return
+	}
+
 	role, err := definednet.GetRole(ctx, r.client, definednet.GetRoleRequest{
-		ID: req.ID,
+		ID: id,
 	})
 
 	if err != nil {
@@ -217,11 +366,13 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 
 	var state State
 	resp.Diagnostics.Append(state.Apply(ctx, role)...)
+	resp.Diagnostics.Append(revision.Save(ctx, resp.Private, revision.Role(*role))...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, Identity{ID: state.ID})...)
 
 	tflog.Trace(ctx, "imported Defined.net role", map[string]any{
 		"id":   state.ID.String(),
@@ -1,35 +1,31 @@
 package role_test
 
 import (
+	"fmt"
 	"regexp"
 
-	"github.com/hashicorp/terraform-plugin-framework/providerserver"
-	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/config"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
 	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/acc"
+	fakeserver "github.com/sendsmaily/terraform-provider-definednet/internal/testing/server"
 )
 
 var _ = DescribeTable("role resource management",
 	func(steps ...resource.TestStep) {
-		resource.Test(GinkgoT(), resource.TestCase{
-			Steps: lo.Map(steps, func(step resource.TestStep, _ int) resource.TestStep {
-				step.ProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-					"definednet": providerserver.NewProtocol6WithError(providerFactory()),
-				}
-
-				return step
-			}),
-		})
+		harness.Test(steps...)
 	},
 	Entry("assert role is created in expected configuration",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/role.tf"),
+			ConfigFile: acc.Fixture("role.tf"),
 			ConfigVariables: config.Variables{
 				"name":        config.StringVariable("test: Role"),
 				"description": config.StringVariable("Role's description"),
@@ -55,7 +51,7 @@ var _ = DescribeTable("role resource management",
 	),
 	Entry("assert role is updated with expected configuration",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/role.tf"),
+			ConfigFile: acc.Fixture("role.tf"),
 			ConfigVariables: config.Variables{
 				"name":        config.StringVariable("test: Role"),
 				"description": config.StringVariable("Role's description"),
@@ -68,7 +64,7 @@ var _ = DescribeTable("role resource management",
 			},
 		},
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/role.tf"),
+			ConfigFile: acc.Fixture("role.tf"),
 			ConfigVariables: config.Variables{
 				"name":        config.StringVariable("test: Updated role"),
 				"description": config.StringVariable("Updated role's description"),
@@ -87,39 +83,44 @@ var _ = DescribeTable("role resource management",
 	),
 	Entry("assert importing the role populates the state",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/role.tf"),
+			ConfigFile: acc.Fixture("role.tf"),
 			ConfigVariables: config.Variables{
 				"name":        config.StringVariable("test: Role"),
 				"description": config.StringVariable("Role's description"),
 			},
 		},
-		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/role.tf"),
+		acc.ImportStateVerify(resource.TestStep{
+			ConfigFile: acc.Fixture("role.tf"),
 			ConfigVariables: config.Variables{
 				"name": config.StringVariable("test: Role"),
 			},
-			ResourceName:      "definednet_role.test",
-			ImportState:       true,
-			ImportStateVerify: true,
+		}, "definednet_role.test"),
+	),
+	Entry("assert importing the role by name populates the state",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("test: Role"),
+				"description": config.StringVariable("Role's description"),
+			},
 		},
+		acc.ImportStateVerify(resource.TestStep{
+			ConfigFile: acc.Fixture("role.tf"),
+			ConfigVariables: config.Variables{
+				"name": config.StringVariable("test: Role"),
+			},
+			ImportStateId: "role/test: Role",
+		}, "definednet_role.test"),
 	),
 )
 
 var _ = DescribeTable("port-based firewall management",
 	func(steps ...resource.TestStep) {
-		resource.Test(GinkgoT(), resource.TestCase{
-			Steps: lo.Map(steps, func(step resource.TestStep, _ int) resource.TestStep {
-				step.ProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-					"definednet": providerserver.NewProtocol6WithError(providerFactory()),
-				}
-
-				return step
-			}),
-		})
+		harness.Test(steps...)
 	},
 	Entry("assert roles with single port rules can be created",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/role_port.tf"),
+			ConfigFile: acc.Fixture("role_port.tf"),
 			ConfigVariables: config.Variables{
 				"name":        config.StringVariable("test: Role"),
 				"description": config.StringVariable("Role's description"),
@@ -160,9 +161,13 @@ var _ = DescribeTable("port-based firewall management",
 						knownvalue.ObjectExact(map[string]knownvalue.Check{
 							"port":            knownvalue.Int32Exact(22),
 							"port_range":      knownvalue.Null(),
+							"ports":           knownvalue.Null(),
 							"protocol":        knownvalue.StringExact("TCP"),
 							"description":     knownvalue.StringExact("SSH access"),
 							"allowed_role_id": knownvalue.StringExact("role:abcdef"),
+							"allowed_cidr":    knownvalue.Null(),
+							"allow_any_host":  knownvalue.Null(),
+							"local_cidr":      knownvalue.Null(),
 							"allowed_tags": knownvalue.SetExact([]knownvalue.Check{
 								knownvalue.StringExact("tag:one"),
 								knownvalue.StringExact("tag:two"),
@@ -171,9 +176,13 @@ var _ = DescribeTable("port-based firewall management",
 						knownvalue.ObjectExact(map[string]knownvalue.Check{
 							"port":            knownvalue.Int32Exact(443),
 							"port_range":      knownvalue.Null(),
+							"ports":           knownvalue.Null(),
 							"protocol":        knownvalue.StringExact("TCP"),
 							"description":     knownvalue.StringExact("HTTPS access"),
 							"allowed_role_id": knownvalue.StringExact("role:123456"),
+							"allowed_cidr":    knownvalue.Null(),
+							"allow_any_host":  knownvalue.Null(),
+							"local_cidr":      knownvalue.Null(),
 							"allowed_tags": knownvalue.SetExact([]knownvalue.Check{
 								knownvalue.StringExact("tag:https_one"),
 								knownvalue.StringExact("tag:https_two"),
@@ -186,14 +195,14 @@ var _ = DescribeTable("port-based firewall management",
 	),
 	Entry("assert roles with single port rules can be updated",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/role_port.tf"),
+			ConfigFile: acc.Fixture("role_port.tf"),
 			ConfigVariables: config.Variables{
 				"name":        config.StringVariable("test: Role"),
 				"description": config.StringVariable("Role's description"),
 			},
 		},
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/role_port.tf"),
+			ConfigFile: acc.Fixture("role_port.tf"),
 			ConfigVariables: config.Variables{
 				"name":        config.StringVariable("test: Role"),
 				"description": config.StringVariable("Role's description"),
@@ -234,9 +243,13 @@ var _ = DescribeTable("port-based firewall management",
 						knownvalue.ObjectExact(map[string]knownvalue.Check{
 							"port":            knownvalue.Int32Exact(22),
 							"port_range":      knownvalue.Null(),
+							"ports":           knownvalue.Null(),
 							"protocol":        knownvalue.StringExact("TCP"),
 							"description":     knownvalue.StringExact("SSH access"),
 							"allowed_role_id": knownvalue.StringExact("role:abcdef"),
+							"allowed_cidr":    knownvalue.Null(),
+							"allow_any_host":  knownvalue.Null(),
+							"local_cidr":      knownvalue.Null(),
 							"allowed_tags": knownvalue.SetExact([]knownvalue.Check{
 								knownvalue.StringExact("tag:one"),
 								knownvalue.StringExact("tag:two"),
@@ -245,9 +258,13 @@ var _ = DescribeTable("port-based firewall management",
 						knownvalue.ObjectExact(map[string]knownvalue.Check{
 							"port":            knownvalue.Int32Exact(443),
 							"port_range":      knownvalue.Null(),
+							"ports":           knownvalue.Null(),
 							"protocol":        knownvalue.StringExact("TCP"),
 							"description":     knownvalue.StringExact("HTTPS access"),
 							"allowed_role_id": knownvalue.StringExact("role:123456"),
+							"allowed_cidr":    knownvalue.Null(),
+							"allow_any_host":  knownvalue.Null(),
+							"local_cidr":      knownvalue.Null(),
 							"allowed_tags": knownvalue.SetExact([]knownvalue.Check{
 								knownvalue.StringExact("tag:https_one"),
 								knownvalue.StringExact("tag:https_two"),
@@ -260,7 +277,7 @@ var _ = DescribeTable("port-based firewall management",
 	),
 	Entry("assert importing the role populates the state",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/role_port.tf"),
+			ConfigFile: acc.Fixture("role_port.tf"),
 			ConfigVariables: config.Variables{
 				"name":        config.StringVariable("test: Role"),
 				"description": config.StringVariable("Role's description"),
@@ -288,33 +305,53 @@ var _ = DescribeTable("port-based firewall management",
 				),
 			},
 		},
-		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/role_port.tf"),
+		acc.ImportStateVerify(resource.TestStep{
+			ConfigFile: acc.Fixture("role_port.tf"),
 			ConfigVariables: config.Variables{
 				"name": config.StringVariable("test: Role"),
 			},
-			ResourceName:      "definednet_role.test",
-			ImportState:       true,
-			ImportStateVerify: true,
+		}, "definednet_role.test"),
+	),
+	Entry("assert importing rules only differing by their port populates the state",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role_port.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("test: Role"),
+				"description": config.StringVariable("Role's description"),
+				"rules": config.SetVariable(
+					config.ObjectVariable(map[string]config.Variable{
+						"port":            config.IntegerVariable(80),
+						"protocol":        config.StringVariable("TCP"),
+						"description":     config.StringVariable("Web access"),
+						"allowed_role_id": config.StringVariable("role:abcdef"),
+						"allowed_tags":    config.SetVariable(config.StringVariable("tag:one")),
+					}),
+					config.ObjectVariable(map[string]config.Variable{
+						"port":            config.IntegerVariable(443),
+						"protocol":        config.StringVariable("TCP"),
+						"description":     config.StringVariable("Web access"),
+						"allowed_role_id": config.StringVariable("role:abcdef"),
+						"allowed_tags":    config.SetVariable(config.StringVariable("tag:one")),
+					}),
+				),
+			},
 		},
+		acc.ImportStateVerify(resource.TestStep{
+			ConfigFile: acc.Fixture("role_port.tf"),
+			ConfigVariables: config.Variables{
+				"name": config.StringVariable("test: Role"),
+			},
+		}, "definednet_role.test"),
 	),
 )
 
 var _ = DescribeTable("port range-based firewall management",
 	func(steps ...resource.TestStep) {
-		resource.Test(GinkgoT(), resource.TestCase{
-			Steps: lo.Map(steps, func(step resource.TestStep, _ int) resource.TestStep {
-				step.ProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-					"definednet": providerserver.NewProtocol6WithError(providerFactory()),
-				}
-
-				return step
-			}),
-		})
+		harness.Test(steps...)
 	},
 	Entry("assert roles with port range rules can be created",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/role_port_range.tf"),
+			ConfigFile: acc.Fixture("role_port_range.tf"),
 			ConfigVariables: config.Variables{
 				"name":        config.StringVariable("test: Role"),
 				"description": config.StringVariable("Role's description"),
@@ -360,9 +397,13 @@ var _ = DescribeTable("port range-based firewall management",
 								"from": knownvalue.Int32Exact(1024),
 								"to":   knownvalue.Int32Exact(2048),
 							}),
+							"ports":           knownvalue.Null(),
 							"protocol":        knownvalue.StringExact("TCP"),
 							"description":     knownvalue.StringExact("Range one"),
 							"allowed_role_id": knownvalue.StringExact("role:abcdef"),
+							"allowed_cidr":    knownvalue.Null(),
+							"allow_any_host":  knownvalue.Null(),
+							"local_cidr":      knownvalue.Null(),
 							"allowed_tags": knownvalue.SetExact([]knownvalue.Check{
 								knownvalue.StringExact("tag:one"),
 								knownvalue.StringExact("tag:two"),
@@ -374,9 +415,13 @@ var _ = DescribeTable("port range-based firewall management",
 								"from": knownvalue.Int32Exact(4096),
 								"to":   knownvalue.Int32Exact(8192),
 							}),
+							"ports":           knownvalue.Null(),
 							"protocol":        knownvalue.StringExact("TCP"),
 							"description":     knownvalue.StringExact("Range two"),
 							"allowed_role_id": knownvalue.StringExact("role:123456"),
+							"allowed_cidr":    knownvalue.Null(),
+							"allow_any_host":  knownvalue.Null(),
+							"local_cidr":      knownvalue.Null(),
 							"allowed_tags": knownvalue.SetExact([]knownvalue.Check{
 								knownvalue.StringExact("tag:https_one"),
 								knownvalue.StringExact("tag:https_two"),
@@ -389,14 +434,14 @@ var _ = DescribeTable("port range-based firewall management",
 	),
 	Entry("assert roles with port range rules can be updated",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/role_port_range.tf"),
+			ConfigFile: acc.Fixture("role_port_range.tf"),
 			ConfigVariables: config.Variables{
 				"name":        config.StringVariable("test: Role"),
 				"description": config.StringVariable("Role's description"),
 			},
 		},
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/role_port_range.tf"),
+			ConfigFile: acc.Fixture("role_port_range.tf"),
 			ConfigVariables: config.Variables{
 				"name":        config.StringVariable("test: Role"),
 				"description": config.StringVariable("Role's description"),
@@ -442,9 +487,13 @@ var _ = DescribeTable("port range-based firewall management",
 								"from": knownvalue.Int32Exact(1024),
 								"to":   knownvalue.Int32Exact(2048),
 							}),
+							"ports":           knownvalue.Null(),
 							"protocol":        knownvalue.StringExact("TCP"),
 							"description":     knownvalue.StringExact("Range one"),
 							"allowed_role_id": knownvalue.StringExact("role:abcdef"),
+							"allowed_cidr":    knownvalue.Null(),
+							"allow_any_host":  knownvalue.Null(),
+							"local_cidr":      knownvalue.Null(),
 							"allowed_tags": knownvalue.SetExact([]knownvalue.Check{
 								knownvalue.StringExact("tag:one"),
 								knownvalue.StringExact("tag:two"),
@@ -456,9 +505,13 @@ var _ = DescribeTable("port range-based firewall management",
 								"from": knownvalue.Int32Exact(4096),
 								"to":   knownvalue.Int32Exact(8192),
 							}),
+							"ports":           knownvalue.Null(),
 							"protocol":        knownvalue.StringExact("TCP"),
 							"description":     knownvalue.StringExact("Range two"),
 							"allowed_role_id": knownvalue.StringExact("role:123456"),
+							"allowed_cidr":    knownvalue.Null(),
+							"allow_any_host":  knownvalue.Null(),
+							"local_cidr":      knownvalue.Null(),
 							"allowed_tags": knownvalue.SetExact([]knownvalue.Check{
 								knownvalue.StringExact("tag:https_one"),
 								knownvalue.StringExact("tag:https_two"),
@@ -471,7 +524,7 @@ var _ = DescribeTable("port range-based firewall management",
 	),
 	Entry("assert importing the role populates the state",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/role_port_range.tf"),
+			ConfigFile: acc.Fixture("role_port_range.tf"),
 			ConfigVariables: config.Variables{
 				"name":        config.StringVariable("test: Role"),
 				"description": config.StringVariable("Role's description"),
@@ -501,33 +554,249 @@ var _ = DescribeTable("port range-based firewall management",
 				),
 			},
 		},
+		acc.ImportStateVerify(resource.TestStep{
+			ConfigFile: acc.Fixture("role_port_range.tf"),
+			ConfigVariables: config.Variables{
+				"name": config.StringVariable("test: Role"),
+			},
+		}, "definednet_role.test"),
+	),
+)
+
+var _ = DescribeTable("multi-port firewall management",
+	func(steps ...resource.TestStep) {
+		harness.Test(steps...)
+	},
+	Entry("assert roles with multi-port rules can be created",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role_ports.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("test: Role"),
+				"description": config.StringVariable("Role's description"),
+				"rules": config.SetVariable(
+					config.ObjectVariable(map[string]config.Variable{
+						"ports": config.SetVariable(
+							config.StringVariable("80"),
+							config.StringVariable("443"),
+							config.StringVariable("8000-8100"),
+						),
+						"protocol":        config.StringVariable("TCP"),
+						"description":     config.StringVariable("Web access"),
+						"allowed_role_id": config.StringVariable("role:abcdef"),
+						"allowed_tags": config.SetVariable(
+							config.StringVariable("tag:one"),
+							config.StringVariable("tag:two"),
+						),
+					}),
+				),
+			},
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					// Assert sanity.
+					plancheck.ExpectResourceAction("definednet_role.test", plancheck.ResourceActionCreate),
+				},
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue(
+					"definednet_role.test",
+					tfjsonpath.New("rule"),
+					knownvalue.SetExact([]knownvalue.Check{
+						knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"port":       knownvalue.Null(),
+							"port_range": knownvalue.Null(),
+							"ports": knownvalue.SetExact([]knownvalue.Check{
+								knownvalue.StringExact("80"),
+								knownvalue.StringExact("443"),
+								knownvalue.StringExact("8000-8100"),
+							}),
+							"protocol":        knownvalue.StringExact("TCP"),
+							"description":     knownvalue.StringExact("Web access"),
+							"allowed_role_id": knownvalue.StringExact("role:abcdef"),
+							"allowed_cidr":    knownvalue.Null(),
+							"allow_any_host":  knownvalue.Null(),
+							"local_cidr":      knownvalue.Null(),
+							"allowed_tags": knownvalue.SetExact([]knownvalue.Check{
+								knownvalue.StringExact("tag:one"),
+								knownvalue.StringExact("tag:two"),
+							}),
+						}),
+					}),
+				),
+			},
+		},
+	),
+	Entry("assert roles with multi-port rules can be updated",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role_ports.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("test: Role"),
+				"description": config.StringVariable("Role's description"),
+				"rules": config.SetVariable(
+					config.ObjectVariable(map[string]config.Variable{
+						"ports": config.SetVariable(
+							config.StringVariable("80"),
+							config.StringVariable("443"),
+						),
+						"protocol":        config.StringVariable("TCP"),
+						"description":     config.StringVariable("Web access"),
+						"allowed_role_id": config.StringVariable("role:abcdef"),
+						"allowed_tags": config.SetVariable(
+							config.StringVariable("tag:one"),
+						),
+					}),
+				),
+			},
+		},
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role_ports.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("test: Role"),
+				"description": config.StringVariable("Role's description"),
+				"rules": config.SetVariable(
+					config.ObjectVariable(map[string]config.Variable{
+						"ports": config.SetVariable(
+							config.StringVariable("80"),
+							config.StringVariable("443"),
+							config.StringVariable("8443"),
+						),
+						"protocol":        config.StringVariable("TCP"),
+						"description":     config.StringVariable("Web access"),
+						"allowed_role_id": config.StringVariable("role:abcdef"),
+						"allowed_tags": config.SetVariable(
+							config.StringVariable("tag:one"),
+						),
+					}),
+				),
+			},
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					// Assert sanity.
+					plancheck.ExpectResourceAction("definednet_role.test", plancheck.ResourceActionUpdate),
+				},
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue(
+					"definednet_role.test",
+					tfjsonpath.New("rule"),
+					knownvalue.SetExact([]knownvalue.Check{
+						knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"port":       knownvalue.Null(),
+							"port_range": knownvalue.Null(),
+							"ports": knownvalue.SetExact([]knownvalue.Check{
+								knownvalue.StringExact("80"),
+								knownvalue.StringExact("443"),
+								knownvalue.StringExact("8443"),
+							}),
+							"protocol":        knownvalue.StringExact("TCP"),
+							"description":     knownvalue.StringExact("Web access"),
+							"allowed_role_id": knownvalue.StringExact("role:abcdef"),
+							"allowed_cidr":    knownvalue.Null(),
+							"allow_any_host":  knownvalue.Null(),
+							"local_cidr":      knownvalue.Null(),
+							"allowed_tags": knownvalue.SetExact([]knownvalue.Check{
+								knownvalue.StringExact("tag:one"),
+							}),
+						}),
+					}),
+				),
+			},
+		},
+	),
+	Entry("assert single port rules differing only by port are not collapsed",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role_port.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("test: Role"),
+				"description": config.StringVariable("Role's description"),
+				"rules": config.SetVariable(
+					config.ObjectVariable(map[string]config.Variable{
+						"port":            config.IntegerVariable(80),
+						"protocol":        config.StringVariable("TCP"),
+						"description":     config.StringVariable("Web access"),
+						"allowed_role_id": config.StringVariable("role:abcdef"),
+						"allowed_tags":    config.SetVariable(config.StringVariable("tag:one")),
+					}),
+					config.ObjectVariable(map[string]config.Variable{
+						"port":            config.IntegerVariable(443),
+						"protocol":        config.StringVariable("TCP"),
+						"description":     config.StringVariable("Web access"),
+						"allowed_role_id": config.StringVariable("role:abcdef"),
+						"allowed_tags":    config.SetVariable(config.StringVariable("tag:one")),
+					}),
+				),
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue(
+					"definednet_role.test",
+					tfjsonpath.New("rule"),
+					knownvalue.SetExact([]knownvalue.Check{
+						knownvalue.ObjectPartial(map[string]knownvalue.Check{
+							"port":  knownvalue.Int32Exact(80),
+							"ports": knownvalue.Null(),
+						}),
+						knownvalue.ObjectPartial(map[string]knownvalue.Check{
+							"port":  knownvalue.Int32Exact(443),
+							"ports": knownvalue.Null(),
+						}),
+					}),
+				),
+			},
+		},
+	),
+	Entry("assert importing the role populates the state",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role_ports.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("test: Role"),
+				"description": config.StringVariable("Role's description"),
+				"rules": config.SetVariable(
+					config.ObjectVariable(map[string]config.Variable{
+						"ports": config.SetVariable(
+							config.StringVariable("80"),
+							config.StringVariable("443"),
+							config.StringVariable("8000-8100"),
+						),
+						"protocol":        config.StringVariable("TCP"),
+						"description":     config.StringVariable("Web access"),
+						"allowed_role_id": config.StringVariable("role:abcdef"),
+						"allowed_tags": config.SetVariable(
+							config.StringVariable("tag:one"),
+							config.StringVariable("tag:two"),
+						),
+					}),
+				),
+			},
+		},
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/role_port_range.tf"),
+			ConfigFile: acc.Fixture("role_ports.tf"),
 			ConfigVariables: config.Variables{
 				"name": config.StringVariable("test: Role"),
 			},
-			ResourceName:      "definednet_role.test",
-			ImportState:       true,
-			ImportStateVerify: true,
+			ResourceName: "definednet_role.test",
+			ImportState:  true,
+			// Imported roles declare a rule per port, as their rules' ports can not be told apart.
+			ImportStateCheck: func(states []*terraform.InstanceState) error {
+				if len(states) != 1 {
+					return fmt.Errorf("expected 1 imported resource, got %d", len(states))
+				}
+
+				if rules := states[0].Attributes["rule.#"]; rules != "3" {
+					return fmt.Errorf("expected 3 imported rules, got %s", rules)
+				}
+
+				return nil
+			},
 		},
 	),
 )
 
 var _ = DescribeTable("any port firewall management",
 	func(steps ...resource.TestStep) {
-		resource.Test(GinkgoT(), resource.TestCase{
-			Steps: lo.Map(steps, func(step resource.TestStep, _ int) resource.TestStep {
-				step.ProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-					"definednet": providerserver.NewProtocol6WithError(providerFactory()),
-				}
-
-				return step
-			}),
-		})
+		harness.Test(steps...)
 	},
 	Entry("assert roles with any port rules can be created",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/role_any_port.tf"),
+			ConfigFile: acc.Fixture("role_any_port.tf"),
 			ConfigVariables: config.Variables{
 				"name":        config.StringVariable("test: Role"),
 				"description": config.StringVariable("Role's description"),
@@ -566,9 +835,13 @@ var _ = DescribeTable("any port firewall management",
 						knownvalue.ObjectExact(map[string]knownvalue.Check{
 							"port":            knownvalue.Null(),
 							"port_range":      knownvalue.Null(),
+							"ports":           knownvalue.Null(),
 							"protocol":        knownvalue.StringExact("TCP"),
 							"description":     knownvalue.StringExact("SSH access"),
 							"allowed_role_id": knownvalue.StringExact("role:abcdef"),
+							"allowed_cidr":    knownvalue.Null(),
+							"allow_any_host":  knownvalue.Null(),
+							"local_cidr":      knownvalue.Null(),
 							"allowed_tags": knownvalue.SetExact([]knownvalue.Check{
 								knownvalue.StringExact("tag:one"),
 								knownvalue.StringExact("tag:two"),
@@ -577,9 +850,13 @@ var _ = DescribeTable("any port firewall management",
 						knownvalue.ObjectExact(map[string]knownvalue.Check{
 							"port":            knownvalue.Null(),
 							"port_range":      knownvalue.Null(),
+							"ports":           knownvalue.Null(),
 							"protocol":        knownvalue.StringExact("TCP"),
 							"description":     knownvalue.StringExact("HTTPS access"),
 							"allowed_role_id": knownvalue.StringExact("role:123456"),
+							"allowed_cidr":    knownvalue.Null(),
+							"allow_any_host":  knownvalue.Null(),
+							"local_cidr":      knownvalue.Null(),
 							"allowed_tags": knownvalue.SetExact([]knownvalue.Check{
 								knownvalue.StringExact("tag:https_one"),
 								knownvalue.StringExact("tag:https_two"),
@@ -592,14 +869,14 @@ var _ = DescribeTable("any port firewall management",
 	),
 	Entry("assert roles with any port rules can be updated",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/role_any_port.tf"),
+			ConfigFile: acc.Fixture("role_any_port.tf"),
 			ConfigVariables: config.Variables{
 				"name":        config.StringVariable("test: Role"),
 				"description": config.StringVariable("Role's description"),
 			},
 		},
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/role_any_port.tf"),
+			ConfigFile: acc.Fixture("role_any_port.tf"),
 			ConfigVariables: config.Variables{
 				"name":        config.StringVariable("test: Role"),
 				"description": config.StringVariable("Role's description"),
@@ -638,9 +915,13 @@ var _ = DescribeTable("any port firewall management",
 						knownvalue.ObjectExact(map[string]knownvalue.Check{
 							"port":            knownvalue.Null(),
 							"port_range":      knownvalue.Null(),
+							"ports":           knownvalue.Null(),
 							"protocol":        knownvalue.StringExact("TCP"),
 							"description":     knownvalue.StringExact("SSH access"),
 							"allowed_role_id": knownvalue.StringExact("role:abcdef"),
+							"allowed_cidr":    knownvalue.Null(),
+							"allow_any_host":  knownvalue.Null(),
+							"local_cidr":      knownvalue.Null(),
 							"allowed_tags": knownvalue.SetExact([]knownvalue.Check{
 								knownvalue.StringExact("tag:one"),
 								knownvalue.StringExact("tag:two"),
@@ -649,9 +930,13 @@ var _ = DescribeTable("any port firewall management",
 						knownvalue.ObjectExact(map[string]knownvalue.Check{
 							"port":            knownvalue.Null(),
 							"port_range":      knownvalue.Null(),
+							"ports":           knownvalue.Null(),
 							"protocol":        knownvalue.StringExact("TCP"),
 							"description":     knownvalue.StringExact("HTTPS access"),
 							"allowed_role_id": knownvalue.StringExact("role:123456"),
+							"allowed_cidr":    knownvalue.Null(),
+							"allow_any_host":  knownvalue.Null(),
+							"local_cidr":      knownvalue.Null(),
 							"allowed_tags": knownvalue.SetExact([]knownvalue.Check{
 								knownvalue.StringExact("tag:https_one"),
 								knownvalue.StringExact("tag:https_two"),
@@ -664,7 +949,7 @@ var _ = DescribeTable("any port firewall management",
 	),
 	Entry("assert importing the role populates the state",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/role_any_port.tf"),
+			ConfigFile: acc.Fixture("role_any_port.tf"),
 			ConfigVariables: config.Variables{
 				"name":        config.StringVariable("test: Role"),
 				"description": config.StringVariable("Role's description"),
@@ -690,33 +975,22 @@ var _ = DescribeTable("any port firewall management",
 				),
 			},
 		},
-		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/role_any_port.tf"),
+		acc.ImportStateVerify(resource.TestStep{
+			ConfigFile: acc.Fixture("role_any_port.tf"),
 			ConfigVariables: config.Variables{
 				"name": config.StringVariable("test: Role"),
 			},
-			ResourceName:      "definednet_role.test",
-			ImportState:       true,
-			ImportStateVerify: true,
-		},
+		}, "definednet_role.test"),
 	),
 )
 
 var _ = DescribeTable("any host firewall management",
 	func(steps ...resource.TestStep) {
-		resource.Test(GinkgoT(), resource.TestCase{
-			Steps: lo.Map(steps, func(step resource.TestStep, _ int) resource.TestStep {
-				step.ProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-					"definednet": providerserver.NewProtocol6WithError(providerFactory()),
-				}
-
-				return step
-			}),
-		})
+		harness.Test(steps...)
 	},
 	Entry("assert roles with any host rules can be created",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/role_any_host.tf"),
+			ConfigFile: acc.Fixture("role_any_host.tf"),
 			ConfigVariables: config.Variables{
 				"name":        config.StringVariable("test: Role"),
 				"description": config.StringVariable("Role's description"),
@@ -747,17 +1021,25 @@ var _ = DescribeTable("any host firewall management",
 						knownvalue.ObjectExact(map[string]knownvalue.Check{
 							"port":            knownvalue.Int32Exact(22),
 							"port_range":      knownvalue.Null(),
+							"ports":           knownvalue.Null(),
 							"protocol":        knownvalue.StringExact("TCP"),
 							"description":     knownvalue.StringExact("SSH access"),
 							"allowed_role_id": knownvalue.Null(),
+							"allowed_cidr":    knownvalue.Null(),
+							"allow_any_host":  knownvalue.Null(),
+							"local_cidr":      knownvalue.Null(),
 							"allowed_tags":    knownvalue.Null(),
 						}),
 						knownvalue.ObjectExact(map[string]knownvalue.Check{
 							"port":            knownvalue.Int32Exact(443),
 							"port_range":      knownvalue.Null(),
+							"ports":           knownvalue.Null(),
 							"protocol":        knownvalue.StringExact("TCP"),
 							"description":     knownvalue.StringExact("HTTPS access"),
 							"allowed_role_id": knownvalue.Null(),
+							"allowed_cidr":    knownvalue.Null(),
+							"allow_any_host":  knownvalue.Null(),
+							"local_cidr":      knownvalue.Null(),
 							"allowed_tags":    knownvalue.Null(),
 						}),
 					}),
@@ -767,14 +1049,14 @@ var _ = DescribeTable("any host firewall management",
 	),
 	Entry("assert roles with any host rules can be updated",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/role_any_host.tf"),
+			ConfigFile: acc.Fixture("role_any_host.tf"),
 			ConfigVariables: config.Variables{
 				"name":        config.StringVariable("test: Role"),
 				"description": config.StringVariable("Role's description"),
 			},
 		},
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/role_any_host.tf"),
+			ConfigFile: acc.Fixture("role_any_host.tf"),
 			ConfigVariables: config.Variables{
 				"name":        config.StringVariable("test: Role"),
 				"description": config.StringVariable("Role's description"),
@@ -805,27 +1087,84 @@ var _ = DescribeTable("any host firewall management",
 						knownvalue.ObjectExact(map[string]knownvalue.Check{
 							"port":            knownvalue.Int32Exact(22),
 							"port_range":      knownvalue.Null(),
+							"ports":           knownvalue.Null(),
 							"protocol":        knownvalue.StringExact("TCP"),
 							"description":     knownvalue.StringExact("SSH access"),
 							"allowed_role_id": knownvalue.Null(),
+							"allowed_cidr":    knownvalue.Null(),
+							"allow_any_host":  knownvalue.Null(),
+							"local_cidr":      knownvalue.Null(),
 							"allowed_tags":    knownvalue.Null(),
 						}),
 						knownvalue.ObjectExact(map[string]knownvalue.Check{
 							"port":            knownvalue.Int32Exact(443),
 							"port_range":      knownvalue.Null(),
+							"ports":           knownvalue.Null(),
 							"protocol":        knownvalue.StringExact("TCP"),
 							"description":     knownvalue.StringExact("HTTPS access"),
 							"allowed_role_id": knownvalue.Null(),
+							"allowed_cidr":    knownvalue.Null(),
+							"allow_any_host":  knownvalue.Null(),
+							"local_cidr":      knownvalue.Null(),
+							"allowed_tags":    knownvalue.Null(),
+						}),
+					}),
+				),
+			},
+		},
+	),
+	Entry("assert any host can be allowed explicitly",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role_allow_any_host.tf"),
+			ConfigVariables: config.Variables{
+				"name": config.StringVariable("test: Role"),
+				"rules": config.SetVariable(
+					config.ObjectVariable(map[string]config.Variable{
+						"port":        config.IntegerVariable(22),
+						"protocol":    config.StringVariable("TCP"),
+						"description": config.StringVariable("SSH access"),
+					}),
+				),
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue(
+					"definednet_role.test",
+					tfjsonpath.New("rule"),
+					knownvalue.SetExact([]knownvalue.Check{
+						knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"port":            knownvalue.Int32Exact(22),
+							"port_range":      knownvalue.Null(),
+							"ports":           knownvalue.Null(),
+							"protocol":        knownvalue.StringExact("TCP"),
+							"description":     knownvalue.StringExact("SSH access"),
+							"allowed_role_id": knownvalue.Null(),
+							"allowed_cidr":    knownvalue.Null(),
+							"allow_any_host":  knownvalue.Bool(true),
+							"local_cidr":      knownvalue.Null(),
 							"allowed_tags":    knownvalue.Null(),
 						}),
 					}),
 				),
 			},
 		},
+		// Assert the explicit flag survives refreshing the state.
+		acc.ExpectEmptyPlan(resource.TestStep{
+			ConfigFile: acc.Fixture("role_allow_any_host.tf"),
+			ConfigVariables: config.Variables{
+				"name": config.StringVariable("test: Role"),
+				"rules": config.SetVariable(
+					config.ObjectVariable(map[string]config.Variable{
+						"port":        config.IntegerVariable(22),
+						"protocol":    config.StringVariable("TCP"),
+						"description": config.StringVariable("SSH access"),
+					}),
+				),
+			},
+		}),
 	),
 	Entry("assert importing the role populates the state",
 		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/role_any_host.tf"),
+			ConfigFile: acc.Fixture("role_any_host.tf"),
 			ConfigVariables: config.Variables{
 				"name":        config.StringVariable("test: Role"),
 				"description": config.StringVariable("Role's description"),
@@ -843,14 +1182,419 @@ var _ = DescribeTable("any host firewall management",
 				),
 			},
 		},
-		resource.TestStep{
-			ConfigFile: config.StaticFile("testdata/role_any_host.tf"),
+		acc.ImportStateVerify(resource.TestStep{
+			ConfigFile: acc.Fixture("role_any_host.tf"),
 			ConfigVariables: config.Variables{
 				"name": config.StringVariable("test: Role"),
 			},
-			ResourceName:      "definednet_role.test",
-			ImportState:       true,
-			ImportStateVerify: true,
+		}, "definednet_role.test"),
+	),
+)
+
+var _ = DescribeTable("CIDR firewall management",
+	func(steps ...resource.TestStep) {
+		harness.Test(steps...)
+	},
+	Entry("assert roles with CIDR rules can be created",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role_cidr.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("test: Role"),
+				"description": config.StringVariable("Role's description"),
+				"rules": config.SetVariable(
+					config.ObjectVariable(map[string]config.Variable{
+						"port":         config.IntegerVariable(22),
+						"protocol":     config.StringVariable("TCP"),
+						"description":  config.StringVariable("SSH access"),
+						"allowed_cidr": config.StringVariable("10.128.0.0/16"),
+						"local_cidr":   config.StringVariable("192.168.100.0/24"),
+					}),
+				),
+			},
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					// Assert sanity.
+					plancheck.ExpectResourceAction("definednet_role.test", plancheck.ResourceActionCreate),
+				},
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue(
+					"definednet_role.test",
+					tfjsonpath.New("rule"),
+					knownvalue.SetExact([]knownvalue.Check{
+						knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"port":            knownvalue.Int32Exact(22),
+							"port_range":      knownvalue.Null(),
+							"ports":           knownvalue.Null(),
+							"protocol":        knownvalue.StringExact("TCP"),
+							"description":     knownvalue.StringExact("SSH access"),
+							"allowed_role_id": knownvalue.Null(),
+							"allowed_cidr":    knownvalue.StringExact("10.128.0.0/16"),
+							"allow_any_host":  knownvalue.Null(),
+							"local_cidr":      knownvalue.StringExact("192.168.100.0/24"),
+							"allowed_tags":    knownvalue.Null(),
+						}),
+					}),
+				),
+			},
+		},
+	),
+	Entry("assert importing the role populates the state",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role_cidr.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("test: Role"),
+				"description": config.StringVariable("Role's description"),
+				"rules": config.SetVariable(
+					config.ObjectVariable(map[string]config.Variable{
+						"port":         config.IntegerVariable(22),
+						"protocol":     config.StringVariable("TCP"),
+						"description":  config.StringVariable("SSH access"),
+						"allowed_cidr": config.StringVariable("10.128.0.0/16"),
+						"local_cidr":   config.StringVariable("192.168.100.0/24"),
+					}),
+				),
+			},
+		},
+		acc.ImportStateVerify(resource.TestStep{
+			ConfigFile: acc.Fixture("role_cidr.tf"),
+			ConfigVariables: config.Variables{
+				"name": config.StringVariable("test: Role"),
+			},
+		}, "definednet_role.test"),
+	),
+	Entry("assert rules must declare the allowed traffic's source",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role_cidr.tf"),
+			ConfigVariables: config.Variables{
+				"name": config.StringVariable("test: Role"),
+				"rules": config.SetVariable(
+					config.ObjectVariable(map[string]config.Variable{
+						"port":        config.IntegerVariable(22),
+						"protocol":    config.StringVariable("TCP"),
+						"description": config.StringVariable("SSH access"),
+					}),
+				),
+			},
+			ExpectError: regexp.MustCompile(`At least one attribute out of`),
+		},
+	),
+	Entry("assert rules must declare valid CIDRs",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role_cidr.tf"),
+			ConfigVariables: config.Variables{
+				"name": config.StringVariable("test: Role"),
+				"rules": config.SetVariable(
+					config.ObjectVariable(map[string]config.Variable{
+						"port":         config.IntegerVariable(22),
+						"protocol":     config.StringVariable("TCP"),
+						"description":  config.StringVariable("SSH access"),
+						"allowed_cidr": config.StringVariable("10.128.0.1/16"),
+					}),
+				),
+			},
+			ExpectError: regexp.MustCompile(`value must be a network address in CIDR notation`),
+		},
+	),
+)
+
+var _ = DescribeTable("protocol-aware firewall validation",
+	func(steps ...resource.TestStep) {
+		harness.Test(steps...)
+	},
+	Entry("assert ICMP rules without ports are accepted",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role_any_port.tf"),
+			ConfigVariables: config.Variables{
+				"name": config.StringVariable("test: Role"),
+				"rules": config.SetVariable(
+					config.ObjectVariable(map[string]config.Variable{
+						"protocol":        config.StringVariable("ICMP"),
+						"description":     config.StringVariable("Ping"),
+						"allowed_role_id": config.StringVariable("role:abcdef"),
+						"allowed_tags":    config.SetVariable(config.StringVariable("tag:one")),
+					}),
+				),
+			},
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					// Assert sanity.
+					plancheck.ExpectResourceAction("definednet_role.test", plancheck.ResourceActionCreate),
+				},
+			},
+		},
+	),
+	Entry("assert ICMP rules with ports are rejected",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role_port.tf"),
+			ConfigVariables: config.Variables{
+				"name": config.StringVariable("test: Role"),
+				"rules": config.SetVariable(
+					config.ObjectVariable(map[string]config.Variable{
+						"port":            config.IntegerVariable(22),
+						"protocol":        config.StringVariable("ICMP"),
+						"description":     config.StringVariable("Ping"),
+						"allowed_role_id": config.StringVariable("role:abcdef"),
+						"allowed_tags":    config.SetVariable(config.StringVariable("tag:one")),
+					}),
+				),
+			},
+			ExpectError: regexp.MustCompile(`Ports must not be set for ICMP traffic`),
+		},
+	),
+	Entry("assert TCP rules without ports are accepted by default",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role_any_port.tf"),
+			ConfigVariables: config.Variables{
+				"name": config.StringVariable("test: Role"),
+				"rules": config.SetVariable(
+					config.ObjectVariable(map[string]config.Variable{
+						"protocol":        config.StringVariable("TCP"),
+						"description":     config.StringVariable("Any TCP port"),
+						"allowed_role_id": config.StringVariable("role:abcdef"),
+						"allowed_tags":    config.SetVariable(config.StringVariable("tag:one")),
+					}),
+				),
+			},
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					// Assert sanity.
+					plancheck.ExpectResourceAction("definednet_role.test", plancheck.ResourceActionCreate),
+				},
+			},
+		},
+	),
+	Entry("assert TCP rules without ports are rejected when required by the provider",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role_port_policy.tf"),
+			ConfigVariables: config.Variables{
+				"name": config.StringVariable("test: Role"),
+				"rules": config.SetVariable(
+					config.ObjectVariable(map[string]config.Variable{
+						"protocol":        config.StringVariable("TCP"),
+						"description":     config.StringVariable("Any TCP port"),
+						"allowed_role_id": config.StringVariable("role:abcdef"),
+						"allowed_tags":    config.SetVariable(config.StringVariable("tag:one")),
+					}),
+				),
+			},
+			ExpectError: regexp.MustCompile(`The provider requires TCP firewall rules to declare allowed ports`),
+		},
+	),
+	Entry("assert TCP rules with ports are accepted when required by the provider",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role_port_policy.tf"),
+			ConfigVariables: config.Variables{
+				"name": config.StringVariable("test: Role"),
+				"rules": config.SetVariable(
+					config.ObjectVariable(map[string]config.Variable{
+						"port":            config.IntegerVariable(22),
+						"protocol":        config.StringVariable("TCP"),
+						"description":     config.StringVariable("SSH access"),
+						"allowed_role_id": config.StringVariable("role:abcdef"),
+						"allowed_tags":    config.SetVariable(config.StringVariable("tag:one")),
+					}),
+				),
+			},
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					// Assert sanity.
+					plancheck.ExpectResourceAction("definednet_role.test", plancheck.ResourceActionCreate),
+				},
+			},
+		},
+	),
+)
+
+var _ = DescribeTable("firewall rule reference validation",
+	func(steps ...resource.TestStep) {
+		harness.Test(steps...)
+	},
+	Entry("assert rules allowing unknown roles fail planning",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role_port.tf"),
+			ConfigVariables: config.Variables{
+				"name": config.StringVariable("test: Role"),
+				"rules": config.SetVariable(
+					config.ObjectVariable(map[string]config.Variable{
+						"port":            config.IntegerVariable(22),
+						"protocol":        config.StringVariable("TCP"),
+						"description":     config.StringVariable("SSH access"),
+						"allowed_role_id": config.StringVariable("role-MISSING"),
+						"allowed_tags":    config.SetVariable(),
+					}),
+				),
+			},
+			PlanOnly:    true,
+			ExpectError: regexp.MustCompile(`Role "role-MISSING" does not exist`),
+		},
+	),
+)
+
+var _ = DescribeTable("role name uniqueness",
+	func(steps ...resource.TestStep) {
+		harness.Test(steps...)
+	},
+	Entry("assert roles can not reuse names",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("test: Referenced role"),
+				"description": config.StringVariable("Role's description"),
+			},
+			ExpectError: regexp.MustCompile(`Name "test: Referenced role" is already used by role "role:abcdef"`),
+		},
+	),
+	Entry("assert roles can not be renamed to used names",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("test: Role"),
+				"description": config.StringVariable("Role's description"),
+			},
+		},
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("test: Other referenced role"),
+				"description": config.StringVariable("Role's description"),
+			},
+			ExpectError: regexp.MustCompile(`Name "test: Other referenced role" is already used by role "role:123456"`),
+		},
+	),
+)
+
+var _ = DescribeTable("role deletion protection",
+	func(steps ...resource.TestStep) {
+		harness.Test(steps...)
+	},
+	Entry("assert protected roles can not be deleted",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role.tf"),
+			ConfigVariables: config.Variables{
+				"name":                config.StringVariable("test: Role"),
+				"deletion_protection": config.BoolVariable(true),
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("definednet_role.test", tfjsonpath.New("deletion_protection"), knownvalue.Bool(true)),
+			},
+		},
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role.tf"),
+			ConfigVariables: config.Variables{
+				"name":                config.StringVariable("test: Role"),
+				"deletion_protection": config.BoolVariable(true),
+			},
+			Destroy:     true,
+			ExpectError: regexp.MustCompile(`Deletion Protection Enabled`),
+		},
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role.tf"),
+			ConfigVariables: config.Variables{
+				"name":                config.StringVariable("test: Role"),
+				"deletion_protection": config.BoolVariable(false),
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("definednet_role.test", tfjsonpath.New("deletion_protection"), knownvalue.Bool(false)),
+			},
+		},
+	),
+	Entry("assert deletion protection is disabled by default",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role.tf"),
+			ConfigVariables: config.Variables{
+				"name": config.StringVariable("test: Role"),
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("definednet_role.test", tfjsonpath.New("deletion_protection"), knownvalue.Bool(false)),
+			},
+		},
+	),
+	Entry("assert roles assigned to hosts can not be deleted",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role.tf"),
+			ConfigVariables: config.Variables{
+				"name": config.StringVariable("test: Role"),
+			},
+		},
+		resource.TestStep{
+			PreConfig: func() {
+				role, ok := lo.Find(harness.Server.Roles.List(), func(r fakeserver.Role) bool {
+					return r.Name == "test: Role"
+				})
+
+				Expect(ok).To(BeTrue())
+				Expect(harness.Server.Hosts.Add(fakeserver.Host{Host: definednet.Host{
+					ID:        "host-ASSIGNED",
+					NetworkID: "network-id",
+					RoleID:    role.ID,
+					Name:      "host.defined.test",
+				}})).To(Succeed())
+			},
+			ConfigFile: acc.Fixture("role.tf"),
+			ConfigVariables: config.Variables{
+				"name": config.StringVariable("test: Role"),
+			},
+			Destroy:     true,
+			ExpectError: regexp.MustCompile(`(?s)Role In Use.*"host-ASSIGNED"`),
+		},
+		resource.TestStep{
+			PreConfig: func() {
+				Expect(harness.Server.Hosts.Remove("host-ASSIGNED")).To(Succeed())
+			},
+			ConfigFile: acc.Fixture("role.tf"),
+			ConfigVariables: config.Variables{
+				"name": config.StringVariable("test: Role"),
+			},
+		},
+	),
+)
+
+var _ = DescribeTable("concurrent role modification",
+	func(steps ...resource.TestStep) {
+		harness.Test(steps...)
+	},
+	Entry("assert roles changed since they were last read are not overwritten",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("test: Role"),
+				"description": config.StringVariable("Role's description"),
+			},
+		},
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("test: Updated role"),
+				"description": config.StringVariable("Role's description"),
+			},
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					modifyRemotely(func() {
+						for _, role := range harness.Server.Roles.List() {
+							role.Description = "Concurrently updated description"
+							Expect(harness.Server.Roles.Replace(role)).To(Succeed())
+						}
+					}),
+				},
+			},
+			ExpectError: regexp.MustCompile(`(?s)Conflicting Changes.*description: "Role's description" => "Concurrently updated description"`),
+		},
+		resource.TestStep{
+			PreConfig: func() {
+				for _, role := range harness.Server.Roles.List() {
+					Expect(harness.Server.Roles.Revision(role.Key())).To(Equal(2), "the role must not have been updated")
+				}
+			},
+			ConfigFile: acc.Fixture("role.tf"),
+			ConfigVariables: config.Variables{
+				"name":        config.StringVariable("test: Updated role"),
+				"description": config.StringVariable("Role's description"),
+			},
+			Check: resource.ComposeAggregateTestCheckFunc(
+				resource.TestCheckResourceAttr("definednet_role.test", "name", "test: Updated role"),
+				resource.TestCheckResourceAttr("definednet_role.test", "description", "Role's description"),
+			),
 		},
 	),
 )
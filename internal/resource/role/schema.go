@@ -3,21 +3,25 @@ package role
 import (
 	_ "embed"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
-	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
 	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/deletionprotection"
 	"github.com/sendsmaily/terraform-provider-definednet/internal/validation"
 )
 
 // Schema is the host resource's schema.
 var Schema = schema.Schema{
+	Version:             0,
 	MarkdownDescription: resourceDescription,
 	Attributes: map[string]schema.Attribute{
 		"id": schema.StringAttribute{
@@ -41,76 +45,140 @@ var Schema = schema.Schema{
 				stringvalidator.LengthAtMost(255),
 			},
 		},
+		"deletion_protection": deletionprotection.Attribute("role"),
+		"ignore_unmanaged_rules": schema.BoolAttribute{
+			Description: "Ignore firewall rules not declared on the role, e.g. ones managed by `definednet_role_firewall_rule` resources",
+			Optional:    true,
+		},
 	},
 	Blocks: map[string]schema.Block{
 		"rule": schema.SetNestedBlock{
 			Description: "Role's firewall rule",
 			NestedObject: schema.NestedBlockObject{
-				Attributes: map[string]schema.Attribute{
-					"protocol": schema.StringAttribute{
-						Description: "Network protocol. One of `ANY`, `TCP`, `UDP`, or `ICMP`.",
-						Required:    true,
-						Validators: []validator.String{
-							stringvalidator.OneOf("ANY", "TCP", "UDP", "ICMP"),
-						},
-					},
-					"description": schema.StringAttribute{
-						Description: "Role's description",
-						Optional:    true,
-						Validators: []validator.String{
-							stringvalidator.LengthAtMost(255),
-						},
-					},
-					"allowed_role_id": schema.StringAttribute{
-						Description: "Allowed role's ID",
-						Optional:    true,
-					},
-					"allowed_tags": schema.SetAttribute{
-						Description: "Allowed hosts' tags",
-						ElementType: types.StringType,
-						Optional:    true,
-						Validators: []validator.Set{
-							setvalidator.ValueStringsAre(validation.HostTag()),
-						},
-					},
-					"port": schema.Int32Attribute{
-						Description: "Allowed port",
-						Optional:    true,
-						Validators: []validator.Int32{
-							int32validator.Between(1, 65535),
-						},
-					},
-					"port_range": schema.SingleNestedAttribute{
-						Description: "Allowed port range",
-						Optional:    true,
-						Attributes: map[string]schema.Attribute{
-							"from": schema.Int32Attribute{
-								Description: "Start of the allowed port range",
-								Required:    true,
-								Validators: []validator.Int32{
-									int32validator.Between(1, 65535),
-								},
-							},
-							"to": schema.Int32Attribute{
-								Description: "End of the allowed port range",
-								Required:    true,
-								Validators: []validator.Int32{
-									int32validator.Between(1, 65535),
-								},
-							},
-						},
+				Attributes: FirewallRuleAttributes(),
+				Validators: []validator.Object{
+					validation.ProtocolPorts("port", "port_range", "ports"),
+				},
+			},
+		},
+	},
+}
+
+// FirewallRuleAttributes returns the firewall rule's schema attributes.
+func FirewallRuleAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"protocol": schema.StringAttribute{
+			Description: "Network protocol. One of `ANY`, `TCP`, `UDP`, or `ICMP`.",
+			Required:    true,
+			Validators: []validator.String{
+				stringvalidator.OneOf("ANY", "TCP", "UDP", "ICMP"),
+			},
+		},
+		"description": schema.StringAttribute{
+			Description: "Role's description",
+			Optional:    true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtMost(255),
+			},
+		},
+		"allowed_role_id": schema.StringAttribute{
+			Description: "Allowed role's ID",
+			Optional:    true,
+		},
+		"allowed_tags": schema.SetAttribute{
+			Description: "Allowed hosts' tags",
+			ElementType: types.StringType,
+			Optional:    true,
+			Validators: []validator.Set{
+				setvalidator.ValueStringsAre(validation.HostTag()),
+			},
+		},
+		"allowed_cidr": schema.StringAttribute{
+			Description: "Allowed overlay network CIDR",
+			Optional:    true,
+			Validators: []validator.String{
+				validation.CIDR(),
+			},
+		},
+		"allow_any_host": schema.BoolAttribute{
+			Description: "Allow any host on the network. Must be `true` when set. Rules without any allowed roles, tags or CIDRs allow any host as well.",
+			Optional:    true,
+			Validators: []validator.Bool{
+				boolvalidator.Equals(true),
+				boolvalidator.ConflictsWith(
+					path.MatchRelative().AtParent().AtName("allowed_role_id"),
+					path.MatchRelative().AtParent().AtName("allowed_tags"),
+					path.MatchRelative().AtParent().AtName("allowed_cidr"),
+				),
+			},
+		},
+		"local_cidr": schema.StringAttribute{
+			Description: "Local CIDR the rule applies to, e.g. an unsafe route's network",
+			Optional:    true,
+			Validators: []validator.String{
+				validation.CIDR(),
+			},
+		},
+		"port": schema.Int32Attribute{
+			Description: "Allowed port",
+			Optional:    true,
+			Validators: []validator.Int32{
+				int32validator.Between(1, 65535),
+			},
+		},
+		"port_range": schema.SingleNestedAttribute{
+			Description: "Allowed port range",
+			Optional:    true,
+			Attributes: map[string]schema.Attribute{
+				"from": schema.Int32Attribute{
+					Description: "Start of the allowed port range",
+					Required:    true,
+					Validators: []validator.Int32{
+						int32validator.Between(1, 65535),
 					},
 				},
-				Validators: []validator.Object{
-					objectvalidator.AtLeastOneOf(
-						path.MatchRelative().AtName("allowed_role_id"),
-						path.MatchRelative().AtName("allowed_tags"),
-					),
+				"to": schema.Int32Attribute{
+					Description: "End of the allowed port range",
+					Required:    true,
+					Validators: []validator.Int32{
+						int32validator.Between(1, 65535),
+					},
 				},
 			},
 		},
+		"ports": schema.SetAttribute{
+			Description: "Allowed ports and port ranges, e.g. `443` or `8000-8100`",
+			ElementType: types.StringType,
+			Optional:    true,
+			Validators: []validator.Set{
+				setvalidator.SizeAtLeast(1),
+				setvalidator.ValueStringsAre(validation.Ports(1, 65535)),
+				setvalidator.ConflictsWith(
+					path.MatchRelative().AtParent().AtName("port"),
+					path.MatchRelative().AtParent().AtName("port_range"),
+				),
+			},
+		},
+	}
+}
+
+// IdentitySchema is the role resource's identity schema.
+var IdentitySchema = identityschema.Schema{
+	Attributes: map[string]identityschema.Attribute{
+		"id": identityschema.StringAttribute{
+			Description:       "Role's ID",
+			RequiredForImport: true,
+		},
 	},
 }
 
+// ListSchema is the role list resource's configuration schema.
+var ListSchema = listschema.Schema{
+	MarkdownDescription: listDescription,
+}
+
 //go:embed docs/resource.md
 var resourceDescription string
+
+//go:embed docs/list.md
+var listDescription string
@@ -2,20 +2,32 @@ package role
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/samber/lo"
 	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/deletionprotection"
 )
 
 // State is the role resource's state.
 type State struct {
-	ID            types.String   `tfsdk:"id"`
-	Name          types.String   `tfsdk:"name"`
-	Description   types.String   `tfsdk:"description"`
-	FirewallRules []FirewallRule `tfsdk:"rule"`
+	ID                   types.String   `tfsdk:"id"`
+	Name                 types.String   `tfsdk:"name"`
+	Description          types.String   `tfsdk:"description"`
+	IgnoreUnmanagedRules types.Bool     `tfsdk:"ignore_unmanaged_rules"`
+	FirewallRules        []FirewallRule `tfsdk:"rule"`
+	DeletionProtection   types.Bool     `tfsdk:"deletion_protection"`
+}
+
+// Identity is the role resource's identity.
+type Identity struct {
+	ID types.String `tfsdk:"id"`
 }
 
 // FirewallRule is the role's firewall rule state.
@@ -24,8 +36,12 @@ type FirewallRule struct {
 	Description   types.String       `tfsdk:"description"`
 	AllowedRoleID types.String       `tfsdk:"allowed_role_id"`
 	AllowedTags   types.Set          `tfsdk:"allowed_tags"`
+	AllowedCIDR   types.String       `tfsdk:"allowed_cidr"`
+	AllowAnyHost  types.Bool         `tfsdk:"allow_any_host"`
+	LocalCIDR     types.String       `tfsdk:"local_cidr"`
 	Port          types.Int32        `tfsdk:"port"`
 	PortRange     *FirewallPortRange `tfsdk:"port_range"`
+	Ports         types.Set          `tfsdk:"ports"`
 }
 
 // FirewallPortRange is the firewall rule's port range state.
@@ -35,42 +51,275 @@ type FirewallPortRange struct {
 }
 
 // Apply applies Defined.net role information to the state.
+//
+// Defined.net firewall rules carry a single port range each, so rules declared with multiple ports are expanded
+// into several Defined.net rules on write. Those rules are collapsed back into the rule declaring them, based on
+// the state's current rules. Rules not claimed by any multi-port rule are mapped one-to-one, so imported roles
+// declare a rule per port.
+//
+// Defined.net does not distinguish rules explicitly allowing any host from rules without any allowed sources, so
+// allow_any_host is only kept for rules declaring it in the state's current rules.
 func (s *State) Apply(ctx context.Context, role *definednet.Role) (diags diag.Diagnostics) {
+	claims := map[string]int{}
+	anyHost := map[string]bool{}
+	for idx, rule := range s.FirewallRules {
+		base, d := rule.base(ctx)
+		diags.Append(d...)
+
+		key := ruleKey(base)
+
+		if rule.AllowAnyHost.ValueBool() {
+			anyHost[key] = true
+		}
+
+		if rule.Ports.IsNull() || rule.Ports.IsUnknown() {
+			continue
+		}
+
+		var ports []string
+		diags.Append(rule.Ports.ElementsAs(ctx, &ports, false)...)
+
+		for _, p := range ports {
+			if _, exists := claims[key+p]; !exists {
+				claims[key+p] = idx
+			}
+		}
+	}
+
+	if diags.HasError() {
+		return diags
+	}
+
+	var (
+		rules  []FirewallRule
+		groups = map[int]int{}
+		ports  = map[int][]string{}
+	)
+
+	for _, rule := range role.FirewallRules {
+		if lo.IsNotNil(rule.PortRange) {
+			p := formatPorts(*rule.PortRange)
+
+			if owner, claimed := claims[ruleKey(rule)+p]; claimed {
+				if _, exists := groups[owner]; !exists {
+					groups[owner] = len(rules)
+					rules = append(rules, applyFirewallRule(ctx, rule, anyHost[ruleKey(rule)], &diags))
+				}
+
+				ports[groups[owner]] = append(ports[groups[owner]], p)
+
+				continue
+			}
+		}
+
+		rules = append(rules, applyFirewallRule(ctx, rule, anyHost[ruleKey(rule)], &diags))
+	}
+
+	for idx, p := range ports {
+		// Rules claimed by several multi-port rules, or repeated in the role, declare their ports once.
+		p = lo.Uniq(p)
+		slices.Sort(p)
+
+		rules[idx].Port = types.Int32Null()
+		rules[idx].PortRange = nil
+		rules[idx].Ports = func() basetypes.SetValue {
+			v, d := types.SetValueFrom(ctx, types.StringType, p)
+			diags = append(diags, d...)
+			return v
+		}()
+	}
+
 	s.ID = types.StringValue(role.ID)
+	s.DeletionProtection = deletionprotection.Default(s.DeletionProtection)
 	s.Name = types.StringValue(role.Name)
 	s.Description = lo.If(lo.IsEmpty(role.Description), types.StringNull()).Else(types.StringValue(role.Description))
-	s.FirewallRules = lo.Map(role.FirewallRules, func(rule definednet.FirewallRule, _ int) (r FirewallRule) {
-		r.Protocol = types.StringValue(rule.Protocol)
-		r.Description = lo.If(lo.IsNotEmpty(rule.Description), types.StringValue(rule.Description)).Else(types.StringNull())
-		r.AllowedRoleID = lo.If(lo.IsNotEmpty(rule.AllowedRoleID), types.StringValue(rule.AllowedRoleID)).Else(types.StringNull())
+	s.FirewallRules = rules
 
-		r.AllowedTags = func() basetypes.SetValue {
-			tags, d := types.SetValueFrom(ctx, types.StringType, rule.AllowedTags)
-			diags = append(diags, d...)
-			return tags
-		}()
+	return diags
+}
 
-		r.Port = func() types.Int32 {
-			if lo.IsNil(rule.PortRange) || rule.PortRange.From != rule.PortRange.To {
-				return types.Int32Null()
-			}
+func applyFirewallRule(ctx context.Context, rule definednet.FirewallRule, anyHost bool, diags *diag.Diagnostics) (r FirewallRule) {
+	r.Protocol = types.StringValue(rule.Protocol)
+	r.Description = lo.If(lo.IsNotEmpty(rule.Description), types.StringValue(rule.Description)).Else(types.StringNull())
+	r.AllowedRoleID = lo.If(lo.IsNotEmpty(rule.AllowedRoleID), types.StringValue(rule.AllowedRoleID)).Else(types.StringNull())
+	r.AllowedCIDR = lo.If(lo.IsNotEmpty(rule.AllowedCIDR), types.StringValue(rule.AllowedCIDR)).Else(types.StringNull())
+	r.LocalCIDR = lo.If(lo.IsNotEmpty(rule.LocalCIDR), types.StringValue(rule.LocalCIDR)).Else(types.StringNull())
+	r.Ports = types.SetNull(types.StringType)
 
-			return types.Int32Value(int32(rule.PortRange.From))
-		}()
+	r.AllowAnyHost = lo.If(anyHost, types.BoolValue(true)).Else(types.BoolNull())
+
+	r.AllowedTags = func() basetypes.SetValue {
+		tags, d := types.SetValueFrom(ctx, types.StringType, rule.AllowedTags)
+		diags.Append(d...)
+		return tags
+	}()
+
+	r.Port = func() types.Int32 {
+		if lo.IsNil(rule.PortRange) || rule.PortRange.From != rule.PortRange.To {
+			return types.Int32Null()
+		}
+
+		return types.Int32Value(int32(rule.PortRange.From))
+	}()
+
+	r.PortRange = func() *FirewallPortRange {
+		if lo.IsNil(rule.PortRange) || rule.PortRange.From == rule.PortRange.To {
+			return nil
+		}
+
+		return &FirewallPortRange{
+			From: types.Int32Value(int32(rule.PortRange.From)),
+			To:   types.Int32Value(int32(rule.PortRange.To)),
+		}
+	}()
+
+	return r
+}
+
+// ExpandRules converts the firewall rules' state to Defined.net firewall rules.
+func ExpandRules(ctx context.Context, rules []FirewallRule) (out []definednet.FirewallRule, diags diag.Diagnostics) {
+	for _, rule := range rules {
+		expanded, d := rule.Expand(ctx)
+		diags.Append(d...)
 
-		r.PortRange = func() *FirewallPortRange {
-			if lo.IsNil(rule.PortRange) || rule.PortRange.From == rule.PortRange.To {
-				return nil
+		out = append(out, expanded...)
+	}
+
+	return out, diags
+}
+
+// Expand converts the firewall rule state to Defined.net firewall rules.
+//
+// Rules declaring multiple ports are expanded into a Defined.net rule per port or port range, ordered by port.
+func (r FirewallRule) Expand(ctx context.Context) (rules []definednet.FirewallRule, diags diag.Diagnostics) {
+	out, d := r.base(ctx)
+	diags.Append(d...)
+
+	switch {
+	case !r.Ports.IsNull():
+		var ports []string
+		diags.Append(r.Ports.ElementsAs(ctx, &ports, false)...)
+
+		ranges := lo.Map(ports, func(p string, _ int) definednet.PortRange {
+			pr, err := parsePorts(p)
+			if err != nil {
+				diags.AddError("Invalid Port", err.Error())
 			}
 
-			return &FirewallPortRange{
-				From: types.Int32Value(int32(rule.PortRange.From)),
-				To:   types.Int32Value(int32(rule.PortRange.To)),
+			return pr
+		})
+
+		slices.SortFunc(ranges, func(a, b definednet.PortRange) int {
+			if a.From != b.From {
+				return a.From - b.From
 			}
-		}()
 
-		return r
-	})
+			return a.To - b.To
+		})
 
-	return diags
+		return lo.Map(ranges, func(pr definednet.PortRange, _ int) definednet.FirewallRule {
+			rule := out
+			rule.PortRange = &pr
+			return rule
+		}), diags
+
+	case lo.IsNotNil(r.PortRange):
+		out.PortRange = &definednet.PortRange{
+			From: int(r.PortRange.From.ValueInt32()),
+			To:   int(r.PortRange.To.ValueInt32()),
+		}
+
+	case !r.Port.IsNull():
+		out.PortRange = &definednet.PortRange{
+			From: int(r.Port.ValueInt32()),
+			To:   int(r.Port.ValueInt32()),
+		}
+	}
+
+	return []definednet.FirewallRule{out}, diags
+}
+
+// base converts the firewall rule state to a Defined.net firewall rule, disregarding its ports.
+func (r FirewallRule) base(ctx context.Context) (out definednet.FirewallRule, diags diag.Diagnostics) {
+	out.Protocol = r.Protocol.ValueString()
+	out.Description = r.Description.ValueString()
+	out.AllowedRoleID = r.AllowedRoleID.ValueString()
+	out.AllowedCIDR = r.AllowedCIDR.ValueString()
+	out.LocalCIDR = r.LocalCIDR.ValueString()
+
+	diags.Append(r.AllowedTags.ElementsAs(ctx, &out.AllowedTags, false)...)
+
+	return out, diags
+}
+
+// ruleKey returns the Defined.net firewall rule's identity, disregarding its ports.
+func ruleKey(rule definednet.FirewallRule) string {
+	tags := slices.Clone(rule.AllowedTags)
+	slices.Sort(tags)
+
+	return strings.Join([]string{
+		rule.Protocol,
+		rule.Description,
+		rule.AllowedRoleID,
+		strings.Join(tags, ","),
+		rule.AllowedCIDR,
+		rule.LocalCIDR,
+		"",
+	}, "\x00")
+}
+
+// RuleIdentity returns the Defined.net firewall rule's identity, including its ports.
+func RuleIdentity(rule definednet.FirewallRule) string {
+	if lo.IsNil(rule.PortRange) {
+		return ruleKey(rule)
+	}
+
+	return ruleKey(rule) + formatPorts(*rule.PortRange)
+}
+
+// PartitionRules splits the Defined.net firewall rules into ones matching the other rules and the rest.
+//
+// Each of the other rules matches a single firewall rule at most, so duplicate rules are accounted for.
+func PartitionRules(rules, other []definednet.FirewallRule) (matched, rest []definednet.FirewallRule) {
+	counts := lo.CountValuesBy(other, RuleIdentity)
+
+	for _, rule := range rules {
+		if id := RuleIdentity(rule); counts[id] > 0 {
+			counts[id]--
+			matched = append(matched, rule)
+
+			continue
+		}
+
+		rest = append(rest, rule)
+	}
+
+	return matched, rest
+}
+
+func formatPorts(pr definednet.PortRange) string {
+	if pr.From == pr.To {
+		return strconv.Itoa(pr.From)
+	}
+
+	return fmt.Sprintf("%d-%d", pr.From, pr.To)
+}
+
+func parsePorts(p string) (definednet.PortRange, error) {
+	fromStr, toStr, isRange := strings.Cut(p, "-")
+	if !isRange {
+		toStr = fromStr
+	}
+
+	from, err := strconv.Atoi(fromStr)
+	if err != nil {
+		return definednet.PortRange{}, fmt.Errorf("invalid port %q: %w", p, err)
+	}
+
+	to, err := strconv.Atoi(toStr)
+	if err != nil {
+		return definednet.PortRange{}, fmt.Errorf("invalid port %q: %w", p, err)
+	}
+
+	return definednet.PortRange{From: from, To: to}, nil
 }
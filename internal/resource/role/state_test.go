@@ -0,0 +1,177 @@
+package role_test
+
+import (
+	"context"
+	"slices"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/resource/role"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/generate"
+	"pgregory.net/rapid"
+)
+
+var _ = Describe("role state mapping", func() {
+	// drawRole draws a role with distinct firewall rules, as rules are only distinguishable by their contents.
+	drawRole := func(t *rapid.T) definednet.Role {
+		return definednet.Role{
+			ID:            generate.ID("role").Draw(t, "id"),
+			Name:          generate.Name().Draw(t, "name"),
+			Description:   generate.Optional(generate.Description()).Draw(t, "description"),
+			FirewallRules: rapid.SliceOfNDistinct(generate.FirewallRule(), 0, 8, role.RuleIdentity).Draw(t, "rules"),
+		}
+	}
+
+	Specify("roles round-trip through the state", func() {
+		rapid.Check(GinkgoT(), func(t *rapid.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			r := drawRole(t)
+
+			var state role.State
+			g.Expect(state.Apply(ctx, &r)).To(BeEmpty())
+
+			g.Expect(state.ID.ValueString()).To(Equal(r.ID))
+			g.Expect(state.Name.ValueString()).To(Equal(r.Name))
+			g.Expect(state.Description.ValueString()).To(Equal(r.Description))
+
+			rules, diags := role.ExpandRules(ctx, state.FirewallRules)
+			g.Expect(diags).To(BeEmpty())
+			g.Expect(lo.Map(rules, identity)).To(ConsistOf(lo.Map(r.FirewallRules, identity)))
+		})
+	})
+
+	Specify("single-port ranges are collapsed into ports", func() {
+		rapid.Check(GinkgoT(), func(t *rapid.T) {
+			g := NewWithT(t)
+			r := drawRole(t)
+
+			var state role.State
+			g.Expect(state.Apply(context.Background(), &r)).To(BeEmpty())
+
+			for _, rule := range state.FirewallRules {
+				g.Expect(lo.IsNil(rule.PortRange) || rule.PortRange.From.ValueInt32() < rule.PortRange.To.ValueInt32()).To(BeTrue())
+				g.Expect(rule.Port.IsNull() || lo.IsNil(rule.PortRange)).To(BeTrue())
+				g.Expect(rule.Ports.IsNull() || rule.Port.IsNull() && lo.IsNil(rule.PortRange)).To(BeTrue())
+			}
+		})
+	})
+
+	Specify("re-applying roles leaves the state unchanged", func() {
+		rapid.Check(GinkgoT(), func(t *rapid.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			r := drawRole(t)
+
+			var state role.State
+			g.Expect(state.Apply(ctx, &r)).To(BeEmpty())
+
+			reapplied := state
+			g.Expect(reapplied.Apply(ctx, &r)).To(BeEmpty())
+			g.Expect(reapplied).To(Equal(state))
+		})
+	})
+
+	Specify("any host is only allowed explicitly by rules declaring it", func() {
+		rapid.Check(GinkgoT(), func(t *rapid.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			r := drawRole(t)
+
+			var state role.State
+			g.Expect(state.Apply(ctx, &r)).To(BeEmpty())
+
+			for _, rule := range state.FirewallRules {
+				g.Expect(rule.AllowAnyHost.IsNull()).To(BeTrue())
+			}
+
+			// Declare any host explicitly on the rules without allowed sources.
+			for idx, rule := range state.FirewallRules {
+				if rule.AllowedRoleID.IsNull() && rule.AllowedTags.IsNull() && rule.AllowedCIDR.IsNull() {
+					state.FirewallRules[idx].AllowAnyHost = types.BoolValue(true)
+				}
+			}
+
+			declared := slices.Clone(state.FirewallRules)
+			g.Expect(state.Apply(ctx, &r)).To(BeEmpty())
+			g.Expect(state.FirewallRules).To(ConsistOf(declared))
+		})
+	})
+
+	Specify("imported rules are not collapsed into ports", func() {
+		rapid.Check(GinkgoT(), func(t *rapid.T) {
+			g := NewWithT(t)
+			r := drawRole(t)
+
+			var state role.State
+			g.Expect(state.Apply(context.Background(), &r)).To(BeEmpty())
+
+			g.Expect(state.FirewallRules).To(HaveLen(len(r.FirewallRules)))
+			for _, rule := range state.FirewallRules {
+				g.Expect(rule.Ports.IsNull()).To(BeTrue())
+			}
+		})
+	})
+
+	Specify("ports claimed by several rules are declared once", func() {
+		rapid.Check(GinkgoT(), func(t *rapid.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+
+			rule := generate.FirewallRule().Draw(t, "rule")
+			rule.PortRange = nil
+
+			var declared role.State
+			g.Expect(declared.Apply(ctx, &definednet.Role{FirewallRules: []definednet.FirewallRule{rule}})).To(BeEmpty())
+
+			// Rules sharing their allowed sources, but declaring overlapping ports.
+			base := declared.FirewallRules[0]
+			shared := generate.Port().Draw(t, "shared")
+
+			declared.FirewallRules = nil
+			for range 2 {
+				ports := rapid.SliceOfNDistinct(generate.Port(), 1, 4, rapid.ID).Draw(t, "ports")
+
+				base.Ports = types.SetValueMust(types.StringType, lo.Map(lo.Uniq(append(ports, shared)), func(p int, _ int) attr.Value {
+					return types.StringValue(strconv.Itoa(p))
+				}))
+				declared.FirewallRules = append(declared.FirewallRules, base)
+			}
+
+			rules, diags := role.ExpandRules(ctx, declared.FirewallRules)
+			g.Expect(diags).To(BeEmpty())
+
+			state := declared
+			g.Expect(state.Apply(ctx, &definednet.Role{FirewallRules: rules})).To(BeEmpty())
+
+			for _, rule := range state.FirewallRules {
+				var ports []string
+				g.Expect(rule.Ports.ElementsAs(ctx, &ports, false)).To(BeEmpty())
+				g.Expect(ports).To(Equal(lo.Uniq(ports)))
+			}
+		})
+	})
+
+	Specify("rules are partitioned without losing rules", func() {
+		rapid.Check(GinkgoT(), func(t *rapid.T) {
+			g := NewWithT(t)
+			rules := rapid.SliceOfN(generate.FirewallRule(), 0, 8).Draw(t, "rules")
+			other := rapid.SliceOfN(rapid.SampledFrom(slices.Concat(rules, []definednet.FirewallRule{generate.FirewallRule().Draw(t, "unrelated")})), 0, 8).Draw(t, "other")
+
+			matched, rest := role.PartitionRules(rules, other)
+
+			g.Expect(lo.Map(append(matched, rest...), identity)).To(ConsistOf(lo.Map(rules, identity)))
+			g.Expect(len(matched)).To(BeNumerically("<=", len(other)))
+		})
+	})
+})
+
+// identity adapts role.RuleIdentity for lo.Map.
+func identity(rule definednet.FirewallRule, _ int) string {
+	return role.RuleIdentity(rule)
+}
@@ -1,31 +1,34 @@
 package role_test
 
 import (
+	"context"
 	"testing"
 
-	tfprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
-	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
-	"github.com/sendsmaily/terraform-provider-definednet/internal/provider"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/acc"
 	fakeserver "github.com/sendsmaily/terraform-provider-definednet/internal/testing/server"
 )
 
-var providerFactory func() tfprovider.Provider
+var harness *acc.Harness
 
 var _ = BeforeEach(func() {
-	server := fakeserver.New()
-	DeferCleanup(server.Close)
-
-	providerFactory = provider.New(
-		func(string, string, string) (definednet.Client, error) {
-			return server.Client(), nil
-		},
-		"test",
-	)
+	harness = acc.New()
+
+	// Seed the roles referenced by the tests' firewall rules.
+	Expect(harness.Server.Roles.Add(fakeserver.Role{ID: "role:abcdef", Name: "test: Referenced role"})).To(Succeed())
+	Expect(harness.Server.Roles.Add(fakeserver.Role{ID: "role:123456", Name: "test: Other referenced role"})).To(Succeed())
 })
 
 func TestSuite(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "internal/resource/role")
 }
+
+// modifyRemotely is a plan check modifying the fake server's objects between planning and applying.
+type modifyRemotely func()
+
+func (f modifyRemotely) CheckPlan(context.Context, plancheck.CheckPlanRequest, *plancheck.CheckPlanResponse) {
+	f()
+}
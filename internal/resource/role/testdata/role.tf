@@ -11,7 +11,14 @@ variable "description" {
   default = ""
 }
 
+variable "deletion_protection" {
+  type    = bool
+  default = null
+}
+
 resource "definednet_role" "test" {
   name       = var.name
   description = var.description
+
+  deletion_protection = var.deletion_protection
 }
@@ -0,0 +1,42 @@
+provider "definednet" {
+  token = "supersecret"
+
+  require_firewall_rule_ports = true
+}
+
+variable "name" {
+  type = string
+}
+
+variable "description" {
+  type = string
+  default = ""
+}
+
+variable "rules" {
+  type = list(object({
+    port = optional(number)
+    protocol = string
+    description = string
+    allowed_role_id = string
+    allowed_tags = list(string)
+  }))
+
+  default = []
+}
+
+resource "definednet_role" "test" {
+  name       = var.name
+  description = var.description
+
+  dynamic "rule" {
+    for_each = var.rules
+    content {
+      port = rule.value.port
+      protocol = rule.value.protocol
+      description = rule.value.description
+      allowed_role_id = rule.value.allowed_role_id
+      allowed_tags = rule.value.allowed_tags
+    }
+  }
+}
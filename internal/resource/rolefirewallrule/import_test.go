@@ -0,0 +1,104 @@
+package rolefirewallrule_test
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/provider"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/resource/rolefirewallrule"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/acc"
+	fakeserver "github.com/sendsmaily/terraform-provider-definednet/internal/testing/server"
+)
+
+var _ = Describe("role firewall rule imports", func() {
+	BeforeEach(func() {
+		Expect(harness.Server.Roles.Add(fakeserver.Role{
+			ID:   "role-IMPORTED",
+			Name: "test: Role",
+			FirewallRules: []definednet.FirewallRule{
+				{Protocol: "TCP", Description: "SSH access", PortRange: &definednet.PortRange{From: 22, To: 22}},
+				{Protocol: "UDP", AllowedTags: []string{"tag:one"}, PortRange: &definednet.PortRange{From: 8000, To: 8100}},
+			},
+		})).To(Succeed())
+	})
+
+	// importRule imports the firewall rule through the provider server, configured with the fake API.
+	importRule := func(ctx SpecContext, id string) *tfprotov6.ImportResourceStateResponse {
+		srv, err := harness.ProviderFactories()[acc.ProviderName]()
+		Expect(err).NotTo(HaveOccurred())
+
+		configType := provider.Schema.Type().TerraformType(ctx).(tftypes.Object)
+		config := lo.MapValues(configType.AttributeTypes, func(t tftypes.Type, _ string) tftypes.Value {
+			return tftypes.NewValue(t, nil)
+		})
+
+		config["token"] = tftypes.NewValue(tftypes.String, harness.Server.Token)
+
+		dv, err := tfprotov6.NewDynamicValue(configType, tftypes.NewValue(configType, config))
+		Expect(err).NotTo(HaveOccurred())
+
+		configured, err := srv.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: &dv})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configured.Diagnostics).To(BeEmpty())
+
+		resp, err := srv.ImportResourceState(ctx, &tfprotov6.ImportResourceStateRequest{
+			TypeName: "definednet_role_firewall_rule",
+			ID:       id,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		return resp
+	}
+
+	// imported decodes the imported firewall rule's state.
+	imported := func(ctx SpecContext, resp *tfprotov6.ImportResourceStateResponse) rolefirewallrule.State {
+		Expect(resp.Diagnostics).To(BeEmpty())
+		Expect(resp.ImportedResources).To(HaveLen(1))
+
+		raw, err := resp.ImportedResources[0].State.Unmarshal(rolefirewallrule.Schema.Type().TerraformType(ctx))
+		Expect(err).NotTo(HaveOccurred())
+
+		var state rolefirewallrule.State
+		Expect(tfsdk.State{Schema: rolefirewallrule.Schema, Raw: raw}.Get(ctx, &state)).To(BeEmpty())
+
+		return state
+	}
+
+	Specify("firewall rules are imported by their position", func(ctx SpecContext) {
+		state := imported(ctx, importRule(ctx, "role-IMPORTED/2"))
+
+		Expect(state.ID.ValueString()).To(MatchRegexp(`^role-IMPORTED/[0-9a-f]{16}$`))
+		Expect(state.RoleID.ValueString()).To(Equal("role-IMPORTED"))
+		Expect(state.Protocol.ValueString()).To(Equal("UDP"))
+		Expect(state.PortRange).NotTo(BeNil())
+		Expect(state.PortRange.From.ValueInt32()).To(BeEquivalentTo(8000))
+		Expect(state.PortRange.To.ValueInt32()).To(BeEquivalentTo(8100))
+		Expect(state.DeletionProtection.ValueBool()).To(BeFalse())
+	})
+
+	Specify("firewall rules are imported by their ID", func(ctx SpecContext) {
+		byPosition := imported(ctx, importRule(ctx, "role-IMPORTED/1"))
+
+		state := imported(ctx, importRule(ctx, byPosition.ID.ValueString()))
+		Expect(state).To(Equal(byPosition))
+		Expect(state.Port.ValueInt32()).To(BeEquivalentTo(22))
+	})
+
+	DescribeTable("unknown firewall rules are refused",
+		func(ctx SpecContext, id string, message string) {
+			resp := importRule(ctx, id)
+
+			Expect(resp.Diagnostics).To(HaveLen(1))
+			Expect(resp.Diagnostics[0].Summary).To(Equal("Import Failure"))
+			Expect(resp.Diagnostics[0].Detail).To(ContainSubstring(message))
+		},
+		Entry("assert positions out of range are refused", "role-IMPORTED/3", `has no firewall rule matching "3"`),
+		Entry("assert positions count from one", "role-IMPORTED/0", `has no firewall rule matching "0"`),
+		Entry("assert unknown IDs are refused", "role-IMPORTED/0123456789abcdef", `has no firewall rule matching`),
+		Entry("assert IDs without roles are refused", "0123456789abcdef", `expected <role-id>/<position>`),
+	)
+})
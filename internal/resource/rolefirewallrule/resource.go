@@ -0,0 +1,365 @@
+package rolefirewallrule
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/deletionprotection"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/providerdata"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/resource/role"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/validation"
+)
+
+// NewResource creates a Defined.net role firewall rule resource.
+func NewResource() resource.Resource {
+	return &Resource{}
+}
+
+// Resource is Defined.net role firewall rule resource.
+//
+// Defined.net has no API for managing individual firewall rules, so the rule is managed by reading, modifying and
+// writing the role's firewall rules. Updates of the same role are serialized within the provider.
+type Resource struct {
+	client       definednet.Client
+	locks        *providerdata.Locks
+	references   *providerdata.References
+	requirePorts bool
+}
+
+var _ resource.Resource = (*Resource)(nil)
+var _ resource.ResourceWithConfigure = (*Resource)(nil)
+var _ resource.ResourceWithImportState = (*Resource)(nil)
+var _ resource.ResourceWithModifyPlan = (*Resource)(nil)
+var _ resource.ResourceWithValidateConfig = (*Resource)(nil)
+
+// Configure configures the resource.
+func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerdata.Data)
+	if !ok {
+		resp.Diagnostics.AddError("Invalid Configuration", "The provider specifies an invalid provider data type")
+		return
+	}
+
+	r.client = data.Client
+	r.locks = data.RoleLocks
+	r.references = data.References
+	r.requirePorts = data.RequireFirewallRulePorts
+}
+
+// Metadata returns the resource's metadata.
+func (r *Resource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_role_firewall_rule", req.ProviderTypeName)
+}
+
+// Schema returns the resource's configuration schema.
+func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = Schema
+}
+
+// ValidateConfig validates the firewall rule's ports are applicable to its protocol.
+func (r *Resource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config types.Object
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	res := new(validator.ObjectResponse)
+	validation.ProtocolPorts("port", "port_range", "ports").ValidateObject(ctx, validator.ObjectRequest{
+		Path:        path.Empty(),
+		ConfigValue: config,
+		Config:      req.Config,
+	}, res)
+
+	resp.Diagnostics.Append(res.Diagnostics...)
+}
+
+// ModifyPlan validates the planned rule's role references, and enforces the provider's firewall rule policy on it.
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan State
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.references != nil {
+		resp.Diagnostics.Append(r.references.ValidateRole(ctx, path.Root("role_id"), plan.RoleID)...)
+		resp.Diagnostics.Append(r.references.ValidateRole(ctx, path.Root("allowed_role_id"), plan.AllowedRoleID)...)
+	}
+
+	if !r.requirePorts || !slices.Contains([]string{"TCP", "UDP"}, plan.Protocol.ValueString()) {
+		return
+	}
+
+	if plan.Port.IsNull() && plan.PortRange == nil && plan.Ports.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("protocol"),
+			"Missing Ports",
+			fmt.Sprintf("The provider requires %s firewall rules to declare allowed ports.", plan.Protocol.ValueString()),
+		)
+	}
+}
+
+// Create adds the firewall rule to the role on Defined.net control plane.
+func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var state State
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rules, diags := state.Expand(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.modifyRules(ctx, state.RoleID.ValueString(), func(current []definednet.FirewallRule) ([]definednet.FirewallRule, diag.Diagnostics) {
+		var diags diag.Diagnostics
+
+		if existing, _ := role.PartitionRules(current, rules); len(existing) > 0 {
+			diags.AddError(
+				"Duplicate Firewall Rule",
+				fmt.Sprintf("Role %q already has a firewall rule matching the configuration.", state.RoleID.ValueString()),
+			)
+		}
+
+		return append(current, rules...), diags
+	})...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Apply(rules)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+
+	tflog.Trace(ctx, "created Defined.net role firewall rule", map[string]any{
+		"id":      state.ID.String(),
+		"role_id": state.RoleID.String(),
+	})
+}
+
+// Delete removes the firewall rule from the role on Defined.net control plane.
+//
+// Removal is refused, when the firewall rule is protected from deletion.
+func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state State
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(deletionprotection.Check("firewall rule", state.ID.ValueString(), state.DeletionProtection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rules, diags := state.Expand(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.modifyRules(ctx, state.RoleID.ValueString(), func(current []definednet.FirewallRule) ([]definednet.FirewallRule, diag.Diagnostics) {
+		_, rest := role.PartitionRules(current, rules)
+		return rest, nil
+	})...)
+}
+
+// Read reads the firewall rule from the role on Defined.net control plane.
+//
+// The firewall rule is removed from the state, when the role no longer has it.
+func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state State
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rules, diags := state.Expand(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := definednet.GetRole(ctx, r.client, definednet.GetRoleRequest{
+		ID: state.RoleID.ValueString(),
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Request Failure", err.Error())
+		return
+	}
+
+	if existing, _ := role.PartitionRules(current.FirewallRules, rules); len(existing) != len(rules) {
+		resp.State.RemoveResource(ctx)
+
+		tflog.Trace(ctx, "Defined.net role firewall rule no longer exists", map[string]any{
+			"id":      state.ID.String(),
+			"role_id": state.RoleID.String(),
+		})
+
+		return
+	}
+
+	state.Apply(rules)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+
+	tflog.Trace(ctx, "refreshed Defined.net role firewall rule", map[string]any{
+		"id":      state.ID.String(),
+		"role_id": state.RoleID.String(),
+	})
+}
+
+// Update replaces the firewall rule on the role on Defined.net control plane.
+func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state, prior State
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rules, diags := state.Expand(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	priorRules, diags := prior.Expand(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.modifyRules(ctx, state.RoleID.ValueString(), func(current []definednet.FirewallRule) ([]definednet.FirewallRule, diag.Diagnostics) {
+		_, rest := role.PartitionRules(current, priorRules)
+		return append(rest, rules...), nil
+	})...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Apply(rules)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+
+	tflog.Trace(ctx, "updated Defined.net role firewall rule", map[string]any{
+		"id":      state.ID.String(),
+		"role_id": state.RoleID.String(),
+	})
+}
+
+// ImportState imports a firewall rule of a role from Defined.net control plane.
+//
+// Firewall rules are imported either by their position in the role's firewall rules, in the format
+// <role-id>/<position> counting from 1, or by their ID. Each Defined.net firewall rule carries a single port or port
+// range, so rules declaring multiple ports are imported as a rule per port.
+func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	roleID, ref, ok := strings.Cut(req.ID, "/")
+	if !ok || lo.IsEmpty(roleID) || lo.IsEmpty(ref) {
+		resp.Diagnostics.AddError(
+			"Import Failure",
+			fmt.Sprintf("Invalid firewall rule import ID %q, expected <role-id>/<position> or the firewall rule's ID.", req.ID),
+		)
+
+		return
+	}
+
+	current, err := definednet.GetRole(ctx, r.client, definednet.GetRoleRequest{
+		ID: roleID,
+	})
+
+	if err != nil {
+		resp.Diagnostics.AddError("Request Failure", err.Error())
+		return
+	}
+
+	states := lo.Map(current.FirewallRules, func(rule definednet.FirewallRule, _ int) State {
+		var rs role.State
+		resp.Diagnostics.Append(rs.Apply(ctx, &definednet.Role{ID: current.ID, FirewallRules: []definednet.FirewallRule{rule}})...)
+
+		state := State{RoleID: types.StringValue(current.ID), FirewallRule: rs.FirewallRules[0]}
+		state.Apply([]definednet.FirewallRule{rule})
+
+		return state
+	})
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, found := lo.Find(states, func(s State) bool {
+		return s.ID.ValueString() == req.ID
+	})
+
+	if position, err := strconv.Atoi(ref); !found && err == nil && position >= 1 && position <= len(states) {
+		state, found = states[position-1], true
+	}
+
+	if !found {
+		resp.Diagnostics.AddError(
+			"Import Failure",
+			fmt.Sprintf("Role %q has no firewall rule matching %q.", roleID, ref),
+		)
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// modifyRules modifies the role's firewall rules with the role locked for the duration of the read-modify-write.
+func (r *Resource) modifyRules(ctx context.Context, roleID string, modify func([]definednet.FirewallRule) ([]definednet.FirewallRule, diag.Diagnostics)) (diags diag.Diagnostics) {
+	unlock := r.locks.Lock(roleID)
+	defer unlock()
+
+	current, err := definednet.GetRole(ctx, r.client, definednet.GetRoleRequest{
+		ID: roleID,
+	})
+
+	if err != nil {
+		diags.AddError("Request Failure", err.Error())
+		return diags
+	}
+
+	rules, d := modify(current.FirewallRules)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if _, err := definednet.UpdateRole(ctx, r.client, definednet.UpdateRoleRequest{
+		ID:            current.ID,
+		Name:          current.Name,
+		Description:   current.Description,
+		FirewallRules: rules,
+	}); err != nil {
+		diags.AddError("Request Failure", err.Error())
+	}
+
+	return diags
+}
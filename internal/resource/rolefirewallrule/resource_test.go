@@ -0,0 +1,197 @@
+package rolefirewallrule_test
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/acc"
+)
+
+var _ = DescribeTable("role firewall rule resource management",
+	func(steps ...resource.TestStep) {
+		harness.Test(steps...)
+	},
+	Entry("assert firewall rule is added to the role",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role_firewall_rule.tf"),
+			ConfigVariables: config.Variables{
+				"ports": acc.Strings("80", "443"),
+			},
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					// Assert sanity.
+					plancheck.ExpectResourceAction("definednet_role_firewall_rule.test", plancheck.ResourceActionCreate),
+				},
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue(
+					"definednet_role_firewall_rule.test",
+					tfjsonpath.New("id"),
+					knownvalue.StringRegexp(regexp.MustCompile(`^role-[A-Z0-9]+/[0-9a-f]{16}$`)),
+				),
+				statecheck.ExpectKnownValue(
+					"definednet_role.test",
+					tfjsonpath.New("rule"),
+					knownvalue.SetSizeExact(1),
+				),
+			},
+			Check: expectRoleFirewallRules("definednet_role.test", 3),
+		},
+	),
+	Entry("assert firewall rule is updated on the role",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role_firewall_rule.tf"),
+			ConfigVariables: config.Variables{
+				"ports": acc.Strings("80", "443"),
+			},
+		},
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role_firewall_rule.tf"),
+			ConfigVariables: config.Variables{
+				"ports": acc.Strings("443"),
+			},
+			ConfigPlanChecks: resource.ConfigPlanChecks{
+				PreApply: []plancheck.PlanCheck{
+					plancheck.ExpectResourceAction("definednet_role.test", plancheck.ResourceActionNoop),
+					plancheck.ExpectResourceAction("definednet_role_firewall_rule.test", plancheck.ResourceActionUpdate),
+				},
+			},
+			Check: expectRoleFirewallRules("definednet_role.test", 2),
+		},
+	),
+	Entry("assert firewall rule is overridden by roles managing all rules",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role_firewall_rule.tf"),
+			ConfigVariables: config.Variables{
+				"ignore_unmanaged_rules": config.BoolVariable(false),
+				"ports":                  acc.Strings("443"),
+			},
+			ExpectNonEmptyPlan: true,
+		},
+	),
+	Entry("assert ICMP firewall rules with ports are rejected",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role_firewall_rule.tf"),
+			ConfigVariables: config.Variables{
+				"protocol": config.StringVariable("ICMP"),
+				"ports":    acc.Strings("443"),
+			},
+			ExpectError: regexp.MustCompile(`Ports must not be set for ICMP traffic`),
+		},
+	),
+)
+
+func expectRoleFirewallRules(name string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %q not found", name)
+		}
+
+		role, err := harness.Server.Roles.Get(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if len(role.FirewallRules) != count {
+			return fmt.Errorf("expected role %q to have %d firewall rules, got %d", rs.Primary.ID, count, len(role.FirewallRules))
+		}
+
+		return nil
+	}
+}
+
+var _ = DescribeTable("role firewall rule deletion protection",
+	func(steps ...resource.TestStep) {
+		harness.Test(steps...)
+	},
+	Entry("assert protected firewall rules can not be deleted",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role_firewall_rule.tf"),
+			ConfigVariables: config.Variables{
+				"ports":               acc.Strings("443"),
+				"deletion_protection": config.BoolVariable(true),
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("definednet_role_firewall_rule.test", tfjsonpath.New("deletion_protection"), knownvalue.Bool(true)),
+			},
+		},
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role_firewall_rule.tf"),
+			ConfigVariables: config.Variables{
+				"ports":               acc.Strings("443"),
+				"deletion_protection": config.BoolVariable(true),
+			},
+			Destroy:     true,
+			ExpectError: regexp.MustCompile(`Deletion Protection Enabled`),
+		},
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role_firewall_rule.tf"),
+			ConfigVariables: config.Variables{
+				"ports":               acc.Strings("443"),
+				"deletion_protection": config.BoolVariable(false),
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("definednet_role_firewall_rule.test", tfjsonpath.New("deletion_protection"), knownvalue.Bool(false)),
+			},
+		},
+	),
+	Entry("assert deletion protection is disabled by default",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role_firewall_rule.tf"),
+			ConfigVariables: config.Variables{
+				"ports": acc.Strings("443"),
+			},
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("definednet_role_firewall_rule.test", tfjsonpath.New("deletion_protection"), knownvalue.Bool(false)),
+			},
+		},
+	),
+)
+
+var _ = DescribeTable("role firewall rule import",
+	func(steps ...resource.TestStep) {
+		harness.Test(steps...)
+	},
+	Entry("assert importing the firewall rule by its ID populates the state",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role_firewall_rule.tf"),
+			ConfigVariables: config.Variables{
+				"port": config.IntegerVariable(443),
+			},
+		},
+		// Defined.net does not distinguish rules explicitly allowing any host.
+		acc.ImportStateVerify(resource.TestStep{
+			ConfigFile: acc.Fixture("role_firewall_rule.tf"),
+			ConfigVariables: config.Variables{
+				"port": config.IntegerVariable(443),
+			},
+		}, "definednet_role_firewall_rule.test", "allow_any_host"),
+	),
+	Entry("assert importing the firewall rule by its position populates the state",
+		resource.TestStep{
+			ConfigFile: acc.Fixture("role_firewall_rule.tf"),
+			ConfigVariables: config.Variables{
+				"port": config.IntegerVariable(443),
+			},
+		},
+		acc.ImportStateVerify(resource.TestStep{
+			ConfigFile: acc.Fixture("role_firewall_rule.tf"),
+			ConfigVariables: config.Variables{
+				"port": config.IntegerVariable(443),
+			},
+			// The role's own SSH rule comes first.
+			ImportStateIdFunc: func(s *terraform.State) (string, error) {
+				return s.RootModule().Resources["definednet_role.test"].Primary.ID + "/2", nil
+			},
+		}, "definednet_role_firewall_rule.test", "allow_any_host"),
+	),
+)
@@ -0,0 +1,35 @@
+package rolefirewallrule
+
+import (
+	_ "embed"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/deletionprotection"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/resource/role"
+)
+
+// Schema is the role firewall rule resource's schema.
+var Schema = schema.Schema{
+	Version:             0,
+	MarkdownDescription: resourceDescription,
+	Attributes: lo.Assign(role.FirewallRuleAttributes(), map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Description: "Firewall rule's ID",
+			Computed:    true,
+		},
+		"deletion_protection": deletionprotection.Attribute("firewall rule"),
+		"role_id": schema.StringAttribute{
+			Description: "Role's ID",
+			Required:    true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+	}),
+}
+
+//go:embed docs/resource.md
+var resourceDescription string
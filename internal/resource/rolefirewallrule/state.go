@@ -0,0 +1,44 @@
+package rolefirewallrule
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/deletionprotection"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/resource/role"
+)
+
+// State is the role firewall rule resource's state.
+type State struct {
+	ID                 types.String `tfsdk:"id"`
+	RoleID             types.String `tfsdk:"role_id"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+
+	role.FirewallRule
+}
+
+// Apply applies the firewall rule's Defined.net identity to the state.
+//
+// The ID is derived from the role's ID and the firewall rule's contents, as Defined.net firewall rules have no
+// identifiers of their own.
+func (s *State) Apply(rules []definednet.FirewallRule) {
+	ids := lo.Map(rules, func(rule definednet.FirewallRule, _ int) string {
+		return role.RuleIdentity(rule)
+	})
+
+	slices.Sort(ids)
+
+	h := sha256.New()
+	for _, id := range ids {
+		h.Write([]byte(id))
+		h.Write([]byte{0})
+	}
+
+	s.DeletionProtection = deletionprotection.Default(s.DeletionProtection)
+	s.ID = types.StringValue(fmt.Sprintf("%s/%s", s.RoleID.ValueString(), hex.EncodeToString(h.Sum(nil)[:8])))
+}
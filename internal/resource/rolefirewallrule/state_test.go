@@ -0,0 +1,31 @@
+package rolefirewallrule_test
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/resource/rolefirewallrule"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/generate"
+	"pgregory.net/rapid"
+)
+
+var _ = Describe("role firewall rule state mapping", func() {
+	Specify("IDs are derived from the role and the rules' contents", func() {
+		rapid.Check(GinkgoT(), func(t *rapid.T) {
+			g := NewWithT(t)
+			roleID := generate.ID("role").Draw(t, "roleID")
+			rules := rapid.SliceOfN(generate.FirewallRule(), 1, 8).Draw(t, "rules")
+
+			state := rolefirewallrule.State{RoleID: types.StringValue(roleID)}
+			state.Apply(rules)
+
+			g.Expect(state.ID.ValueString()).To(MatchRegexp(`^%s/[0-9a-f]{16}$`, roleID))
+
+			// Expanded rules are ordered by port, but the ID must not depend on their order.
+			permuted := rolefirewallrule.State{RoleID: types.StringValue(roleID)}
+			permuted.Apply(rapid.Permutation(rules).Draw(t, "permuted"))
+
+			g.Expect(permuted.ID).To(Equal(state.ID))
+		})
+	})
+})
@@ -0,0 +1,20 @@
+package rolefirewallrule_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/acc"
+)
+
+var harness *acc.Harness
+
+var _ = BeforeEach(func() {
+	harness = acc.New()
+})
+
+func TestSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "internal/resource/rolefirewallrule")
+}
@@ -0,0 +1,51 @@
+provider "definednet" {
+  token = "supersecret"
+}
+
+variable "ignore_unmanaged_rules" {
+  type    = bool
+  default = true
+}
+
+variable "protocol" {
+  type    = string
+  default = "TCP"
+}
+
+variable "port" {
+  type    = number
+  default = null
+}
+
+variable "ports" {
+  type    = list(string)
+  default = null
+}
+
+variable "deletion_protection" {
+  type    = bool
+  default = null
+}
+
+resource "definednet_role" "test" {
+  name                   = "test: Role"
+  ignore_unmanaged_rules = var.ignore_unmanaged_rules
+
+  rule {
+    protocol       = "TCP"
+    description    = "SSH access"
+    port           = 22
+    allow_any_host = true
+  }
+}
+
+resource "definednet_role_firewall_rule" "test" {
+  role_id        = definednet_role.test.id
+  protocol       = var.protocol
+  description    = "Web access"
+  port           = var.port
+  ports          = var.ports
+  allow_any_host = true
+
+  deletion_protection = var.deletion_protection
+}
@@ -0,0 +1,195 @@
+package revision
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+)
+
+// privateStateKey is the private state key the object's last read revision is stored under.
+const privateStateKey = "revision"
+
+// PrivateState is the resource's private state.
+type PrivateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// Revision is the Defined.net object's fields managed by the provider, keyed by their HTTP API names.
+//
+// Defined.net objects carry no revision identifiers, so the managed fields' values as last read are stored instead.
+// Fields changing without anyone's involvement, e.g. metadata and timestamps, are left out.
+type Revision map[string]any
+
+// Host returns the Defined.net host's or lighthouse's revision.
+func Host(host definednet.Host) Revision {
+	return Revision{
+		"name":            host.Name,
+		"roleID":          host.RoleID,
+		"tags":            host.Tags,
+		"staticAddresses": host.StaticAddresses,
+		"listenPort":      host.ListenPort,
+		"configOverrides": host.ConfigOverrides,
+	}
+}
+
+// Role returns the Defined.net role's revision.
+//
+// The role's firewall rules are expected to be narrowed down to the rules owned by the resource.
+func Role(role definednet.Role) Revision {
+	return Revision{
+		"name":          role.Name,
+		"description":   role.Description,
+		"firewallRules": role.FirewallRules,
+	}
+}
+
+// Save stores the Defined.net object's revision in the private state.
+func Save(ctx context.Context, private PrivateState, rev Revision) (diags diag.Diagnostics) {
+	data, err := json.Marshal(rev)
+	if err != nil {
+		diags.AddError("Invalid Revision", err.Error())
+		return diags
+	}
+
+	return private.SetKey(ctx, privateStateKey, data)
+}
+
+// Check verifies the Defined.net object has not changed since its revision was stored in the private state.
+//
+// The check passes, when no revision has been stored.
+func Check(ctx context.Context, private PrivateState, kind string, rev Revision) (diags diag.Diagnostics) {
+	saved, d := private.GetKey(ctx, privateStateKey)
+	diags.Append(d...)
+	if diags.HasError() || len(saved) == 0 {
+		return diags
+	}
+
+	current, err := json.Marshal(rev)
+	if err != nil {
+		diags.AddError("Invalid Revision", err.Error())
+		return diags
+	}
+
+	changes, err := Diff(saved, current)
+	if err != nil {
+		diags.AddError("Invalid Revision", err.Error())
+		return diags
+	}
+
+	if len(changes) > 0 {
+		diags.AddError(
+			"Conflicting Changes",
+			fmt.Sprintf(
+				"The %s was changed outside of Terraform since it was last read:\n\n%s\n\n"+
+					"Refresh the state and review the plan before applying again.",
+				kind,
+				strings.Join(changes, "\n"),
+			),
+		)
+	}
+
+	return diags
+}
+
+// Diff returns the changes between two JSON objects' top-level fields, ordered by field name.
+//
+// Missing fields, nulls, empty strings and empty collections are considered equal, as the Defined.net HTTP API
+// omits empty fields. Arrays are compared regardless of their order, as the API's collections, e.g. tags, static
+// addresses and firewall rules, are unordered.
+func Diff(before, after []byte) ([]string, error) {
+	var a, b map[string]json.RawMessage
+
+	if err := json.Unmarshal(before, &a); err != nil {
+		return nil, fmt.Errorf("error decoding revision: %w", err)
+	}
+
+	if err := json.Unmarshal(after, &b); err != nil {
+		return nil, fmt.Errorf("error decoding revision: %w", err)
+	}
+
+	keys := lo.Union(lo.Keys(a), lo.Keys(b))
+	slices.Sort(keys)
+
+	var changes []string
+	for _, key := range keys {
+		if !jsonEqual(a[key], b[key]) {
+			changes = append(changes, fmt.Sprintf("  %s: %s => %s", key, jsonString(a[key]), jsonString(b[key])))
+		}
+	}
+
+	return changes, nil
+}
+
+// jsonEqual returns true, when the JSON values are equal once normalized. Values that are not valid JSON are
+// compared byte by byte.
+func jsonEqual(a, b json.RawMessage) bool {
+	var va, vb any
+
+	if err := json.Unmarshal(lo.Ternary(len(a) > 0, []byte(a), []byte("null")), &va); err != nil {
+		return bytes.Equal(a, b)
+	}
+
+	if err := json.Unmarshal(lo.Ternary(len(b) > 0, []byte(b), []byte("null")), &vb); err != nil {
+		return bytes.Equal(a, b)
+	}
+
+	ja, _ := json.Marshal(normalize(va))
+	jb, _ := json.Marshal(normalize(vb))
+
+	return bytes.Equal(ja, jb)
+}
+
+// normalize converts the decoded JSON value's empty strings and collections to nulls, and sorts its arrays by their
+// elements' encoding, recursively.
+func normalize(v any) any {
+	switch v := v.(type) {
+	case string:
+		if v == "" {
+			return nil
+		}
+
+	case []any:
+		if len(v) == 0 {
+			return nil
+		}
+
+		items := lo.Map(v, func(item any, _ int) string {
+			data, _ := json.Marshal(normalize(item))
+			return string(data)
+		})
+
+		slices.Sort(items)
+
+		return lo.Map(items, func(item string, _ int) json.RawMessage {
+			return json.RawMessage(item)
+		})
+
+	case map[string]any:
+		if len(v) == 0 {
+			return nil
+		}
+
+		return lo.MapValues(v, func(field any, _ string) any {
+			return normalize(field)
+		})
+	}
+
+	return v
+}
+
+// jsonString returns the JSON value for displaying in changes, missing values are displayed as null.
+func jsonString(v json.RawMessage) string {
+	if len(v) == 0 {
+		return "null"
+	}
+
+	return string(v)
+}
@@ -0,0 +1,137 @@
+package revision_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/revision"
+)
+
+var _ = Describe("checking revisions", func() {
+	var private privateState
+
+	BeforeEach(func() {
+		private = privateState{}
+	})
+
+	Specify("unchanged objects pass the check", func(ctx SpecContext) {
+		host := definednet.Host{ID: "host-1", Name: "host.defined.test", Tags: []string{"tag:one"}}
+
+		Expect(revision.Save(ctx, private, revision.Host(host))).To(BeEmpty())
+		Expect(revision.Check(ctx, private, "host", revision.Host(host))).To(BeEmpty())
+	})
+
+	Specify("changed objects fail the check", func(ctx SpecContext) {
+		host := definednet.Host{ID: "host-1", Name: "host.defined.test", Tags: []string{"tag:one"}}
+		Expect(revision.Save(ctx, private, revision.Host(host))).To(BeEmpty())
+
+		host.Name = "renamed.defined.test"
+		host.Tags = []string{"tag:two"}
+
+		diags := revision.Check(ctx, private, "host", revision.Host(host))
+		Expect(diags).To(HaveLen(1))
+		Expect(diags[0].Summary()).To(Equal("Conflicting Changes"))
+		Expect(diags[0].Detail()).To(ContainSubstring("The host was changed outside of Terraform"))
+		Expect(diags[0].Detail()).To(ContainSubstring(`name: "host.defined.test" => "renamed.defined.test"`))
+		Expect(diags[0].Detail()).To(ContainSubstring(`tags: ["tag:one"] => ["tag:two"]`))
+	})
+
+	Specify("hosts checking in pass the check", func(ctx SpecContext) {
+		seen := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		host := definednet.Host{
+			ID:       "host-1",
+			Name:     "host.defined.test",
+			Metadata: &definednet.HostMetadata{LastSeenAt: &seen, Version: "0.4.0"},
+		}
+
+		Expect(revision.Save(ctx, private, revision.Host(host))).To(BeEmpty())
+
+		host.Metadata = &definednet.HostMetadata{LastSeenAt: lo.ToPtr(seen.Add(time.Minute)), Version: "0.4.1"}
+		host.IsBlocked = true
+
+		Expect(revision.Check(ctx, private, "host", revision.Host(host))).To(BeEmpty())
+	})
+
+	Specify("roles modified without changes to their managed fields pass the check", func(ctx SpecContext) {
+		modified := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		role := definednet.Role{ID: "role-1", Name: "Role", ModifiedAt: &modified}
+
+		Expect(revision.Save(ctx, private, revision.Role(role))).To(BeEmpty())
+
+		role.ModifiedAt = lo.ToPtr(modified.Add(time.Minute))
+
+		Expect(revision.Check(ctx, private, "role", revision.Role(role))).To(BeEmpty())
+	})
+
+	Specify("reordered collections pass the check", func(ctx SpecContext) {
+		host := definednet.Host{
+			ID:              "host-1",
+			Name:            "host.defined.test",
+			Tags:            []string{"tag:one", "tag:two"},
+			StaticAddresses: []string{"10.0.0.1:4242", "10.0.0.2:4242"},
+		}
+		role := definednet.Role{ID: "role-1", Name: "Role", FirewallRules: []definednet.FirewallRule{
+			{Protocol: "TCP", AllowedTags: []string{"tag:one", "tag:two"}, PortRange: &definednet.PortRange{From: 22, To: 22}},
+			{Protocol: "UDP", PortRange: &definednet.PortRange{From: 53, To: 53}},
+		}}
+
+		Expect(revision.Save(ctx, private, revision.Host(host))).To(BeEmpty())
+
+		host.Tags = []string{"tag:two", "tag:one"}
+		host.StaticAddresses = []string{"10.0.0.2:4242", "10.0.0.1:4242"}
+		Expect(revision.Check(ctx, private, "host", revision.Host(host))).To(BeEmpty())
+
+		Expect(revision.Save(ctx, private, revision.Role(role))).To(BeEmpty())
+
+		role.FirewallRules = []definednet.FirewallRule{role.FirewallRules[1], role.FirewallRules[0]}
+		role.FirewallRules[1].AllowedTags = []string{"tag:two", "tag:one"}
+		Expect(revision.Check(ctx, private, "role", revision.Role(role))).To(BeEmpty())
+	})
+
+	Specify("objects without a stored revision pass the check", func(ctx SpecContext) {
+		Expect(revision.Check(ctx, private, "host", revision.Host(definednet.Host{ID: "host-1"}))).To(BeEmpty())
+	})
+})
+
+var _ = DescribeTable("diffing revisions",
+	func(before, after string, expected []string) {
+		changes, err := revision.Diff([]byte(before), []byte(after))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changes).To(Equal(expected))
+	},
+	Entry("equal objects", `{"a":1,"b":[1,2]}`, `{"b":[1,2],"a":1}`, nil),
+	Entry("nulls and empty collections", `{"a":null,"b":[],"c":{}}`, `{"a":[],"b":null}`, nil),
+	Entry("empty strings", `{"a":""}`, `{"a":null}`, nil),
+	Entry("reordered arrays", `{"a":[1,2],"b":[{"c":[3,4]},{"c":[]}]}`, `{"a":[2,1],"b":[{"c":null},{"c":[4,3]}]}`, nil),
+	Entry("arrays with repeated elements",
+		`{"a":[1,1,2]}`,
+		`{"a":[1,2,2]}`,
+		[]string{`  a: [1,1,2] => [1,2,2]`},
+	),
+	Entry("changed fields are ordered by name",
+		`{"b":1,"a":"x"}`,
+		`{"a":"y","b":2}`,
+		[]string{`  a: "x" => "y"`, `  b: 1 => 2`},
+	),
+	Entry("added and removed fields",
+		`{"a":1}`,
+		`{"b":2}`,
+		[]string{`  a: 1 => null`, `  b: null => 2`},
+	),
+)
+
+type privateState map[string][]byte
+
+func (p privateState) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func (p privateState) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	p[key] = value
+	return nil
+}
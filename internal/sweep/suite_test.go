@@ -0,0 +1,24 @@
+package sweep_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/sweep"
+)
+
+// TestMain runs the sweepers against the Defined.net account, when the -sweep flag is set.
+func TestMain(m *testing.M) {
+	for _, s := range sweep.Sweepers(sweep.ClientFromEnv) {
+		resource.AddTestSweepers(s.Name, s)
+	}
+
+	resource.TestMain(m)
+}
+
+func TestSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "internal/sweep")
+}
@@ -0,0 +1,136 @@
+// Package sweep deletes Defined.net objects leaked by crashed acceptance tests.
+//
+// Acceptance tests name roles with the RolePrefix, and hosts and lighthouses under the HostDomain. Sweepers
+// only delete objects named so, leaving the rest of the account intact.
+package sweep
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/provider"
+)
+
+const (
+	// RolePrefix is the name prefix of roles created by acceptance tests.
+	RolePrefix = "test: "
+	// HostDomain is the domain of hosts and lighthouses created by acceptance tests.
+	HostDomain = "defined.test"
+)
+
+// Sweeper names.
+const (
+	HostSweeper       = "definednet_host"
+	LighthouseSweeper = "definednet_lighthouse"
+	RoleSweeper       = "definednet_role"
+)
+
+// ClientFactory creates the Defined.net HTTP API client used by sweepers.
+type ClientFactory func() (definednet.Client, error)
+
+// ClientFromEnv creates a Defined.net HTTP API client, configured by the DEFINEDNET_TOKEN and DEFINEDNET_ENDPOINT
+// environment variables.
+func ClientFromEnv() (definednet.Client, error) {
+	endpoint := lo.CoalesceOrEmpty(os.Getenv("DEFINEDNET_ENDPOINT"), provider.DefinednetAPIEndpoint)
+
+	return definednet.NewClient(endpoint, os.Getenv("DEFINEDNET_TOKEN"), "sweep")
+}
+
+// Sweepers returns the sweepers, for registering with resource.AddTestSweepers.
+//
+// Roles are swept after hosts and lighthouses, as roles assigned to hosts can not be deleted.
+func Sweepers(clientFactory ClientFactory) []*resource.Sweeper {
+	sweeper := func(name string, sweep func(context.Context, definednet.Client) error, deps ...string) *resource.Sweeper {
+		return &resource.Sweeper{
+			Name:         name,
+			Dependencies: deps,
+			// Defined.net has no regions, the region is disregarded.
+			F: func(string) error {
+				client, err := clientFactory()
+				if err != nil {
+					return err
+				}
+
+				return sweep(context.Background(), client)
+			},
+		}
+	}
+
+	return []*resource.Sweeper{
+		sweeper(HostSweeper, Hosts),
+		sweeper(LighthouseSweeper, Lighthouses),
+		sweeper(RoleSweeper, Roles, HostSweeper, LighthouseSweeper),
+	}
+}
+
+// Hosts deletes the hosts created by acceptance tests, excluding lighthouses.
+func Hosts(ctx context.Context, client definednet.Client) error {
+	return sweepHosts(ctx, client, false)
+}
+
+// Lighthouses deletes the lighthouses created by acceptance tests.
+func Lighthouses(ctx context.Context, client definednet.Client) error {
+	return sweepHosts(ctx, client, true)
+}
+
+func sweepHosts(ctx context.Context, client definednet.Client, lighthouses bool) error {
+	hosts, err := definednet.ListHosts(ctx, client, definednet.ListHostsRequest{})
+	if err != nil {
+		return fmt.Errorf("error listing hosts: %w", err)
+	}
+
+	var errs []error
+	for _, host := range hosts {
+		if host.IsLighthouse != lighthouses || !IsTestHost(host.Name) {
+			continue
+		}
+
+		log.Printf("[INFO] Deleting %s %q (%s)", host.Kind(), host.Name, host.ID)
+
+		if err := definednet.DeleteHost(ctx, client, definednet.DeleteHostRequest{ID: host.ID}); err != nil {
+			errs = append(errs, fmt.Errorf("error deleting %s %q (%s): %w", host.Kind(), host.Name, host.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Roles deletes the roles created by acceptance tests.
+func Roles(ctx context.Context, client definednet.Client) error {
+	roles, err := definednet.ListRoles(ctx, client, definednet.ListRolesRequest{})
+	if err != nil {
+		return fmt.Errorf("error listing roles: %w", err)
+	}
+
+	var errs []error
+	for _, role := range roles {
+		if !IsTestRole(role.Name) {
+			continue
+		}
+
+		log.Printf("[INFO] Deleting role %q (%s)", role.Name, role.ID)
+
+		if err := definednet.DeleteRole(ctx, client, definednet.DeleteRoleRequest{ID: role.ID}); err != nil {
+			errs = append(errs, fmt.Errorf("error deleting role %q (%s): %w", role.Name, role.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// IsTestHost returns true, when the host or lighthouse name is under the HostDomain.
+func IsTestHost(name string) bool {
+	return strings.HasSuffix(name, "."+HostDomain)
+}
+
+// IsTestRole returns true, when the role name has the RolePrefix.
+func IsTestRole(name string) bool {
+	return strings.HasPrefix(name, RolePrefix)
+}
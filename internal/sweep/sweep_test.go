@@ -0,0 +1,96 @@
+package sweep_test
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/sweep"
+	fakeserver "github.com/sendsmaily/terraform-provider-definednet/internal/testing/server"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/server/servertest"
+)
+
+var _ = Describe("sweeping leaked test objects", func() {
+	var server *fakeserver.Server
+
+	BeforeEach(func() {
+		server = servertest.New()
+		DeferCleanup(server.Close)
+
+		Expect(server.Networks.Add(fakeserver.Network{ID: "network-id", Name: "test-network"})).To(Succeed())
+
+		for _, role := range []fakeserver.Role{
+			{ID: "role-test", Name: "test: Role"},
+			{ID: "role-other", Name: "Production role"},
+		} {
+			Expect(server.Roles.Add(role)).To(Succeed())
+		}
+
+		for _, host := range []definednet.Host{
+			{ID: "host-test", NetworkID: "network-id", RoleID: "role-test", Name: "host.defined.test"},
+			{ID: "host-other", NetworkID: "network-id", RoleID: "role-other", Name: "host.example.com"},
+			{ID: "host-lighthouse-test", NetworkID: "network-id", RoleID: "role-test", Name: "lighthouse.defined.test", IsLighthouse: true},
+			{ID: "host-lighthouse-other", NetworkID: "network-id", Name: "lighthouse.example.com", IsLighthouse: true},
+		} {
+			Expect(server.Hosts.Add(fakeserver.Host{Host: host})).To(Succeed())
+		}
+	})
+
+	hostIDs := func() []string {
+		return lo.Map(server.Hosts.List(), func(h fakeserver.Host, _ int) string { return h.Host.ID })
+	}
+
+	roleIDs := func() []string {
+		return lo.Map(server.Roles.List(), func(r fakeserver.Role, _ int) string { return r.ID })
+	}
+
+	Specify("hosts are swept, leaving lighthouses and other hosts", func(ctx SpecContext) {
+		Expect(sweep.Hosts(ctx, server.Client())).To(Succeed())
+
+		Expect(hostIDs()).To(ConsistOf("host-other", "host-lighthouse-test", "host-lighthouse-other"))
+		Expect(roleIDs()).To(ConsistOf("role-test", "role-other"))
+	})
+
+	Specify("lighthouses are swept, leaving hosts and other lighthouses", func(ctx SpecContext) {
+		Expect(sweep.Lighthouses(ctx, server.Client())).To(Succeed())
+
+		Expect(hostIDs()).To(ConsistOf("host-test", "host-other", "host-lighthouse-other"))
+	})
+
+	Specify("roles assigned to hosts are not swept", func(ctx SpecContext) {
+		err := sweep.Roles(ctx, server.Client())
+		Expect(err).To(MatchError(ContainSubstring(`error deleting role "test: Role" (role-test)`)))
+
+		var apiErr *definednet.Error
+		Expect(errors.As(err, &apiErr)).To(BeTrue())
+		Expect(apiErr.StatusCode).To(Equal(http.StatusConflict))
+
+		Expect(roleIDs()).To(ConsistOf("role-test", "role-other"))
+	})
+
+	Specify("sweepers delete hosts before roles", func() {
+		sweepers := sweep.Sweepers(func() (definednet.Client, error) {
+			return server.Client(), nil
+		})
+
+		byName := lo.KeyBy(sweepers, func(s *resource.Sweeper) string { return s.Name })
+		Expect(byName).To(HaveKeyWithValue(sweep.RoleSweeper, HaveField("Dependencies", ConsistOf(sweep.HostSweeper, sweep.LighthouseSweeper))))
+
+		// Run the sweepers in dependency order, as the sweeper runner does.
+		for _, name := range []string{sweep.HostSweeper, sweep.LighthouseSweeper, sweep.RoleSweeper} {
+			Expect(byName[name].F("global")).To(Succeed())
+		}
+
+		Expect(hostIDs()).To(ConsistOf("host-other", "host-lighthouse-other"))
+		Expect(roleIDs()).To(ConsistOf("role-other"))
+
+		Expect(server).NotTo(servertest.HaveReceivedRequest(
+			servertest.HaveMethod(http.MethodDelete),
+			servertest.HavePath(Not(HaveSuffix("-test"))),
+		))
+	})
+})
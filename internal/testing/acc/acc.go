@@ -0,0 +1,143 @@
+// Package acc implements the resources' acceptance test harness.
+//
+// The harness runs Terraform against the provider, which is configured with a fake Defined.net HTTP API, e.g.:
+//
+//	var harness *acc.Harness
+//
+//	var _ = BeforeEach(func() {
+//		harness = acc.New()
+//	})
+//
+//	var _ = DescribeTable("host resource management",
+//		func(steps ...resource.TestStep) {
+//			harness.Test(steps...)
+//		},
+//		...
+//	)
+//
+// Harnesses created with NewCassette run against a cassette of Defined.net HTTP API interactions instead, replaying
+// the interactions recorded against the real API. Cassettes are recorded by running the acceptance tests with
+// DEFINEDNET_CASSETTE_MODE=record, which sends the requests to the API at DEFINEDNET_ENDPOINT with the
+// DEFINEDNET_TOKEN. Specs whose cassette has not been recorded yet are skipped.
+package acc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	tfprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/provider"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/cassette"
+	fakeserver "github.com/sendsmaily/terraform-provider-definednet/internal/testing/server"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/server/servertest"
+)
+
+// ProviderName is the provider's name in the fixtures' configurations.
+const ProviderName = "definednet"
+
+// Harness runs acceptance tests against a fake Defined.net HTTP API.
+type Harness struct {
+	// Server is the fake Defined.net HTTP API the provider sends its requests to, nil for cassette harnesses.
+	Server *fakeserver.Server
+	// Cassette records or replays the provider's requests, nil for fake API harnesses.
+	Cassette *cassette.Cassette
+}
+
+// New starts a fake Defined.net HTTP API and creates a harness for it.
+//
+// The fake API is closed when the spec ends, so New is called from a setup node, e.g. BeforeEach.
+func New() *Harness {
+	server := servertest.New()
+	ginkgo.DeferCleanup(server.Close)
+
+	return &Harness{Server: server}
+}
+
+// NewCassette creates a harness for the named cassette in the suite's testdata/cassettes directory.
+//
+// The cassette's mode is declared by the DEFINEDNET_CASSETTE_MODE environment variable, see cassette.ModeFromEnv.
+// Replayed cassettes answer refreshes repeated more often than recorded, as their number varies between Terraform
+// versions. The spec is skipped, when the cassette to replay has not been recorded.
+func NewCassette(name string) *Harness {
+	ginkgo.GinkgoHelper()
+
+	c, err := cassette.New(filepath.Join("testdata", "cassettes", name+".json"), cassette.ModeFromEnv(), http.DefaultTransport)
+	if errors.Is(err, os.ErrNotExist) {
+		ginkgo.Skip(fmt.Sprintf("cassette %q has not been recorded", name))
+	}
+
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+	return &Harness{Cassette: c.AllowRepeatedReads()}
+}
+
+// ProviderFactory returns a factory creating providers configured with the fake API or the cassette.
+//
+// The fixtures' token is disregarded: recording cassettes authorize with the DEFINEDNET_TOKEN, replaying cassettes
+// with the cassette.Redacted token.
+func (h *Harness) ProviderFactory() func() tfprovider.Provider {
+	if h.Cassette != nil {
+		return provider.New(
+			func(endpoint, _, version string) (definednet.Client, error) {
+				token := cassette.Redacted
+				if h.Cassette.Mode() == cassette.ModeRecord {
+					token = os.Getenv("DEFINEDNET_TOKEN")
+				}
+
+				return h.Cassette.ClientFactory()(endpoint, token, version)
+			},
+			"test",
+		)
+	}
+
+	return provider.New(
+		func(string, string, string) (definednet.Client, error) {
+			return h.Server.Client(), nil
+		},
+		"test",
+	)
+}
+
+// ProviderFactories returns the Terraform provider server factories, for configuring test steps.
+func (h *Harness) ProviderFactories() map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		ProviderName: providerserver.NewProtocol6WithError(h.ProviderFactory()()),
+	}
+}
+
+// Test runs the test steps as an acceptance test case.
+func (h *Harness) Test(steps ...resource.TestStep) {
+	h.Run(resource.TestCase{Steps: steps})
+}
+
+// Run runs the acceptance test case, configuring each of its steps with a new provider.
+//
+// Test cases are run with GinkgoT, so they are skipped unless acceptance tests are enabled with TF_ACC. Recorded
+// cassettes are saved after the test case, replayed cassettes are verified to have had all their interactions used.
+func (h *Harness) Run(tc resource.TestCase) {
+	ginkgo.GinkgoHelper()
+
+	steps := make([]resource.TestStep, len(tc.Steps))
+	for idx, step := range tc.Steps {
+		step.ProtoV6ProviderFactories = h.ProviderFactories()
+		steps[idx] = step
+	}
+
+	tc.Steps = steps
+
+	resource.Test(ginkgo.GinkgoT(), tc)
+
+	if h.Cassette != nil {
+		gomega.Expect(h.Cassette.Save()).To(gomega.Succeed())
+		gomega.Expect(h.Cassette.Verify()).To(gomega.Succeed())
+	}
+}
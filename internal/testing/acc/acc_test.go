@@ -0,0 +1,63 @@
+package acc_test
+
+import (
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/acc"
+)
+
+var _ = Describe("acceptance test harness", func() {
+	Specify("providers are served", func(ctx SpecContext) {
+		harness := acc.New()
+
+		factory, ok := harness.ProviderFactories()[acc.ProviderName]
+		Expect(ok).To(BeTrue())
+
+		srv, err := factory()
+		Expect(err).NotTo(HaveOccurred())
+
+		resp, err := srv.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.ResourceSchemas).To(HaveKey("definednet_host"))
+	})
+
+	Specify("fixtures are read from the testdata directory", func() {
+		Expect(acc.Fixture("host.tf")(config.TestStepConfigRequest{})).To(Equal("testdata/host.tf"))
+	})
+
+	Specify("strings are converted to list variables", func() {
+		Expect(json.Marshal(acc.Strings("tag:one", "tag:two"))).To(MatchJSON(`["tag:one", "tag:two"]`))
+		Expect(json.Marshal(acc.Strings())).To(MatchJSON(`[]`))
+	})
+
+	Specify("import steps verify the imported state", func() {
+		step := acc.ImportStateVerify(resource.TestStep{
+			ImportStateId:           "network/test-network/host.defined.test",
+			ImportStateVerifyIgnore: []string{"metrics"},
+		}, "definednet_host.test", "enrollment_code")
+
+		Expect(step.ResourceName).To(Equal("definednet_host.test"))
+		Expect(step.ImportState).To(BeTrue())
+		Expect(step.ImportStateId).To(Equal("network/test-network/host.defined.test"))
+		Expect(step.ImportStateVerify).To(BeTrue())
+		Expect(step.ImportStateVerifyIgnore).To(Equal([]string{"metrics", "enrollment_code"}))
+	})
+
+	Specify("empty plans are expected before applying", func() {
+		checks := make([]plancheck.PlanCheck, 1, 2)
+		checks[0] = plancheck.ExpectResourceAction("definednet_host.test", plancheck.ResourceActionNoop)
+
+		step := acc.ExpectEmptyPlan(resource.TestStep{
+			ConfigPlanChecks: resource.ConfigPlanChecks{PreApply: checks},
+		})
+
+		Expect(step.ConfigPlanChecks.PreApply).To(HaveLen(2))
+		Expect(checks[:2][1]).To(BeNil(), "the step's plan checks are not modified")
+	})
+})
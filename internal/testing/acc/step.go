@@ -0,0 +1,43 @@
+package acc
+
+import (
+	"path/filepath"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/samber/lo"
+)
+
+// Fixture returns the named HCL fixture from the suite's testdata directory.
+func Fixture(name string) config.TestStepConfigFunc {
+	return config.StaticFile(filepath.Join("testdata", name))
+}
+
+// Strings returns a list variable of the strings, e.g. for tags.
+func Strings(values ...string) config.Variable {
+	return config.ListVariable(lo.Map(values, func(v string, _ int) config.Variable {
+		return config.StringVariable(v)
+	})...)
+}
+
+// ImportStateVerify turns the step into importing the named resource declared by the step's configuration.
+//
+// The imported resource's state is verified to match the resource's state, except for the ignored attributes,
+// e.g. secrets that are not returned by the Defined.net HTTP API.
+func ImportStateVerify(step resource.TestStep, resourceName string, ignore ...string) resource.TestStep {
+	step.ResourceName = resourceName
+	step.ImportState = true
+	step.ImportStateVerify = true
+	step.ImportStateVerifyIgnore = slices.Concat(step.ImportStateVerifyIgnore, ignore)
+
+	return step
+}
+
+// ExpectEmptyPlan asserts applying the step's configuration has no changes to make.
+func ExpectEmptyPlan(step resource.TestStep) resource.TestStep {
+	step.ConfigPlanChecks.PreApply = append(slices.Clone(step.ConfigPlanChecks.PreApply), plancheck.ExpectEmptyPlan())
+
+	return step
+}
@@ -0,0 +1,329 @@
+// Package cassette records and replays Defined.net HTTP API interactions.
+//
+// Interactions are recorded once against the real Defined.net HTTP API, with secrets scrubbed, and replayed
+// deterministically in offline tests. Requests are matched on their method, path, query and normalised JSON body.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+)
+
+// Mode is a cassette's mode of operation.
+type Mode string
+
+const (
+	// ModeReplay replays recorded interactions, failing requests without a recorded interaction.
+	ModeReplay Mode = "replay"
+	// ModeRecord sends requests to the HTTP API, recording the interactions.
+	ModeRecord Mode = "record"
+)
+
+// ModeFromEnv returns the mode declared by the DEFINEDNET_CASSETTE_MODE environment variable, defaults to ModeReplay.
+func ModeFromEnv() Mode {
+	return Mode(lo.CoalesceOrEmpty(os.Getenv("DEFINEDNET_CASSETTE_MODE"), string(ModeReplay)))
+}
+
+// Redacted replaces secrets in recorded interactions.
+const Redacted = "REDACTED"
+
+// ErrUnusedInteractions is returned by Verify, when a replayed cassette's interactions were not all used.
+var ErrUnusedInteractions = errors.New("cassette has unused interactions")
+
+// anyParent matches fields of any parent in secrets.
+const anyParent = "*"
+
+// secrets declares the JSON string fields scrubbed from recorded bodies, as parent and field name pairs.
+var secrets = map[[2]string]bool{
+	{"enrollmentCode", "code"}:    true,
+	{anyParent, "organizationID"}: true,
+}
+
+// Interaction is a recorded HTTP request and response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded HTTP request.
+type Request struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	Body   string `json:"body,omitempty"`
+}
+
+// Response is a recorded HTTP response.
+type Response struct {
+	StatusCode int    `json:"statusCode"`
+	Body       string `json:"body,omitempty"`
+}
+
+// Cassette is an http.RoundTripper recording or replaying HTTP interactions.
+type Cassette struct {
+	name      string
+	mode      Mode
+	transport http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+	repeatReads  bool
+}
+
+// New creates a cassette stored in the named file.
+//
+// Recording cassettes send requests with the transport, replaying cassettes load the file's interactions.
+func New(name string, mode Mode, transport http.RoundTripper) (*Cassette, error) {
+	c := &Cassette{
+		name:      name,
+		mode:      mode,
+		transport: transport,
+	}
+
+	switch mode {
+	case ModeRecord:
+		return c, nil
+
+	case ModeReplay:
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("error reading cassette %q: %w", name, err)
+		}
+
+		if err := json.Unmarshal(data, &c.interactions); err != nil {
+			return nil, fmt.Errorf("error decoding cassette %q: %w", name, err)
+		}
+
+		c.used = make([]bool, len(c.interactions))
+
+		return c, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported cassette mode %q", mode)
+	}
+}
+
+// Mode returns the cassette's mode of operation.
+func (c *Cassette) Mode() Mode {
+	return c.mode
+}
+
+// AllowRepeatedReads makes the replaying cassette answer GET requests repeated more often than recorded with their
+// last recorded interaction, e.g. as the number of times Terraform refreshes resources varies between its versions.
+func (c *Cassette) AllowRepeatedReads() *Cassette {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.repeatReads = true
+
+	return c
+}
+
+// ClientFactory returns a Defined.net client factory, creating clients sending requests through the cassette.
+//
+// The factory is assignable to provider.ClientFactory.
+func (c *Cassette) ClientFactory() func(endpoint, token, version string) (definednet.Client, error) {
+	return func(endpoint, token, version string) (definednet.Client, error) {
+		return definednet.NewClientWithTransport(endpoint, token, version, c)
+	}
+}
+
+// Save writes the recorded interactions to the cassette's file. Replaying cassettes are not saved.
+func (c *Cassette) Save() error {
+	if c.mode != ModeRecord {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, err := json.MarshalIndent(lo.Ternary(c.interactions == nil, []Interaction{}, c.interactions), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.name), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(c.name, append(data, '\n'), 0o644)
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	token, _ := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+
+	recorded := Request{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  req.URL.Query().Encode(),
+		Body:   scrub(body, token),
+	}
+
+	if c.mode == ModeReplay {
+		return c.replay(req, recorded)
+	}
+
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	resp, err := c.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := readBody(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.interactions = append(c.interactions, Interaction{
+		Request: recorded,
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Body:       scrub(respBody, token),
+		},
+	})
+
+	return resp, nil
+}
+
+// replay responds with the first unused interaction matching the request.
+//
+// Repeated reads are answered with the last used interaction matching the request, when allowed.
+func (c *Cassette) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	last := -1
+	for idx, interaction := range c.interactions {
+		if interaction.Request != recorded {
+			continue
+		}
+
+		if c.used[idx] {
+			last = idx
+			continue
+		}
+
+		c.used[idx] = true
+
+		return respond(req, interaction.Response), nil
+	}
+
+	if c.repeatReads && recorded.Method == http.MethodGet && last >= 0 {
+		return respond(req, c.interactions[last].Response), nil
+	}
+
+	return nil, fmt.Errorf("cassette %q has no interaction matching %s %s", c.name, recorded.Method, recorded.Path)
+}
+
+// respond creates the HTTP response to the request from the recorded response.
+func respond(req *http.Request, resp Response) *http.Response {
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode)),
+		StatusCode: resp.StatusCode,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(resp.Body)),
+		Request:    req,
+	}
+}
+
+// readBody reads and closes the body, which may be nil.
+func readBody(body io.ReadCloser) ([]byte, error) {
+	if body == nil {
+		return nil, nil
+	}
+	defer body.Close()
+
+	return io.ReadAll(body)
+}
+
+// scrub normalises the JSON body and redacts its secrets, including the API token.
+//
+// Bodies that are not valid JSON are only redacted of the API token.
+func scrub(body []byte, token string) string {
+	if token != "" {
+		body = bytes.ReplaceAll(body, []byte(token), []byte(Redacted))
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		return ""
+	}
+
+	var doc any
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return string(body)
+	}
+
+	// Object keys are sorted when encoding, which normalises the body.
+	data, err := json.Marshal(redact("", doc))
+	if err != nil {
+		return string(body)
+	}
+
+	return string(data)
+}
+
+// redact replaces the secrets in the decoded JSON value.
+func redact(parent string, value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if s, ok := field.(string); ok && s != "" && (secrets[[2]string{parent, key}] || secrets[[2]string{anyParent, key}]) {
+				v[key] = Redacted
+				continue
+			}
+
+			v[key] = redact(key, field)
+		}
+
+	case []any:
+		for idx, item := range v {
+			v[idx] = redact(parent, item)
+		}
+	}
+
+	return value
+}
+
+// Verify returns an error, when the replayed cassette's interactions were not all used.
+func (c *Cassette) Verify() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.mode != ModeReplay {
+		return nil
+	}
+
+	if unused := lo.Count(c.used, false); unused > 0 {
+		return fmt.Errorf("%w: %d of %d interactions in %q", ErrUnusedInteractions, unused, len(c.used), c.name)
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package cassette_test
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/cassette"
+	fakeserver "github.com/sendsmaily/terraform-provider-definednet/internal/testing/server"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/server/servertest"
+)
+
+var _ = Describe("recording and replaying interactions", func() {
+	var (
+		server *fakeserver.Server
+		name   string
+	)
+
+	BeforeEach(func() {
+		server = servertest.New()
+		DeferCleanup(server.Close)
+
+		Expect(server.Networks.Add(fakeserver.Network{ID: "network-id", Name: "test-network"})).To(Succeed())
+
+		name = filepath.Join(GinkgoT().TempDir(), "cassettes", "enrollment.json")
+	})
+
+	// record records creating and retrieving a host against the fake server.
+	record := func(ctx SpecContext) *definednet.Enrollment {
+		recorder := lo.Must(cassette.New(name, cassette.ModeRecord, http.DefaultTransport))
+		client := lo.Must(recorder.ClientFactory()(server.URL(), server.Token, "test"))
+
+		enrollment, err := definednet.CreateEnrollment(ctx, client, definednet.CreateEnrollmentRequest{
+			NetworkID: "network-id",
+			Name:      "host.defined.test",
+			Tags:      []string{"tag:one"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = definednet.GetHost(ctx, client, definednet.GetHostRequest{ID: "host-MISSING"})
+		Expect(err).To(HaveOccurred())
+
+		Expect(recorder.Save()).To(Succeed())
+
+		return enrollment
+	}
+
+	Specify("recorded interactions are replayed", func(ctx SpecContext) {
+		recorded := record(ctx)
+		server.Close()
+
+		player := lo.Must(cassette.New(name, cassette.ModeReplay, nil))
+		client := lo.Must(player.ClientFactory()("http://definednet.invalid/", "other-token", "test"))
+
+		enrollment, err := definednet.CreateEnrollment(ctx, client, definednet.CreateEnrollmentRequest{
+			Tags:      []string{"tag:one"},
+			Name:      "host.defined.test",
+			NetworkID: "network-id",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(enrollment.Host).To(Equal(recorded.Host))
+		Expect(enrollment.EnrollmentCode.Code).To(Equal(cassette.Redacted))
+
+		_, err = definednet.GetHost(ctx, client, definednet.GetHostRequest{ID: "host-MISSING"})
+		Expect(err).To(MatchError(ContainSubstring("code=404")))
+
+		Expect(player.Verify()).To(Succeed())
+	})
+
+	Specify("secrets are scrubbed from recorded interactions", func(ctx SpecContext) {
+		recorded := record(ctx)
+
+		data := string(lo.Must(os.ReadFile(name)))
+		Expect(data).NotTo(ContainSubstring(recorded.EnrollmentCode.Code))
+		Expect(data).NotTo(ContainSubstring(server.Token))
+	})
+
+	Specify("organization IDs are scrubbed from recorded interactions", func(ctx SpecContext) {
+		recorder := lo.Must(cassette.New(name, cassette.ModeRecord, roundTripper(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body: io.NopCloser(strings.NewReader(`{
+					"data": {"id": "host-id", "organizationID": "org-SECRET", "name": "host.defined.test"}
+				}`)),
+				Request: req,
+			}, nil
+		})))
+		client := lo.Must(recorder.ClientFactory()("http://definednet.invalid/", "token", "test"))
+
+		host, err := definednet.GetHost(ctx, client, definednet.GetHostRequest{ID: "host-id"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(host.OrganizationID).To(Equal("org-SECRET"))
+
+		Expect(recorder.Save()).To(Succeed())
+		Expect(os.ReadFile(name)).NotTo(ContainSubstring("org-SECRET"))
+	})
+
+	Specify("requests without recorded interactions fail", func(ctx SpecContext) {
+		record(ctx)
+
+		player := lo.Must(cassette.New(name, cassette.ModeReplay, nil))
+		client := lo.Must(player.ClientFactory()(server.URL(), server.Token, "test"))
+
+		_, err := definednet.CreateEnrollment(ctx, client, definednet.CreateEnrollmentRequest{
+			NetworkID: "network-id",
+			Name:      "other.defined.test",
+		})
+		Expect(err).To(MatchError(ContainSubstring("has no interaction matching POST /v1/host-and-enrollment-code")))
+
+		Expect(player.Verify()).To(MatchError(cassette.ErrUnusedInteractions))
+	})
+
+	Specify("interactions are replayed once", func(ctx SpecContext) {
+		record(ctx)
+
+		player := lo.Must(cassette.New(name, cassette.ModeReplay, nil))
+		client := lo.Must(player.ClientFactory()(server.URL(), server.Token, "test"))
+
+		_, err := definednet.GetHost(ctx, client, definednet.GetHostRequest{ID: "host-MISSING"})
+		Expect(err).To(MatchError(ContainSubstring("code=404")))
+
+		_, err = definednet.GetHost(ctx, client, definednet.GetHostRequest{ID: "host-MISSING"})
+		Expect(err).To(MatchError(ContainSubstring("has no interaction matching GET /v1/hosts/host-MISSING")))
+	})
+
+	Specify("repeated reads are replayed, when allowed", func(ctx SpecContext) {
+		record(ctx)
+
+		player := lo.Must(cassette.New(name, cassette.ModeReplay, nil)).AllowRepeatedReads()
+		client := lo.Must(player.ClientFactory()(server.URL(), server.Token, "test"))
+
+		for range 3 {
+			_, err := definednet.GetHost(ctx, client, definednet.GetHostRequest{ID: "host-MISSING"})
+			Expect(err).To(MatchError(ContainSubstring("code=404")))
+		}
+
+		// Repeated writes are not replayed.
+		req := definednet.CreateEnrollmentRequest{NetworkID: "network-id", Name: "host.defined.test", Tags: []string{"tag:one"}}
+		Expect(definednet.CreateEnrollment(ctx, client, req)).Error().NotTo(HaveOccurred())
+		Expect(definednet.CreateEnrollment(ctx, client, req)).Error().To(MatchError(ContainSubstring("has no interaction matching POST")))
+
+		Expect(player.Verify()).To(Succeed())
+	})
+
+	Specify("request bodies are matched regardless of formatting", func(ctx SpecContext) {
+		record(ctx)
+
+		player := lo.Must(cassette.New(name, cassette.ModeReplay, nil))
+		req := lo.Must(http.NewRequestWithContext(ctx, http.MethodPost, "http://definednet.invalid/v1/host-and-enrollment-code", strings.NewReader(`{
+			"tags": ["tag:one"],
+			"name": "host.defined.test",
+			"networkID": "network-id",
+			"isRelay": false,
+			"isLighthouse": false,
+			"listenPort": 0,
+			"staticAddresses": null,
+			"configOverrides": null
+		}`)))
+
+		resp := lo.Must((&http.Client{Transport: player}).Do(req))
+		defer resp.Body.Close()
+
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	})
+
+	Specify("unsupported modes are refused", func() {
+		Expect(cassette.New(name, cassette.Mode("invalid"), nil)).Error().To(MatchError(`unsupported cassette mode "invalid"`))
+	})
+
+	Specify("missing cassettes are refused", func() {
+		Expect(cassette.New(name, cassette.ModeReplay, nil)).Error().To(MatchError(ContainSubstring("error reading cassette")))
+	})
+})
+
+// roundTripper is an http.RoundTripper function.
+type roundTripper func(*http.Request) (*http.Response, error)
+
+// RoundTrip implements the http.RoundTripper interface.
+func (fn roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
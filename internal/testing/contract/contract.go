@@ -0,0 +1,119 @@
+// Package contract validates Defined.net HTTP API interactions against the transcribed OpenAPI document.
+//
+// The validation keeps the client and the fake API consistent with the transcription in package openapi. The
+// transcription is not the published document, so the validation does not detect either drifting from the real API.
+package contract
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet/openapi"
+)
+
+// NewTransport creates a transport validating requests and responses against the OpenAPI document.
+//
+// Requests are validated before, and responses after sending them with the next transport. Interactions violating the
+// document fail with an error.
+func NewTransport(next http.RoundTripper) (*Transport, error) {
+	data, err := openapi.Document()
+	if err != nil {
+		return nil, fmt.Errorf("error applying OpenAPI overlay: %w", err)
+	}
+
+	doc, err := openapi3.NewLoader().LoadFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("error loading OpenAPI document: %w", err)
+	}
+
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document: %w", err)
+	}
+
+	// Requests are routed by their path only, regardless of the server they are sent to.
+	doc.Servers = nil
+
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("error routing OpenAPI document: %w", err)
+	}
+
+	return &Transport{
+		router: router,
+		next:   next,
+	}, nil
+}
+
+// Transport is an http.RoundTripper validating requests and responses against the OpenAPI document.
+type Transport struct {
+	router routers.Router
+	next   http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	route, params, err := t.router.FindRoute(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s is not declared by the OpenAPI document: %w", req.Method, req.URL.Path, err)
+	}
+
+	input := &openapi3filter.RequestValidationInput{
+		Request:    req,
+		PathParams: params,
+		Route:      route,
+		Options: &openapi3filter.Options{
+			MultiError:         true,
+			AuthenticationFunc: authenticate,
+		},
+	}
+
+	if err := openapi3filter.ValidateRequest(req.Context(), input); err != nil {
+		return nil, fmt.Errorf("request %s %s violates the OpenAPI document: %w", req.Method, req.URL.Path, err)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err := openapi3filter.ValidateResponse(req.Context(), (&openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 resp.StatusCode,
+		Header:                 resp.Header,
+		Options: &openapi3filter.Options{
+			MultiError:            true,
+			IncludeResponseStatus: true,
+		},
+	}).SetBodyBytes(body)); err != nil {
+		return nil, fmt.Errorf("response to %s %s violates the OpenAPI document: %w", req.Method, req.URL.Path, err)
+	}
+
+	return resp, nil
+}
+
+// authenticate validates the request is authorized with a bearer token.
+func authenticate(_ context.Context, input *openapi3filter.AuthenticationInput) error {
+	token, ok := strings.CutPrefix(input.RequestValidationInput.Request.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return errors.New("missing bearer token")
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package contract_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/contract"
+	fakeserver "github.com/sendsmaily/terraform-provider-definednet/internal/testing/server"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/server/servertest"
+)
+
+var _ = Describe("the fake API and the client", func() {
+	var (
+		server *fakeserver.Server
+		client definednet.Client
+	)
+
+	BeforeEach(func() {
+		server = servertest.New()
+		DeferCleanup(server.Close)
+
+		Expect(server.Networks.Add(fakeserver.Network{ID: "network-id", Name: "test-network", CIDR: "10.0.0.0/16"})).To(Succeed())
+
+		transport := lo.Must(contract.NewTransport(http.DefaultTransport))
+		client = lo.Must(definednet.NewClientWithTransport(server.URL(), server.Token, "test", transport))
+	})
+
+	Specify("host interactions conform to the transcribed OpenAPI document", func(ctx SpecContext) {
+		role, err := definednet.CreateRole(ctx, client, definednet.CreateRoleRequest{Name: "test: Role"})
+		Expect(err).NotTo(HaveOccurred())
+
+		enrollment, err := definednet.CreateEnrollment(ctx, client, definednet.CreateEnrollmentRequest{
+			NetworkID:       "network-id",
+			RoleID:          role.ID,
+			Name:            "lighthouse.defined.test",
+			StaticAddresses: []string{"127.0.0.1:8484"},
+			ListenPort:      8484,
+			IsLighthouse:    true,
+			Tags:            []string{"tag:one"},
+			ConfigOverrides: []definednet.ConfigOverride{{Key: "logging.level", Value: "debug"}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = definednet.CreateEnrollment(ctx, client, definednet.CreateEnrollmentRequest{
+			NetworkID: "network-id",
+			Name:      "host.defined.test",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = definednet.GetHost(ctx, client, definednet.GetHostRequest{ID: enrollment.Host.ID})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = definednet.UpdateHost(ctx, client, definednet.UpdateHostRequest{
+			ID:         enrollment.Host.ID,
+			Name:       "updated.defined.test",
+			ListenPort: 8484,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(definednet.BlockHost(ctx, client, definednet.BlockHostRequest{ID: enrollment.Host.ID})).To(Succeed())
+		Expect(definednet.UnblockHost(ctx, client, definednet.UnblockHostRequest{ID: enrollment.Host.ID})).To(Succeed())
+
+		Expect(definednet.ListHosts(ctx, client, definednet.ListHostsRequest{PageSize: 1})).To(HaveLen(2))
+		Expect(definednet.ListNetworks(ctx, client, definednet.ListNetworksRequest{})).To(HaveLen(1))
+
+		Expect(definednet.DeleteHost(ctx, client, definednet.DeleteHostRequest{ID: enrollment.Host.ID})).To(Succeed())
+	})
+
+	Specify("role interactions conform to the transcribed OpenAPI document", func(ctx SpecContext) {
+		role, err := definednet.CreateRole(ctx, client, definednet.CreateRoleRequest{
+			Name:        "test: Role",
+			Description: "Test role",
+			FirewallRules: []definednet.FirewallRule{
+				{Protocol: "TCP", Description: "HTTPS", PortRange: &definednet.PortRange{From: 443, To: 443}},
+				{Protocol: "ANY", AllowedTags: []string{"tag:one"}},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = definednet.UpdateRole(ctx, client, definednet.UpdateRoleRequest{
+			ID:   role.ID,
+			Name: "test: Updated role",
+			FirewallRules: []definednet.FirewallRule{
+				{Protocol: "ICMP", AllowedRoleID: role.ID},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(definednet.GetRole(ctx, client, definednet.GetRoleRequest{ID: role.ID})).NotTo(BeNil())
+		Expect(definednet.ListRoles(ctx, client, definednet.ListRolesRequest{})).To(HaveLen(1))
+		Expect(definednet.DeleteRole(ctx, client, definednet.DeleteRoleRequest{ID: role.ID})).To(Succeed())
+	})
+
+	Specify("error responses conform to the transcribed OpenAPI document", func(ctx SpecContext) {
+		var apiErr *definednet.Error
+
+		_, err := definednet.GetHost(ctx, client, definednet.GetHostRequest{ID: "host-MISSING"})
+		Expect(err).To(BeAssignableToTypeOf(apiErr))
+
+		_, err = definednet.UpdateHost(ctx, client, definednet.UpdateHostRequest{ID: "host-MISSING", Name: "host.defined.test"})
+		Expect(err).To(BeAssignableToTypeOf(apiErr))
+	})
+})
+
+var _ = Describe("drifting from the transcribed OpenAPI document", func() {
+	var (
+		server *ghttp.Server
+		client *http.Client
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		DeferCleanup(server.Close)
+
+		client = &http.Client{Transport: lo.Must(contract.NewTransport(http.DefaultTransport))}
+	})
+
+	DescribeTable("drifted requests are refused",
+		func(method, path, body string) {
+			req := lo.Must(http.NewRequestWithContext(GinkgoT().Context(), method, server.URL()+path, lo.Ternary[io.Reader](body != "", strings.NewReader(body), nil)))
+			req.Header.Set("Authorization", "Bearer supersecret")
+			req.Header.Set("Content-Type", "application/json")
+
+			_, err := client.Do(req)
+			Expect(err).To(MatchError(ContainSubstring("violates the OpenAPI document")))
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		},
+		Entry("assert renamed fields are refused",
+			http.MethodPut, "/v2/hosts/host-id", `{"name": "host.defined.test", "roleId": "role-id"}`,
+		),
+		Entry("assert mistyped fields are refused",
+			http.MethodPut, "/v2/hosts/host-id", `{"name": "host.defined.test", "configOverrides": {"key": "value"}}`,
+		),
+		Entry("assert missing fields are refused",
+			http.MethodPost, "/v1/roles", `{"description": "Test role"}`,
+		),
+		Entry("assert unknown protocols are refused",
+			http.MethodPost, "/v1/roles", `{"name": "test: Role", "firewallRules": [{"protocol": "SCTP"}]}`,
+		),
+		Entry("assert invalid page sizes are refused",
+			http.MethodGet, "/v1/roles?pageSize=1000", "",
+		),
+	)
+
+	Specify("undeclared endpoints are refused", func(ctx SpecContext) {
+		req := lo.Must(http.NewRequestWithContext(ctx, http.MethodGet, server.URL()+"/v3/hosts", nil))
+		req.Header.Set("Authorization", "Bearer supersecret")
+
+		_, err := client.Do(req)
+		Expect(err).To(MatchError(ContainSubstring("is not declared by the OpenAPI document")))
+	})
+
+	DescribeTable("drifted responses are refused",
+		func(body string) {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusOK, body, http.Header{"Content-Type": []string{"application/json"}}))
+
+			req := lo.Must(http.NewRequestWithContext(GinkgoT().Context(), http.MethodGet, server.URL()+"/v1/hosts/host-id", nil))
+			req.Header.Set("Authorization", "Bearer supersecret")
+
+			_, err := client.Do(req)
+			Expect(err).To(MatchError(ContainSubstring("violates the OpenAPI document")))
+		},
+		Entry("assert missing fields are refused",
+			`{"data": {"id": "host-id", "networkID": "network-id", "name": "host.defined.test", "ipAddress": "10.0.0.1", "staticAddresses": [], "listenPort": 0, "isLighthouse": false, "isRelay": false, "isBlocked": false, "tags": []}}`,
+		),
+		Entry("assert mistyped fields are refused",
+			`{"data": {"id": "host-id", "networkID": "network-id", "name": "host.defined.test", "ipAddress": "10.0.0.1", "staticAddresses": [], "listenPort": "8484", "isLighthouse": false, "isRelay": false, "isBlocked": false, "tags": [], "configOverrides": []}}`,
+		),
+	)
+})
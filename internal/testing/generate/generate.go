@@ -0,0 +1,172 @@
+// Package generate implements generators of valid Defined.net objects for property-based tests.
+//
+// Generated objects are shaped like the Defined.net HTTP API's responses, e.g. optional fields are either
+// empty or hold valid values.
+package generate
+
+import (
+	"net"
+	"net/netip"
+	"strconv"
+
+	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	"pgregory.net/rapid"
+)
+
+// Protocols are the firewall rules' protocols.
+var Protocols = []string{"ANY", "TCP", "UDP", "ICMP"}
+
+// ID generates Defined.net object IDs with the prefix, e.g. host-2ab8f3.
+func ID(prefix string) *rapid.Generator[string] {
+	return rapid.Map(rapid.StringMatching(`[0-9a-f]{6}`), func(s string) string {
+		return prefix + "-" + s
+	})
+}
+
+// Optional generates the zero value or the generator's values.
+func Optional[T any](gen *rapid.Generator[T]) *rapid.Generator[T] {
+	var zero T
+	return rapid.OneOf(rapid.Just(zero), gen)
+}
+
+// Name generates object names, e.g. host names and role names.
+func Name() *rapid.Generator[string] {
+	return rapid.StringMatching(`[a-z][a-z0-9-]{0,15}(\.[a-z][a-z0-9-]{0,15}){0,2}`)
+}
+
+// Description generates free-form descriptions.
+func Description() *rapid.Generator[string] {
+	return rapid.StringMatching(`[A-Za-z0-9][A-Za-z0-9 :,.-]{0,31}`)
+}
+
+// Tag generates host tags, e.g. env:prod.
+func Tag() *rapid.Generator[string] {
+	return rapid.StringMatching(`[a-z]{1,8}:[a-z0-9-]{1,8}`)
+}
+
+// Tags generates distinct host tags, nil when there are none.
+func Tags() *rapid.Generator[[]string] {
+	return rapid.Map(rapid.SliceOfNDistinct(Tag(), 0, 4, rapid.ID), func(tags []string) []string {
+		return lo.Ternary(len(tags) == 0, nil, tags)
+	})
+}
+
+// Port generates network ports.
+func Port() *rapid.Generator[int] {
+	return rapid.IntRange(1, 65535)
+}
+
+// PortRange generates firewall rules' port ranges, including single-port ranges.
+func PortRange() *rapid.Generator[definednet.PortRange] {
+	return rapid.Custom(func(t *rapid.T) definednet.PortRange {
+		from := Port().Draw(t, "from")
+
+		if rapid.Bool().Draw(t, "single") {
+			return definednet.PortRange{From: from, To: from}
+		}
+
+		return definednet.PortRange{From: from, To: rapid.IntRange(from, 65535).Draw(t, "to")}
+	})
+}
+
+// IPAddress generates IPv4 and IPv6 addresses in their canonical representation.
+func IPAddress() *rapid.Generator[string] {
+	ipv4 := rapid.Map(rapid.SliceOfN(rapid.Byte(), 4, 4), func(b []byte) netip.Addr {
+		return netip.AddrFrom4([4]byte(b))
+	})
+
+	// IPv4-mapped IPv6 addresses are excluded, as they are represented as IPv4 addresses.
+	ipv6 := rapid.Map(rapid.SliceOfN(rapid.Byte(), 16, 16), func(b []byte) netip.Addr {
+		return netip.AddrFrom16([16]byte(b))
+	}).Filter(func(addr netip.Addr) bool {
+		return !addr.Is4In6()
+	})
+
+	return rapid.Map(rapid.OneOf(ipv4, ipv6), netip.Addr.String)
+}
+
+// CIDR generates IPv4 CIDRs in their canonical representation.
+func CIDR() *rapid.Generator[string] {
+	return rapid.Custom(func(t *rapid.T) string {
+		addr := netip.AddrFrom4([4]byte(rapid.SliceOfN(rapid.Byte(), 4, 4).Draw(t, "bytes")))
+		return netip.PrefixFrom(addr, rapid.IntRange(0, 32).Draw(t, "bits")).Masked().String()
+	})
+}
+
+// FirewallRule generates firewall rules.
+//
+// Rules allow traffic from any combination of a role, tags and a CIDR, or from any host when none are set.
+func FirewallRule() *rapid.Generator[definednet.FirewallRule] {
+	return rapid.Custom(func(t *rapid.T) definednet.FirewallRule {
+		rule := definednet.FirewallRule{
+			Protocol:      rapid.SampledFrom(Protocols).Draw(t, "protocol"),
+			Description:   Optional(Description()).Draw(t, "description"),
+			AllowedRoleID: Optional(ID("role")).Draw(t, "allowedRoleID"),
+			AllowedTags:   Tags().Draw(t, "allowedTags"),
+			AllowedCIDR:   Optional(CIDR()).Draw(t, "allowedCIDR"),
+			LocalCIDR:     Optional(CIDR()).Draw(t, "localCIDR"),
+		}
+
+		if rapid.Bool().Draw(t, "ports") {
+			rule.PortRange = lo.ToPtr(PortRange().Draw(t, "portRange"))
+		}
+
+		return rule
+	})
+}
+
+// MetricsOverrides generates the metrics exporter's config overrides, as encoded by the provider.
+//
+// The extra metrics are toggled with the extraMetricsKey, which differs between hosts and lighthouses.
+func MetricsOverrides(extraMetricsKey string) *rapid.Generator[[]definednet.ConfigOverride] {
+	return rapid.Custom(func(t *rapid.T) []definednet.ConfigOverride {
+		if !rapid.Bool().Draw(t, "metrics") {
+			return nil
+		}
+
+		return []definednet.ConfigOverride{
+			{Key: "stats.type", Value: "prometheus"},
+			{Key: "stats.listen", Value: net.JoinHostPort(IPAddress().Draw(t, "listenHost"), strconv.Itoa(Port().Draw(t, "listenPort")))},
+			{Key: "stats.path", Value: rapid.StringMatching(`/[a-z0-9/_-]{0,16}`).Draw(t, "path")},
+			{Key: "stats.namespace", Value: rapid.StringMatching(`[a-z][a-z0-9_]{0,15}`).Draw(t, "namespace")},
+			{Key: "stats.subsystem", Value: rapid.StringMatching(`[a-z][a-z0-9_]{0,15}`).Draw(t, "subsystem")},
+			{Key: "stats." + extraMetricsKey, Value: rapid.Bool().Draw(t, "extraMetrics")},
+			{Key: "stats.interval", Value: "60s"},
+		}
+	})
+}
+
+// Host generates hosts, which are neither lighthouses nor relays.
+func Host() *rapid.Generator[definednet.Host] {
+	return rapid.Custom(func(t *rapid.T) definednet.Host {
+		return definednet.Host{
+			ID:              ID("host").Draw(t, "id"),
+			NetworkID:       ID("network").Draw(t, "networkID"),
+			RoleID:          Optional(ID("role")).Draw(t, "roleID"),
+			Name:            Name().Draw(t, "name"),
+			IPAddress:       IPAddress().Draw(t, "ipAddress"),
+			StaticAddresses: []string{},
+			Tags:            Tags().Draw(t, "tags"),
+			IsBlocked:       rapid.Bool().Draw(t, "isBlocked"),
+			ConfigOverrides: MetricsOverrides("message_metrics").Draw(t, "configOverrides"),
+		}
+	})
+}
+
+// Lighthouse generates lighthouses, advertising static addresses on their listen port or on other ports.
+func Lighthouse() *rapid.Generator[definednet.Host] {
+	return rapid.Custom(func(t *rapid.T) definednet.Host {
+		host := Host().Draw(t, "host")
+		host.IsLighthouse = true
+		host.ListenPort = Port().Draw(t, "listenPort")
+		host.ConfigOverrides = MetricsOverrides("lighthouse_metrics").Draw(t, "configOverrides")
+
+		host.StaticAddresses = rapid.SliceOfN(rapid.Custom(func(t *rapid.T) string {
+			port := rapid.OneOf(rapid.Just(host.ListenPort), Port()).Draw(t, "port")
+			return net.JoinHostPort(IPAddress().Draw(t, "host"), strconv.Itoa(port))
+		}), 1, 4).Draw(t, "staticAddresses")
+
+		return host
+	})
+}
@@ -5,31 +5,38 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/samber/lo"
 	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
 )
 
-func (s *Server) createEnrollment(w http.ResponseWriter, r *http.Request) {
+func (s *Handler) createEnrollment(w http.ResponseWriter, r *http.Request) {
 	var req definednet.CreateEnrollmentRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		panic(err)
+	if err := decodeRequest(r, &req); err != nil {
+		respondWithError(w, err)
+		return
 	}
 
 	state := Host{
 		Host: definednet.Host{
-			ID:              fmt.Sprintf("host-%s", strings.ToUpper(lo.RandomString(8, lo.AlphanumericCharset))),
-			NetworkID:       req.NetworkID,
-			RoleID:          req.RoleID,
-			Name:            req.Name,
-			IPAddress:       "10.0.0.1",
-			StaticAddresses: req.StaticAddresses,
-			ListenPort:      req.ListenPort,
-			IsLighthouse:    req.IsLighthouse,
-			IsRelay:         req.IsRelay,
+			ID:           fmt.Sprintf("host-%s", strings.ToUpper(lo.RandomString(8, lo.AlphanumericCharset))),
+			NetworkID:    req.NetworkID,
+			RoleID:       req.RoleID,
+			Name:         req.Name,
+			IPAddress:    "10.0.0.1",
+			ListenPort:   req.ListenPort,
+			IsLighthouse: req.IsLighthouse,
+			IsRelay:      req.IsRelay,
+			CreatedAt:    lo.ToPtr(time.Now().UTC()),
 		},
 	}
 
+	state.Host.StaticAddresses = []string{}
+	if !lo.IsNil(req.StaticAddresses) {
+		state.Host.StaticAddresses = req.StaticAddresses
+	}
+
 	state.Host.Tags = []string{}
 	if !lo.IsNil(req.Tags) {
 		state.Host.Tags = req.Tags
@@ -40,8 +47,20 @@ func (s *Server) createEnrollment(w http.ResponseWriter, r *http.Request) {
 		state.Host.ConfigOverrides = req.ConfigOverrides
 	}
 
+	var v validator
+
+	_, err := s.Networks.Get(req.NetworkID)
+	v.check(err == nil, "networkID", "network %q does not exist", req.NetworkID)
+
+	s.validateHost(&v, state.Host)
+	if err := v.err(); err != nil {
+		respondWithError(w, err)
+		return
+	}
+
 	if err := s.Hosts.Add(state); err != nil {
-		panic(err)
+		respondWithError(w, err)
+		return
 	}
 
 	w.WriteHeader(http.StatusOK)
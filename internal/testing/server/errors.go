@@ -0,0 +1,83 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+)
+
+// Defined.net HTTP API error codes used by the fake API, besides definednet.ErrCodeDuplicateValue.
+const (
+	errCodeInvalidValue = "ERR_INVALID_VALUE"
+	errCodeNotFound     = "ERR_NOT_FOUND"
+	errCodeConflict     = "ERR_CONFLICT"
+	errCodeUnauthorized = "ERR_UNAUTHORIZED"
+	errCodeInternal     = "ERR_INTERNAL"
+)
+
+// ValidationError is a request validation failure.
+type ValidationError struct {
+	Errors []definednet.ErrorDetail
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	return "request validation failed"
+}
+
+// respondWithError responds with the Defined.net HTTP API error response matching the error.
+func respondWithError(w http.ResponseWriter, err error) {
+	var (
+		validationErr *ValidationError
+		constraintErr *ConstraintError
+		notFoundErr   *NotFoundError
+	)
+
+	switch {
+	case errors.As(err, &validationErr):
+		writeErrors(w, http.StatusBadRequest, validationErr.Errors...)
+
+	case errors.As(err, &constraintErr):
+		writeErrors(w, http.StatusBadRequest, definednet.ErrorDetail{
+			Code:    definednet.ErrCodeDuplicateValue,
+			Message: "value already exists",
+			Path:    constraintErr.Field,
+		})
+
+	case errors.As(err, &notFoundErr):
+		writeErrors(w, http.StatusNotFound, definednet.ErrorDetail{
+			Code:    errCodeNotFound,
+			Message: "object not found",
+		})
+
+	default:
+		writeErrors(w, http.StatusInternalServerError, definednet.ErrorDetail{
+			Code:    errCodeInternal,
+			Message: err.Error(),
+		})
+	}
+}
+
+// writeErrors writes the Defined.net HTTP API error envelope.
+func writeErrors(w http.ResponseWriter, status int, errs ...definednet.ErrorDetail) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(map[string]any{
+		"errors": errs,
+	}); err != nil {
+		panic(err)
+	}
+}
+
+// decodeRequest decodes the JSON request body, reporting malformed bodies as validation errors.
+func decodeRequest(r *http.Request, req any) error {
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		return &ValidationError{Errors: []definednet.ErrorDetail{
+			{Code: errCodeInvalidValue, Message: "request body must be a valid JSON object"},
+		}}
+	}
+
+	return nil
+}
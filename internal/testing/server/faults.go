@@ -0,0 +1,207 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+)
+
+// errCodeFault is the error code of the fake API's injected error responses.
+const errCodeFault = "ERR_INJECTED_FAULT"
+
+// Fault is a fault injected into the fake API's responses to matching requests.
+//
+// Faults are applied in the order latency, connection reset, error response and truncated body. A fault with only
+// latency delays the request, which is then served normally.
+type Fault struct {
+	// Method is the HTTP method of matching requests, any method matches when empty.
+	Method string `json:"method"`
+	// Path is the path.Match pattern of matching requests' URL paths, e.g. /v1/hosts/*; any path matches when empty.
+	Path string `json:"path"`
+	// Rate is the probability of injecting the fault into a matching request, the fault is always injected when zero.
+	Rate float64 `json:"rate"`
+	// Count is the number of times the fault is injected, the fault is injected indefinitely when zero.
+	Count int `json:"count"`
+
+	// Latency delays the response.
+	Latency Duration `json:"latency"`
+	// Reset resets the connection without a response.
+	Reset bool `json:"reset"`
+	// StatusCode is the status code of the error response, e.g. 429, 502 or 503.
+	StatusCode int `json:"statusCode"`
+	// RetryAfter sets the error response's Retry-After header, rounded to seconds.
+	RetryAfter Duration `json:"retryAfter"`
+	// Truncate serves the request, but cuts the response body in half.
+	//
+	// The request's changes are applied, like with responses lost in transit.
+	Truncate bool `json:"truncate"`
+}
+
+// matches reports whether the fault applies to the request.
+func (f Fault) matches(r *http.Request) bool {
+	if f.Method != "" && f.Method != r.Method {
+		return false
+	}
+
+	if f.Path != "" {
+		if ok, _ := path.Match(f.Path, r.URL.Path); !ok {
+			return false
+		}
+	}
+
+	return f.Rate == 0 || rand.Float64() < f.Rate
+}
+
+// Duration is a time.Duration decoded from a Go duration string, e.g. 250ms.
+type Duration time.Duration
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string: %w", err)
+	}
+
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+
+	*d = Duration(v)
+
+	return nil
+}
+
+// FaultPlan is a programmable set of faults injected into the fake API's responses.
+//
+// The first fault matching a request is injected. Faults are removed from the plan, once injected Count times.
+type FaultPlan struct {
+	mu     sync.Mutex
+	faults []*faultState
+}
+
+type faultState struct {
+	Fault
+	injected int
+}
+
+// Inject adds the faults to the plan.
+func (p *FaultPlan) Inject(faults ...Fault) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, f := range faults {
+		p.faults = append(p.faults, &faultState{Fault: f})
+	}
+}
+
+// Clear removes all faults from the plan.
+func (p *FaultPlan) Clear() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.faults = nil
+}
+
+// next returns the fault to inject into the request, if any.
+func (p *FaultPlan) next(r *http.Request) (Fault, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for idx, f := range p.faults {
+		if !f.matches(r) {
+			continue
+		}
+
+		f.injected++
+		if f.Count > 0 && f.injected >= f.Count {
+			p.faults = append(p.faults[:idx], p.faults[idx+1:]...)
+		}
+
+		return f.Fault, true
+	}
+
+	return Fault{}, false
+}
+
+// LoadFaults reads a YAML or JSON file listing faults.
+func LoadFaults(name string) ([]Fault, error) {
+	var faults []Fault
+	if err := loadYAML(name, &faults); err != nil {
+		return nil, fmt.Errorf("error decoding faults %q: %w", name, err)
+	}
+
+	return faults, nil
+}
+
+// injectFaults injects the fault plan's faults into the responses.
+func (s *Handler) injectFaults(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fault, ok := s.Faults.next(r)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if fault.Latency > 0 {
+			select {
+			case <-time.After(time.Duration(fault.Latency)):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		switch {
+		case fault.Reset:
+			resetConnection(w)
+
+		case fault.StatusCode != 0:
+			if fault.RetryAfter > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int(time.Duration(fault.RetryAfter).Round(time.Second).Seconds())))
+			}
+
+			writeErrors(w, fault.StatusCode, definednet.ErrorDetail{
+				Code:    errCodeFault,
+				Message: http.StatusText(fault.StatusCode),
+			})
+
+		case fault.Truncate:
+			rec := httptest.NewRecorder()
+			next.ServeHTTP(rec, r)
+
+			for k, v := range rec.Header() {
+				w.Header()[k] = v
+			}
+
+			body := rec.Body.Bytes()
+			w.WriteHeader(rec.Code)
+			_, _ = w.Write(body[:len(body)/2])
+
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// resetConnection closes the request's connection without a response, resetting TCP connections.
+func resetConnection(w http.ResponseWriter) {
+	conn, _, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		panic(http.ErrAbortHandler)
+	}
+
+	if tcp, ok := conn.(*net.TCPConn); ok {
+		_ = tcp.SetLinger(0)
+	}
+
+	_ = conn.Close()
+}
@@ -0,0 +1,152 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	fakeserver "github.com/sendsmaily/terraform-provider-definednet/internal/testing/server"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/server/servertest"
+)
+
+var _ = Describe("injecting faults", func() {
+	var server *fakeserver.Server
+
+	BeforeEach(func() {
+		server = servertest.New()
+		DeferCleanup(server.Close)
+
+		Expect(server.Roles.Add(fakeserver.Role{ID: "role-id", Name: "test: Role"})).To(Succeed())
+	})
+
+	DescribeTable("error responses are injected",
+		func(statusCode int) {
+			server.Faults.Inject(fakeserver.Fault{StatusCode: statusCode})
+
+			_, err := definednet.GetRole(GinkgoT().Context(), server.Client(), definednet.GetRoleRequest{ID: "role-id"})
+
+			var apiErr *definednet.Error
+			Expect(errors.As(err, &apiErr)).To(BeTrue())
+			Expect(apiErr.StatusCode).To(Equal(statusCode))
+		},
+		Entry("assert 429 responses are injected", http.StatusTooManyRequests),
+		Entry("assert 502 responses are injected", http.StatusBadGateway),
+		Entry("assert 503 responses are injected", http.StatusServiceUnavailable),
+	)
+
+	Specify("rate limited responses declare when to retry", func(ctx SpecContext) {
+		server.Faults.Inject(fakeserver.Fault{
+			StatusCode: http.StatusTooManyRequests,
+			RetryAfter: fakeserver.Duration(2 * time.Second),
+		})
+
+		req := lo.Must(http.NewRequestWithContext(ctx, http.MethodGet, server.URL()+"/v1/roles/role-id", nil))
+		req.Header.Set("Authorization", "Bearer "+server.Token)
+
+		resp := lo.Must(http.DefaultClient.Do(req))
+		defer resp.Body.Close()
+
+		Expect(resp.StatusCode).To(Equal(http.StatusTooManyRequests))
+		Expect(resp.Header.Get("Retry-After")).To(Equal("2"))
+	})
+
+	Specify("faults are injected into matching requests only", func(ctx SpecContext) {
+		server.Faults.Inject(fakeserver.Fault{
+			Method:     http.MethodGet,
+			Path:       "/v1/roles/*",
+			StatusCode: http.StatusServiceUnavailable,
+		})
+
+		Expect(definednet.ListRoles(ctx, server.Client(), definednet.ListRolesRequest{})).To(HaveLen(1))
+
+		_, err := definednet.GetRole(ctx, server.Client(), definednet.GetRoleRequest{ID: "role-id"})
+		Expect(err).To(HaveOccurred())
+
+		server.Faults.Clear()
+
+		Expect(definednet.GetRole(ctx, server.Client(), definednet.GetRoleRequest{ID: "role-id"})).NotTo(BeNil())
+	})
+
+	Specify("faults are injected the declared number of times", func(ctx SpecContext) {
+		server.Faults.Inject(fakeserver.Fault{StatusCode: http.StatusBadGateway, Count: 2})
+
+		for range 2 {
+			_, err := definednet.GetRole(ctx, server.Client(), definednet.GetRoleRequest{ID: "role-id"})
+			Expect(err).To(HaveOccurred())
+		}
+
+		Expect(definednet.GetRole(ctx, server.Client(), definednet.GetRoleRequest{ID: "role-id"})).NotTo(BeNil())
+	})
+
+	Specify("faults are skipped at negligible rates", func(ctx SpecContext) {
+		server.Faults.Inject(fakeserver.Fault{StatusCode: http.StatusBadGateway, Rate: 1e-12})
+
+		Expect(definednet.GetRole(ctx, server.Client(), definednet.GetRoleRequest{ID: "role-id"})).NotTo(BeNil())
+	})
+
+	Specify("latency delays responses", func(ctx SpecContext) {
+		server.Faults.Inject(fakeserver.Fault{Latency: fakeserver.Duration(time.Minute)})
+
+		timeout, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		_, err := definednet.GetRole(timeout, server.Client(), definednet.GetRoleRequest{ID: "role-id"})
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+	})
+
+	Specify("connections are reset", func(ctx SpecContext) {
+		server.Faults.Inject(fakeserver.Fault{Reset: true, Count: 1})
+
+		_, err := definednet.GetRole(ctx, server.Client(), definednet.GetRoleRequest{ID: "role-id"})
+		Expect(err).To(HaveOccurred())
+
+		var apiErr *definednet.Error
+		Expect(errors.As(err, &apiErr)).To(BeFalse())
+	})
+
+	Specify("truncated responses apply the request's changes", func(ctx SpecContext) {
+		server.Faults.Inject(fakeserver.Fault{Method: http.MethodPost, Truncate: true})
+
+		_, err := definednet.CreateRole(ctx, server.Client(), definednet.CreateRoleRequest{Name: "test: Other role"})
+		Expect(err).To(HaveOccurred())
+
+		Expect(server.Roles.List()).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+			"Name": Equal("test: Other role"),
+		})))
+	})
+
+	Specify("faults are loaded from files", func() {
+		name := filepath.Join(GinkgoT().TempDir(), "faults.yaml")
+		Expect(os.WriteFile(name, []byte(`
+- path: /v2/hosts
+  rate: 0.5
+  statusCode: 429
+  retryAfter: 5s
+- method: PUT
+  latency: 250ms
+  truncate: true
+`), 0o600)).To(Succeed())
+
+		Expect(fakeserver.LoadFaults(name)).To(Equal([]fakeserver.Fault{
+			{
+				Path:       "/v2/hosts",
+				Rate:       0.5,
+				StatusCode: http.StatusTooManyRequests,
+				RetryAfter: fakeserver.Duration(5 * time.Second),
+			},
+			{
+				Method:   http.MethodPut,
+				Latency:  fakeserver.Duration(250 * time.Millisecond),
+				Truncate: true,
+			},
+		}))
+	})
+})
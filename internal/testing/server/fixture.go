@@ -0,0 +1,127 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	"go.yaml.in/yaml/v3"
+)
+
+// Fixture is a snapshot of the fake API's data.
+//
+// Objects are encoded with their Defined.net HTTP API field names, e.g. networkID.
+type Fixture struct {
+	Hosts    []definednet.Host    `json:"hosts"`
+	Networks []definednet.Network `json:"networks"`
+	Roles    []definednet.Role    `json:"roles"`
+}
+
+// LoadFixture reads a YAML or JSON fixture file.
+//
+// JSON is valid YAML, so both formats are decoded with the YAML decoder.
+func LoadFixture(name string) (*Fixture, error) {
+	var fixture Fixture
+	if err := loadYAML(name, &fixture); err != nil {
+		return nil, fmt.Errorf("error decoding fixture %q: %w", name, err)
+	}
+
+	return &fixture, nil
+}
+
+// loadYAML decodes a YAML or JSON file into v.
+//
+// The YAML document is converted to JSON, so the JSON field names of v apply.
+func loadYAML(name string, v any) error {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+
+	data, err = json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// SaveFixture writes the fixture to a JSON file.
+//
+// The file is replaced atomically, so concurrent readers never see partially written fixtures.
+func SaveFixture(name string, fixture *Fixture) error {
+	data, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), name)
+}
+
+// Seed adds the fixture's objects to the fake API.
+func (s *Handler) Seed(fixture *Fixture) error {
+	for _, network := range fixture.Networks {
+		if err := s.Networks.Add(Network(network)); err != nil {
+			return fmt.Errorf("error seeding network: %w", err)
+		}
+	}
+
+	for _, role := range fixture.Roles {
+		role.FirewallRules = lo.Ternary(role.FirewallRules == nil, []definednet.FirewallRule{}, role.FirewallRules)
+
+		if err := s.Roles.Add(Role(role)); err != nil {
+			return fmt.Errorf("error seeding role: %w", err)
+		}
+	}
+
+	for _, host := range fixture.Hosts {
+		// Hosts created through the API never have nil slices.
+		host.Tags = lo.Ternary(host.Tags == nil, []string{}, host.Tags)
+		host.StaticAddresses = lo.Ternary(host.StaticAddresses == nil, []string{}, host.StaticAddresses)
+		host.ConfigOverrides = lo.Ternary(host.ConfigOverrides == nil, []definednet.ConfigOverride{}, host.ConfigOverrides)
+
+		if err := s.Hosts.Add(Host{Host: host}); err != nil {
+			return fmt.Errorf("error seeding host: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Snapshot returns the fake API's current data as a fixture.
+func (s *Handler) Snapshot() *Fixture {
+	return &Fixture{
+		Hosts: lo.Map(s.Hosts.List(), func(h Host, _ int) definednet.Host {
+			return h.Host
+		}),
+		Networks: lo.Map(s.Networks.List(), func(n Network, _ int) definednet.Network {
+			return definednet.Network(n)
+		}),
+		Roles: lo.Map(s.Roles.List(), func(r Role, _ int) definednet.Role {
+			return definednet.Role(r)
+		}),
+	}
+}
@@ -0,0 +1,101 @@
+package server_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	fakeserver "github.com/sendsmaily/terraform-provider-definednet/internal/testing/server"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/server/servertest"
+)
+
+var _ = DescribeTable("loading fixtures",
+	func(name, contents string) {
+		path := filepath.Join(GinkgoT().TempDir(), name)
+		Expect(os.WriteFile(path, []byte(contents), 0o600)).To(Succeed())
+
+		Expect(fakeserver.LoadFixture(path)).To(Equal(&fakeserver.Fixture{
+			Hosts: []definednet.Host{
+				{ID: "host-id", NetworkID: "network-id", Name: "host.defined.test", Tags: []string{"tag:one"}},
+			},
+			Networks: []definednet.Network{
+				{ID: "network-id", Name: "test-network", CIDR: "10.0.0.0/16"},
+			},
+			Roles: []definednet.Role{
+				{ID: "role-id", Name: "test: Role"},
+			},
+		}))
+	},
+	Entry("assert YAML fixtures are loaded", "fixture.yaml", `
+hosts:
+  - id: host-id
+    networkID: network-id
+    name: host.defined.test
+    tags: ["tag:one"]
+networks:
+  - id: network-id
+    name: test-network
+    cidr: 10.0.0.0/16
+roles:
+  - id: role-id
+    name: "test: Role"
+`),
+	Entry("assert JSON fixtures are loaded", "fixture.json", `{
+  "hosts": [{"id": "host-id", "networkID": "network-id", "name": "host.defined.test", "tags": ["tag:one"]}],
+  "networks": [{"id": "network-id", "name": "test-network", "cidr": "10.0.0.0/16"}],
+  "roles": [{"id": "role-id", "name": "test: Role"}]
+}`),
+)
+
+var _ = Describe("seeding and persisting data", func() {
+	var server *fakeserver.Server
+
+	BeforeEach(func() {
+		server = servertest.New()
+		DeferCleanup(server.Close)
+	})
+
+	Specify("seeded objects are served by the API", func(ctx SpecContext) {
+		Expect(server.Seed(&fakeserver.Fixture{
+			Hosts:    []definednet.Host{{ID: "host-id", NetworkID: "network-id", Name: "host.defined.test"}},
+			Networks: []definednet.Network{{ID: "network-id", Name: "test-network"}},
+			Roles:    []definednet.Role{{ID: "role-id", Name: "test: Role"}},
+		})).To(Succeed())
+
+		host, err := definednet.GetHost(ctx, server.Client(), definednet.GetHostRequest{ID: "host-id"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(host.Tags).To(BeEmpty())
+		Expect(host.Tags).NotTo(BeNil(), "hosts are normalized like hosts created through the API")
+
+		Expect(definednet.ListNetworks(ctx, server.Client(), definednet.ListNetworksRequest{})).To(HaveLen(1))
+		Expect(definednet.ListRoles(ctx, server.Client(), definednet.ListRolesRequest{})).To(HaveLen(1))
+	})
+
+	Specify("seeding enforces the repository constraints", func() {
+		Expect(server.Seed(&fakeserver.Fixture{
+			Roles: []definednet.Role{{ID: "role-one", Name: "test: Role"}, {ID: "role-two", Name: "test: Role"}},
+		})).To(MatchError(ContainSubstring("error seeding role")))
+	})
+
+	Specify("snapshots survive a save and load round trip", func(ctx SpecContext) {
+		Expect(server.Seed(&fakeserver.Fixture{
+			Networks: []definednet.Network{{ID: "network-id", Name: "test-network"}},
+		})).To(Succeed())
+
+		_, err := definednet.CreateRole(ctx, server.Client(), definednet.CreateRoleRequest{Name: "test: Role"})
+		Expect(err).NotTo(HaveOccurred())
+
+		path := filepath.Join(GinkgoT().TempDir(), "state.json")
+		Expect(fakeserver.SaveFixture(path, server.Snapshot())).To(Succeed())
+
+		restored := servertest.New()
+		DeferCleanup(restored.Close)
+
+		fixture, err := fakeserver.LoadFixture(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restored.Seed(fixture)).To(Succeed())
+		Expect(restored.Snapshot()).To(Equal(server.Snapshot()))
+	})
+})
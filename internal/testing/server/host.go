@@ -20,10 +20,11 @@ func (h Host) Key() string {
 	return h.Host.ID
 }
 
-func (s *Server) getHost(w http.ResponseWriter, r *http.Request) {
+func (s *Handler) getHost(w http.ResponseWriter, r *http.Request) {
 	state, err := s.Hosts.Get(chi.URLParam(r, "id"))
 	if err != nil {
-		panic(err)
+		respondWithError(w, err)
+		return
 	}
 
 	w.WriteHeader(http.StatusOK)
@@ -34,22 +35,34 @@ func (s *Server) getHost(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (s *Server) updateHost(w http.ResponseWriter, r *http.Request) {
+func (s *Handler) listHosts(w http.ResponseWriter, r *http.Request) {
+	paginate(w, r, lo.Map(s.Hosts.List(), func(h Host, _ int) definednet.Host {
+		return h.Host
+	}))
+}
+
+func (s *Handler) updateHost(w http.ResponseWriter, r *http.Request) {
 	var req definednet.UpdateHostRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		panic(err)
+	if err := decodeRequest(r, &req); err != nil {
+		respondWithError(w, err)
+		return
 	}
 
 	state, err := s.Hosts.Get(chi.URLParam(r, "id"))
 	if err != nil {
-		panic(err)
+		respondWithError(w, err)
+		return
 	}
 
 	state.Host.Name = req.Name
 	state.Host.RoleID = req.RoleID
-	state.Host.StaticAddresses = req.StaticAddresses
 	state.Host.ListenPort = req.ListenPort
 
+	state.Host.StaticAddresses = []string{}
+	if !lo.IsNil(req.StaticAddresses) {
+		state.Host.StaticAddresses = req.StaticAddresses
+	}
+
 	state.Host.Tags = []string{}
 	if !lo.IsNil(req.Tags) {
 		state.Host.Tags = req.Tags
@@ -60,8 +73,17 @@ func (s *Server) updateHost(w http.ResponseWriter, r *http.Request) {
 		state.Host.ConfigOverrides = req.ConfigOverrides
 	}
 
+	var v validator
+
+	s.validateHost(&v, state.Host)
+	if err := v.err(); err != nil {
+		respondWithError(w, err)
+		return
+	}
+
 	if err := s.Hosts.Replace(*state); err != nil {
-		panic(err)
+		respondWithError(w, err)
+		return
 	}
 
 	w.WriteHeader(http.StatusOK)
@@ -72,10 +94,38 @@ func (s *Server) updateHost(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (s *Server) deleteHost(w http.ResponseWriter, r *http.Request) {
+func (s *Handler) blockHost(blocked bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		state, err := s.Hosts.Get(chi.URLParam(r, "id"))
+		if err != nil {
+			respondWithError(w, err)
+			return
+		}
+
+		state.Host.IsBlocked = blocked
+
+		if err := s.Hosts.Replace(*state); err != nil {
+			respondWithError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(definednet.Response[map[string]definednet.Host]{
+			Data: map[string]definednet.Host{"host": state.Host},
+		}); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func (s *Handler) deleteHost(w http.ResponseWriter, r *http.Request) {
 	if err := s.Hosts.Remove(chi.URLParam(r, "id")); err != nil {
-		panic(err)
+		respondWithError(w, err)
+		return
 	}
 
 	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(definednet.Response[struct{}]{}); err != nil {
+		panic(err)
+	}
 }
@@ -0,0 +1,22 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+)
+
+// Network is a data model for a Defined.net network.
+type Network definednet.Network
+
+// Key returns the network's repository key.
+func (n Network) Key() string {
+	return n.ID
+}
+
+func (s *Handler) listNetworks(w http.ResponseWriter, r *http.Request) {
+	paginate(w, r, lo.Map(s.Networks.List(), func(n Network, _ int) definednet.Network {
+		return definednet.Network(n)
+	}))
+}
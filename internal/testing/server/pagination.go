@@ -0,0 +1,42 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+)
+
+// paginate responds with the page of objects selected by the request's cursor and page size.
+//
+// Cursors are offsets of the page's first object.
+func paginate[O any](w http.ResponseWriter, r *http.Request, objects []O) {
+	var v validator
+
+	pageSize, err := strconv.Atoi(lo.CoalesceOrEmpty(r.URL.Query().Get("pageSize"), "25"))
+	v.check(err == nil && pageSize >= 1 && pageSize <= 500, "pageSize", "page size must be between 1 and 500")
+
+	offset, err := strconv.Atoi(lo.CoalesceOrEmpty(r.URL.Query().Get("cursor"), "0"))
+	v.check(err == nil && offset >= 0, "cursor", "cursor is invalid")
+
+	if err := v.err(); err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	page := lo.Subset(objects, offset, uint(pageSize))
+	next := offset + len(page)
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(definednet.Page[O]{
+		Data: lo.Ternary(page != nil, page, []O{}),
+		Metadata: definednet.PageMetadata{
+			HasNextPage: next < len(objects),
+			Cursor:      lo.Ternary(next < len(objects), strconv.Itoa(next), ""),
+		},
+	}); err != nil {
+		panic(err)
+	}
+}
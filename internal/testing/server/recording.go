@@ -0,0 +1,76 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+)
+
+// Request is a request received by the fake API.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	// Body is the decoded JSON request body, nil when the request has no body.
+	//
+	// Bodies that are not valid JSON are recorded as strings.
+	Body any
+}
+
+// requestLog is a log of requests received by the fake API.
+type requestLog struct {
+	mu       sync.Mutex
+	requests []Request
+}
+
+// Requests returns the requests received by the fake API, in the order received.
+func (s *Handler) Requests() []Request {
+	s.requests.mu.Lock()
+	defer s.requests.mu.Unlock()
+
+	return slices.Clone(s.requests.requests)
+}
+
+// ResetRequests clears the received requests.
+func (s *Handler) ResetRequests() {
+	s.requests.mu.Lock()
+	defer s.requests.mu.Unlock()
+
+	s.requests.requests = nil
+}
+
+// record records the requests received by the fake API.
+func (s *Handler) record(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			panic(err)
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(data))
+
+		req := Request{
+			Method: r.Method,
+			Path:   r.URL.Path,
+			Query:  r.URL.Query(),
+			Header: r.Header.Clone(),
+		}
+
+		if len(bytes.TrimSpace(data)) > 0 {
+			if err := json.Unmarshal(data, &req.Body); err != nil {
+				req.Body = string(data)
+			}
+		}
+
+		s.requests.mu.Lock()
+		s.requests.requests = append(s.requests.requests, req)
+		s.requests.mu.Unlock()
+
+		next.ServeHTTP(w, r)
+	})
+}
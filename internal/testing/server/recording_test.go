@@ -0,0 +1,85 @@
+package server_test
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	fakeserver "github.com/sendsmaily/terraform-provider-definednet/internal/testing/server"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/server/servertest"
+)
+
+var _ = Describe("recording requests", func() {
+	var server *fakeserver.Server
+
+	BeforeEach(func() {
+		server = servertest.New()
+		DeferCleanup(server.Close)
+
+		Expect(server.Networks.Add(fakeserver.Network{ID: "network-id", Name: "test-network"})).To(Succeed())
+		Expect(server.Hosts.Add(fakeserver.Host{Host: definednet.Host{
+			ID:        "host-id",
+			NetworkID: "network-id",
+			Name:      "host.defined.test",
+		}})).To(Succeed())
+	})
+
+	Specify("requests with bodies are recorded", func(ctx SpecContext) {
+		_, err := definednet.UpdateHost(ctx, server.Client(), definednet.UpdateHostRequest{
+			ID:   "host-id",
+			Name: "updated.defined.test",
+			Tags: []string{"tag:one"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		servertest.ExpectRequest(
+			server,
+			servertest.HaveMethod(http.MethodPut),
+			servertest.HavePath("/v2/hosts/host-id"),
+			servertest.HaveHeader("Authorization", "Bearer supersecret"),
+			servertest.HaveHeader("Content-Type", "application/json"),
+			servertest.HaveHeader("User-Agent", HavePrefix("Terraform-smaily-definednet/")),
+			servertest.HaveBody(`{
+				"name": "updated.defined.test",
+				"staticAddresses": null,
+				"listenPort": 0,
+				"tags": ["tag:one"],
+				"configOverrides": null
+			}`),
+		)
+
+		Expect(server).To(servertest.HaveReceivedRequest(
+			servertest.HaveBody(HaveKeyWithValue("name", "updated.defined.test")),
+		))
+	})
+
+	Specify("requests without bodies are recorded", func(ctx SpecContext) {
+		_, err := definednet.ListHosts(ctx, server.Client(), definednet.ListHostsRequest{PageSize: 10})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(server.Requests()).To(HaveExactElements(SatisfyAll(
+			servertest.HaveMethod(http.MethodGet),
+			servertest.HavePath("/v2/hosts"),
+			servertest.HaveQuery("pageSize", "10"),
+			servertest.HaveHeader("Accept", "application/json"),
+			servertest.HaveHeader("Content-Type", BeEmpty()),
+			servertest.HaveBody(BeNil()),
+		)))
+	})
+
+	Specify("unmatched requests fail the assertion", func(ctx SpecContext) {
+		Expect(definednet.DeleteHost(ctx, server.Client(), definednet.DeleteHostRequest{ID: "host-id"})).To(Succeed())
+
+		Expect(server).NotTo(servertest.HaveReceivedRequest(servertest.HaveMethod(http.MethodPut)))
+		Expect(server).To(servertest.HaveReceivedRequest(servertest.HaveMethod(http.MethodDelete)))
+	})
+
+	Specify("recorded requests are reset", func(ctx SpecContext) {
+		Expect(definednet.DeleteHost(ctx, server.Client(), definednet.DeleteHostRequest{ID: "host-id"})).To(Succeed())
+
+		server.ResetRequests()
+
+		Expect(server.Requests()).To(BeEmpty())
+	})
+})
@@ -2,20 +2,27 @@ package server
 
 import (
 	"fmt"
+	"slices"
 	"sync"
+
+	"github.com/samber/lo"
 )
 
-// NewRepository creates a fake API data repository.
-func NewRepository[O Object]() *Repository[O] {
+// NewRepository creates a fake API data repository, enforcing the uniqueness constraints.
+func NewRepository[O Object](constraints ...Unique[O]) *Repository[O] {
 	return &Repository[O]{
-		data: make(map[string]O),
+		constraints: constraints,
+		data:        make(map[string]O),
+		revisions:   make(map[string]int),
 	}
 }
 
 // Repository is a fake API data repository.
 type Repository[O Object] struct {
-	mu   sync.Mutex
-	data map[string]O
+	mu          sync.Mutex
+	constraints []Unique[O]
+	data        map[string]O
+	revisions   map[string]int
 }
 
 // Object is the object stored in the repository.
@@ -23,6 +30,39 @@ type Object interface {
 	Key() string
 }
 
+// Unique is a uniqueness constraint on the repository's objects.
+type Unique[O Object] struct {
+	// Field is the API field the constraint violations are reported on.
+	Field string
+	// Value derives the object's value, which must be unique in the repository.
+	// Objects with empty values are not constrained.
+	Value func(O) string
+}
+
+// NotFoundError is returned for objects missing from the repository.
+type NotFoundError struct {
+	// ID is the primary identifier of the missing object.
+	ID string
+}
+
+// Error implements the error interface.
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("object with id %q does not exist", e.ID)
+}
+
+// ConstraintError is a uniqueness constraint violation.
+type ConstraintError struct {
+	// Field is the API field the constraint is declared on.
+	Field string
+	// ID is the primary identifier of the object already holding the value.
+	ID string
+}
+
+// Error implements the error interface.
+func (e *ConstraintError) Error() string {
+	return fmt.Sprintf("%s is already used by object with id %q", e.Field, e.ID)
+}
+
 // Add an object to repository.
 func (r *Repository[O]) Add(m O) error {
 	r.mu.Lock()
@@ -33,10 +73,12 @@ func (r *Repository[O]) Add(m O) error {
 		return fmt.Errorf("object with id %q already exists", m.Key())
 	}
 
-	// TODO: implement additional constraints enforcement.
-	// For example, Host.Name values must be unique.
+	if err := r.enforce(m); err != nil {
+		return err
+	}
 
 	r.data[m.Key()] = m
+	r.revisions[m.Key()] = 1
 
 	return nil
 }
@@ -48,7 +90,7 @@ func (r *Repository[O]) Get(id string) (*O, error) {
 
 	obj, exists := r.data[id]
 	if !exists {
-		return nil, fmt.Errorf("object with id %q does not exist", id)
+		return nil, &NotFoundError{ID: id}
 	}
 
 	return &obj, nil
@@ -60,10 +102,11 @@ func (r *Repository[O]) Remove(id string) error {
 	defer r.mu.Unlock()
 
 	if _, exists := r.data[id]; !exists {
-		return fmt.Errorf("object with id %q does not exist", id)
+		return &NotFoundError{ID: id}
 	}
 
 	delete(r.data, id)
+	delete(r.revisions, id)
 
 	return nil
 }
@@ -74,10 +117,59 @@ func (r *Repository[O]) Replace(m O) error {
 	defer r.mu.Unlock()
 
 	if _, exists := r.data[m.Key()]; !exists {
-		return fmt.Errorf("object with id %q does not exist", m.Key())
+		return &NotFoundError{ID: m.Key()}
+	}
+
+	if err := r.enforce(m); err != nil {
+		return err
 	}
 
 	r.data[m.Key()] = m
+	r.revisions[m.Key()]++
+
+	return nil
+}
+
+// List objects in the repository, ordered by their keys.
+func (r *Repository[O]) List() []O {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	keys := lo.Keys(r.data)
+	slices.Sort(keys)
+
+	return lo.Map(keys, func(key string, _ int) O {
+		return r.data[key]
+	})
+}
+
+// Revision returns the object's revision, incremented on every change of the object.
+func (r *Repository[O]) Revision(id string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rev, exists := r.revisions[id]
+	if !exists {
+		return 0, &NotFoundError{ID: id}
+	}
+
+	return rev, nil
+}
+
+// enforce the uniqueness constraints against the other objects in the repository.
+func (r *Repository[O]) enforce(m O) error {
+	for _, constraint := range r.constraints {
+		value := constraint.Value(m)
+		if value == "" {
+			continue
+		}
+
+		for key, obj := range r.data {
+			if key != m.Key() && constraint.Value(obj) == value {
+				return &ConstraintError{Field: constraint.Field, ID: key}
+			}
+		}
+	}
 
 	return nil
 }
@@ -0,0 +1,80 @@
+package server_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	fakeserver "github.com/sendsmaily/terraform-provider-definednet/internal/testing/server"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/server/servertest"
+)
+
+var _ = Describe("repository uniqueness constraints", func() {
+	var server *fakeserver.Server
+
+	BeforeEach(func() {
+		server = servertest.New()
+		DeferCleanup(server.Close)
+
+		Expect(server.Hosts.Add(fakeserver.Host{Host: definednet.Host{
+			ID:        "host-ONE",
+			NetworkID: "network-id",
+			Name:      "one.defined.test",
+		}})).To(Succeed())
+
+		Expect(server.Roles.Add(fakeserver.Role{ID: "role-ONE", Name: "test: Role"})).To(Succeed())
+	})
+
+	Specify("host names are unique per network", func() {
+		Expect(server.Hosts.Add(fakeserver.Host{Host: definednet.Host{
+			ID:        "host-TWO",
+			NetworkID: "network-id",
+			Name:      "one.defined.test",
+		}})).To(MatchError(&fakeserver.ConstraintError{Field: "name", ID: "host-ONE"}))
+
+		Expect(server.Hosts.Add(fakeserver.Host{Host: definednet.Host{
+			ID:        "host-TWO",
+			NetworkID: "other-network-id",
+			Name:      "one.defined.test",
+		}})).To(Succeed())
+	})
+
+	Specify("hosts can not be renamed to used names", func() {
+		Expect(server.Hosts.Add(fakeserver.Host{Host: definednet.Host{
+			ID:        "host-TWO",
+			NetworkID: "network-id",
+			Name:      "two.defined.test",
+		}})).To(Succeed())
+
+		Expect(server.Hosts.Replace(fakeserver.Host{Host: definednet.Host{
+			ID:        "host-TWO",
+			NetworkID: "network-id",
+			Name:      "one.defined.test",
+		}})).To(MatchError(&fakeserver.ConstraintError{Field: "name", ID: "host-ONE"}))
+
+		Expect(server.Hosts.Replace(fakeserver.Host{Host: definednet.Host{
+			ID:        "host-ONE",
+			NetworkID: "network-id",
+			Name:      "one.defined.test",
+		}})).To(Succeed(), "objects do not conflict with themselves")
+	})
+
+	Specify("role names are unique globally", func() {
+		Expect(server.Roles.Add(fakeserver.Role{ID: "role-TWO", Name: "test: Role"})).
+			To(MatchError(&fakeserver.ConstraintError{Field: "name", ID: "role-ONE"}))
+
+		Expect(server.Roles.Add(fakeserver.Role{ID: "role-TWO", Name: "test: Other role"})).To(Succeed())
+	})
+
+	Specify("objects with empty values are not constrained", func() {
+		Expect(server.Roles.Add(fakeserver.Role{ID: "role-TWO"})).To(Succeed())
+		Expect(server.Roles.Add(fakeserver.Role{ID: "role-THREE"})).To(Succeed())
+	})
+
+	Specify("constraint violations are reported by the API", func(ctx SpecContext) {
+		_, err := definednet.CreateRole(ctx, server.Client(), definednet.CreateRoleRequest{Name: "test: Role"})
+
+		detail, ok := definednet.DuplicateValue(err, "name")
+		Expect(ok).To(BeTrue())
+		Expect(detail.Message).To(Equal("value already exists"))
+	})
+})
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/samber/lo"
@@ -19,21 +20,31 @@ func (r Role) Key() string {
 	return r.ID
 }
 
-func (s *Server) createRole(w http.ResponseWriter, r *http.Request) {
+func (s *Handler) createRole(w http.ResponseWriter, r *http.Request) {
 	var req definednet.CreateRoleRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		panic(err)
+	if err := decodeRequest(r, &req); err != nil {
+		respondWithError(w, err)
+		return
 	}
 
 	state := Role{
 		ID:            fmt.Sprintf("role-%s", strings.ToUpper(lo.RandomString(8, lo.AlphanumericCharset))),
 		Name:          req.Name,
 		Description:   req.Description,
-		FirewallRules: req.FirewallRules,
+		FirewallRules: lo.Ternary(req.FirewallRules == nil, []definednet.FirewallRule{}, req.FirewallRules),
+		CreatedAt:     lo.ToPtr(time.Now().UTC()),
+	}
+
+	state.ModifiedAt = state.CreatedAt
+
+	if err := s.validateRole(state); err != nil {
+		respondWithError(w, err)
+		return
 	}
 
 	if err := s.Roles.Add(state); err != nil {
-		panic(err)
+		respondWithError(w, err)
+		return
 	}
 
 	w.WriteHeader(http.StatusOK)
@@ -44,10 +55,11 @@ func (s *Server) createRole(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (s *Server) getRole(w http.ResponseWriter, r *http.Request) {
+func (s *Handler) getRole(w http.ResponseWriter, r *http.Request) {
 	state, err := s.Roles.Get(chi.URLParam(r, "id"))
 	if err != nil {
-		panic(err)
+		respondWithError(w, err)
+		return
 	}
 
 	w.WriteHeader(http.StatusOK)
@@ -58,23 +70,38 @@ func (s *Server) getRole(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (s *Server) updateRole(w http.ResponseWriter, r *http.Request) {
+func (s *Handler) listRoles(w http.ResponseWriter, r *http.Request) {
+	paginate(w, r, lo.Map(s.Roles.List(), func(role Role, _ int) definednet.Role {
+		return definednet.Role(role)
+	}))
+}
+
+func (s *Handler) updateRole(w http.ResponseWriter, r *http.Request) {
 	var req definednet.UpdateRoleRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		panic(err)
+	if err := decodeRequest(r, &req); err != nil {
+		respondWithError(w, err)
+		return
 	}
 
 	state, err := s.Roles.Get(chi.URLParam(r, "id"))
 	if err != nil {
-		panic(err)
+		respondWithError(w, err)
+		return
 	}
 
 	state.Name = req.Name
 	state.Description = req.Description
-	state.FirewallRules = req.FirewallRules
+	state.FirewallRules = lo.Ternary(req.FirewallRules == nil, []definednet.FirewallRule{}, req.FirewallRules)
+	state.ModifiedAt = lo.ToPtr(time.Now().UTC())
+
+	if err := s.validateRole(*state); err != nil {
+		respondWithError(w, err)
+		return
+	}
 
 	if err := s.Roles.Replace(*state); err != nil {
-		panic(err)
+		respondWithError(w, err)
+		return
 	}
 
 	w.WriteHeader(http.StatusOK)
@@ -85,10 +112,26 @@ func (s *Server) updateRole(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (s *Server) deleteRole(w http.ResponseWriter, r *http.Request) {
-	if err := s.Roles.Remove(chi.URLParam(r, "id")); err != nil {
-		panic(err)
+func (s *Handler) deleteRole(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	// Roles assigned to hosts can not be deleted.
+	if lo.ContainsBy(s.Hosts.List(), func(h Host) bool { return h.Host.RoleID == id }) {
+		writeErrors(w, http.StatusConflict, definednet.ErrorDetail{
+			Code:    errCodeConflict,
+			Message: "role is assigned to hosts",
+		})
+
+		return
+	}
+
+	if err := s.Roles.Remove(id); err != nil {
+		respondWithError(w, err)
+		return
 	}
 
 	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(definednet.Response[struct{}]{}); err != nil {
+		panic(err)
+	}
 }
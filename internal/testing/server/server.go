@@ -2,61 +2,136 @@ package server
 
 import (
 	"log"
+	"net/http"
 	"net/http/httptest"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
-	"github.com/onsi/ginkgo/v2"
 	"github.com/samber/lo"
 	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
 )
 
-// New creates a fake Defined.net HTTP API server.
-func New() *Server {
-	middleware.DefaultLogger = middleware.RequestLogger(&middleware.DefaultLogFormatter{
-		Logger: log.New(ginkgo.GinkgoWriter, "", log.LstdFlags),
-	})
+// NewServer starts a fake Defined.net HTTP API server on a local port, logging requests to the logger.
+//
+// Ginkgo tests create servers with servertest.New instead.
+func NewServer(logger *log.Logger) *Server {
+	handler := NewHandler(logger)
 
-	mux := chi.NewMux()
-	mux.Use(middleware.Logger)
-	mux.Use(middleware.Recoverer)
+	return &Server{Handler: handler, server: httptest.NewServer(handler)}
+}
 
-	srv := &Server{
-		Hosts: NewRepository[Host](),
-		Roles: NewRepository[Role](),
+// NewHandler creates a fake Defined.net HTTP API handler, logging requests to the logger.
+//
+// The handler is served by the caller, e.g. by a standalone HTTP server.
+func NewHandler(logger *log.Logger) *Handler {
+	srv := &Handler{
+		// Host names are unique per network.
+		Hosts: NewRepository(Unique[Host]{
+			Field: "name",
+			Value: func(h Host) string {
+				return lo.Ternary(h.Host.Name != "", h.Host.NetworkID+"/"+h.Host.Name, "")
+			},
+		}),
+		Networks: NewRepository[Network](),
+		// Role names are unique globally.
+		Roles: NewRepository(Unique[Role]{
+			Field: "name",
+			Value: func(r Role) string {
+				return r.Name
+			},
+		}),
+		Faults: new(FaultPlan),
 	}
 
+	mux := chi.NewMux()
+	mux.Use(middleware.RequestLogger(&middleware.DefaultLogFormatter{Logger: logger, NoColor: true}))
+	mux.Use(middleware.Recoverer)
+	mux.Use(srv.record)
+	mux.Use(srv.injectFaults)
+	mux.Use(srv.authenticate)
+	mux.Use(middleware.SetHeader("Content-Type", "application/json"))
+
 	// Hosts.
 	mux.Post("/v1/host-and-enrollment-code", srv.createEnrollment)
 	mux.Delete("/v1/hosts/{id}", srv.deleteHost)
 	mux.Get("/v1/hosts/{id}", srv.getHost)
+	mux.Post("/v1/hosts/{id}/block", srv.blockHost(true))
+	mux.Post("/v1/hosts/{id}/unblock", srv.blockHost(false))
+	mux.Get("/v2/hosts", srv.listHosts)
 	mux.Put("/v2/hosts/{id}", srv.updateHost)
 
+	// Networks.
+	mux.Get("/v1/networks", srv.listNetworks)
+
 	// Roles.
+	mux.Get("/v1/roles", srv.listRoles)
 	mux.Post("/v1/roles", srv.createRole)
 	mux.Delete("/v1/roles/{id}", srv.deleteRole)
 	mux.Get("/v1/roles/{id}", srv.getRole)
 	mux.Put("/v1/roles/{id}", srv.updateRole)
 
-	srv.server = httptest.NewServer(mux)
+	srv.mux = mux
 
 	return srv
 }
 
-// Server is a fake Defined.net HTTP API server.
+// Server is a fake Defined.net HTTP API server, serving the handler on a local port.
 type Server struct {
-	Hosts *Repository[Host]
-	Roles *Repository[Role]
+	*Handler
 
 	server *httptest.Server
 }
 
+// Handler is a fake Defined.net HTTP API handler.
+type Handler struct {
+	Hosts    *Repository[Host]
+	Networks *Repository[Network]
+	Roles    *Repository[Role]
+
+	// Faults are injected into the responses, e.g. for testing retries and timeouts.
+	Faults *FaultPlan
+
+	// Token is the HTTP API token requests must be authorized with. Any token is accepted, when empty.
+	Token string
+
+	mux      *chi.Mux
+	requests requestLog
+}
+
+// ServeHTTP serves the fake HTTP API requests.
+func (s *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
 // Close the fake HTTP API server.
 func (s *Server) Close() {
 	s.server.Close()
 }
 
+// URL returns the base URL of the fake HTTP API server.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
 // Client returns a client for the fake HTTP API server.
 func (s *Server) Client() definednet.Client {
-	return lo.Must(definednet.NewClient(s.server.URL, "supersecret", "fake"))
+	return lo.Must(definednet.NewClient(s.server.URL, s.Token, "fake"))
+}
+
+// authenticate refuses requests not authorized with a bearer token.
+func (s *Handler) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" || (s.Token != "" && token != s.Token) {
+			writeErrors(w, http.StatusUnauthorized, definednet.ErrorDetail{
+				Code:    errCodeUnauthorized,
+				Message: "invalid or missing API token",
+			})
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
 }
@@ -0,0 +1,175 @@
+package server_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+	fakeserver "github.com/sendsmaily/terraform-provider-definednet/internal/testing/server"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/server/servertest"
+)
+
+var _ = Describe("fake API error responses", func() {
+	var server *fakeserver.Server
+
+	BeforeEach(func() {
+		server = servertest.New()
+		DeferCleanup(server.Close)
+
+		Expect(server.Networks.Add(fakeserver.Network{ID: "network-id", Name: "test-network"})).To(Succeed())
+		Expect(server.Roles.Add(fakeserver.Role{ID: "role-id", Name: "test: Role"})).To(Succeed())
+	})
+
+	// apiError asserts the error is a Defined.net HTTP API error response with the status code.
+	apiError := func(err error, statusCode int) *definednet.Error {
+		var apiErr *definednet.Error
+		Expect(errors.As(err, &apiErr)).To(BeTrue(), "expected an API error, got %v", err)
+		Expect(apiErr.StatusCode).To(Equal(statusCode))
+
+		return apiErr
+	}
+
+	DescribeTable("invalid host enrollments are refused",
+		func(req definednet.CreateEnrollmentRequest, path string) {
+			_, err := definednet.CreateEnrollment(GinkgoT().Context(), server.Client(), req)
+			Expect(apiError(err, http.StatusBadRequest).Errors).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Code": Equal("ERR_INVALID_VALUE"),
+				"Path": Equal(path),
+			})))
+		},
+		Entry("assert names are required",
+			definednet.CreateEnrollmentRequest{NetworkID: "network-id"},
+			"name",
+		),
+		Entry("assert names are limited in length",
+			definednet.CreateEnrollmentRequest{NetworkID: "network-id", Name: lo.RandomString(256, lo.LowerCaseLettersCharset)},
+			"name",
+		),
+		Entry("assert networks must exist",
+			definednet.CreateEnrollmentRequest{NetworkID: "network-MISSING", Name: "host.defined.test"},
+			"networkID",
+		),
+		Entry("assert roles must exist",
+			definednet.CreateEnrollmentRequest{NetworkID: "network-id", RoleID: "role-MISSING", Name: "host.defined.test"},
+			"roleID",
+		),
+		Entry("assert tags must be in the key:value format",
+			definednet.CreateEnrollmentRequest{NetworkID: "network-id", Name: "host.defined.test", Tags: []string{"tag:one", "two"}},
+			"tags[1]",
+		),
+		Entry("assert listen ports must be valid",
+			definednet.CreateEnrollmentRequest{NetworkID: "network-id", Name: "host.defined.test", ListenPort: 65536},
+			"listenPort",
+		),
+		Entry("assert lighthouses must have a listen port",
+			definednet.CreateEnrollmentRequest{NetworkID: "network-id", Name: "lighthouse.defined.test", IsLighthouse: true},
+			"listenPort",
+		),
+	)
+
+	DescribeTable("invalid roles are refused",
+		func(req definednet.CreateRoleRequest, path string) {
+			_, err := definednet.CreateRole(GinkgoT().Context(), server.Client(), req)
+			Expect(apiError(err, http.StatusBadRequest).Errors).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Code": Equal("ERR_INVALID_VALUE"),
+				"Path": Equal(path),
+			})))
+		},
+		Entry("assert names are required",
+			definednet.CreateRoleRequest{},
+			"name",
+		),
+		Entry("assert names are limited in length",
+			definednet.CreateRoleRequest{Name: lo.RandomString(51, lo.LowerCaseLettersCharset)},
+			"name",
+		),
+		Entry("assert protocols must be known",
+			definednet.CreateRoleRequest{Name: "test: Other role", FirewallRules: []definednet.FirewallRule{
+				{Protocol: "SCTP"},
+			}},
+			"firewallRules[0].protocol",
+		),
+		Entry("assert port ranges must be valid",
+			definednet.CreateRoleRequest{Name: "test: Other role", FirewallRules: []definednet.FirewallRule{
+				{Protocol: "TCP", PortRange: &definednet.PortRange{From: 443, To: 80}},
+			}},
+			"firewallRules[0].portRange",
+		),
+		Entry("assert allowed roles must exist",
+			definednet.CreateRoleRequest{Name: "test: Other role", FirewallRules: []definednet.FirewallRule{
+				{Protocol: "TCP", AllowedRoleID: "role-MISSING"},
+			}},
+			"firewallRules[0].allowedRoleID",
+		),
+		Entry("assert allowed tags must be in the key:value format",
+			definednet.CreateRoleRequest{Name: "test: Other role", FirewallRules: []definednet.FirewallRule{
+				{Protocol: "TCP", AllowedTags: []string{"tag"}},
+			}},
+			"firewallRules[0].allowedTags[0]",
+		),
+	)
+
+	Specify("missing objects are not found", func(ctx SpecContext) {
+		_, err := definednet.GetHost(ctx, server.Client(), definednet.GetHostRequest{ID: "host-MISSING"})
+		Expect(apiError(err, http.StatusNotFound).Errors).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+			"Code": Equal("ERR_NOT_FOUND"),
+		})))
+
+		err = definednet.DeleteRole(ctx, server.Client(), definednet.DeleteRoleRequest{ID: "role-MISSING"})
+		apiError(err, http.StatusNotFound)
+	})
+
+	Specify("roles assigned to hosts can not be deleted", func(ctx SpecContext) {
+		Expect(server.Hosts.Add(fakeserver.Host{Host: definednet.Host{
+			ID:        "host-id",
+			NetworkID: "network-id",
+			RoleID:    "role-id",
+			Name:      "host.defined.test",
+		}})).To(Succeed())
+
+		err := definednet.DeleteRole(ctx, server.Client(), definednet.DeleteRoleRequest{ID: "role-id"})
+		Expect(apiError(err, http.StatusConflict).Errors).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+			"Code": Equal("ERR_CONFLICT"),
+		})))
+	})
+
+	Specify("invalid page sizes are refused", func(ctx SpecContext) {
+		_, err := definednet.ListRoles(ctx, server.Client(), definednet.ListRolesRequest{PageSize: 1000})
+		apiError(err, http.StatusBadRequest)
+	})
+
+	Specify("malformed requests are refused with an error envelope", func(ctx SpecContext) {
+		req := lo.Must(http.NewRequestWithContext(ctx, http.MethodPost, server.URL()+"/v1/roles", strings.NewReader("{")))
+		req.Header.Set("Authorization", "Bearer "+server.Token)
+
+		resp := lo.Must(http.DefaultClient.Do(req))
+		defer resp.Body.Close()
+
+		Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+		Expect(resp.Header.Get("Content-Type")).To(HavePrefix("application/json"))
+
+		var body struct {
+			Errors []definednet.ErrorDetail `json:"errors"`
+		}
+
+		Expect(json.NewDecoder(resp.Body).Decode(&body)).To(Succeed())
+		Expect(body.Errors).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+			"Code": Equal("ERR_INVALID_VALUE"),
+		})))
+	})
+
+	Specify("requests must be authorized with the API token", func(ctx SpecContext) {
+		client := lo.Must(definednet.NewClient(server.URL(), "invalid", "test"))
+
+		_, err := definednet.ListRoles(ctx, client, definednet.ListRolesRequest{})
+		Expect(apiError(err, http.StatusUnauthorized).Errors).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+			"Code": Equal("ERR_UNAUTHORIZED"),
+		})))
+	})
+})
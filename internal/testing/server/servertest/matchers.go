@@ -0,0 +1,75 @@
+package servertest
+
+import (
+	"encoding/json"
+
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/server"
+)
+
+// ExpectRequest asserts the fake API has received a request satisfying all the matchers, e.g.:
+//
+//	servertest.ExpectRequest(server, servertest.HaveMethod(http.MethodPut), servertest.HavePath("/v2/hosts/host-id"))
+func ExpectRequest(s *server.Server, matchers ...types.GomegaMatcher) {
+	gomega.ExpectWithOffset(1, s).To(HaveReceivedRequest(matchers...))
+}
+
+// HaveReceivedRequest succeeds, when the fake API has received a request satisfying all the matchers.
+func HaveReceivedRequest(matchers ...types.GomegaMatcher) types.GomegaMatcher {
+	return gomega.WithTransform(func(s *server.Server) []server.Request {
+		return s.Requests()
+	}, gomega.ContainElement(gomega.SatisfyAll(matchers...)))
+}
+
+// HaveMethod succeeds, when the recorded request's method matches.
+func HaveMethod(method any) types.GomegaMatcher {
+	return gomega.WithTransform(func(r server.Request) string {
+		return r.Method
+	}, matcherOrEqual(method))
+}
+
+// HavePath succeeds, when the recorded request's URL path matches.
+func HavePath(path any) types.GomegaMatcher {
+	return gomega.WithTransform(func(r server.Request) string {
+		return r.Path
+	}, matcherOrEqual(path))
+}
+
+// HaveQuery succeeds, when the recorded request's URL query parameter matches.
+func HaveQuery(key string, value any) types.GomegaMatcher {
+	return gomega.WithTransform(func(r server.Request) string {
+		return r.Query.Get(key)
+	}, matcherOrEqual(value))
+}
+
+// HaveHeader succeeds, when the recorded request's header matches.
+func HaveHeader(key string, value any) types.GomegaMatcher {
+	return gomega.WithTransform(func(r server.Request) string {
+		return r.Header.Get(key)
+	}, matcherOrEqual(value))
+}
+
+// HaveBody succeeds, when the recorded request's decoded body matches.
+//
+// Strings are matched as JSON documents, e.g. HaveBody(`{"name": "host.defined.test"}`).
+func HaveBody(body any) types.GomegaMatcher {
+	if s, ok := body.(string); ok {
+		return gomega.WithTransform(func(r server.Request) ([]byte, error) {
+			return json.Marshal(r.Body)
+		}, gomega.MatchJSON(s))
+	}
+
+	return gomega.WithTransform(func(r server.Request) any {
+		return r.Body
+	}, matcherOrEqual(body))
+}
+
+// matcherOrEqual returns the matcher, or a matcher for equality with the value.
+func matcherOrEqual(value any) types.GomegaMatcher {
+	if m, ok := value.(types.GomegaMatcher); ok {
+		return m
+	}
+
+	return gomega.Equal(value)
+}
@@ -0,0 +1,22 @@
+// Package servertest provides Ginkgo and Gomega helpers for testing against the fake Defined.net HTTP API.
+//
+// The helpers are kept apart from the fake API, so the standalone fake API server does not link the test
+// frameworks.
+package servertest
+
+import (
+	"log"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/testing/server"
+)
+
+// New starts a fake Defined.net HTTP API server for Ginkgo tests.
+//
+// Requests are logged to the Ginkgo writer.
+func New() *server.Server {
+	srv := server.NewServer(log.New(ginkgo.GinkgoWriter, "", log.LstdFlags))
+	srv.Token = "supersecret"
+
+	return srv
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/samber/lo"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/definednet"
+)
+
+// tagPattern matches Defined.net tags, which are in the format key:value.
+var tagPattern = regexp.MustCompile(`^[^:\s]+:\S+$`)
+
+// validator collects request validation errors.
+type validator []definednet.ErrorDetail
+
+// check records a validation error on the path, unless the condition holds.
+func (v *validator) check(ok bool, path, format string, args ...any) {
+	if ok {
+		return
+	}
+
+	*v = append(*v, definednet.ErrorDetail{
+		Code:    errCodeInvalidValue,
+		Message: fmt.Sprintf(format, args...),
+		Path:    path,
+	})
+}
+
+// err returns the collected validation errors, if any.
+func (v validator) err() error {
+	if len(v) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Errors: v}
+}
+
+// validateHost validates host fields shared by host creation and update requests.
+func (s *Handler) validateHost(v *validator, host definednet.Host) {
+	v.check(host.Name != "", "name", "name is required")
+	v.check(len(host.Name) <= 255, "name", "name must be at most 255 characters long")
+	v.check(host.ListenPort >= 0 && host.ListenPort <= 65535, "listenPort", "listen port must be between 0 and 65535")
+	v.check(!host.IsLighthouse || host.ListenPort > 0, "listenPort", "lighthouses must have a listen port")
+
+	if host.RoleID != "" {
+		_, err := s.Roles.Get(host.RoleID)
+		v.check(err == nil, "roleID", "role %q does not exist", host.RoleID)
+	}
+
+	for idx, tag := range host.Tags {
+		v.check(tagPattern.MatchString(tag), fmt.Sprintf("tags[%d]", idx), "tag %q must be in the format key:value", tag)
+	}
+}
+
+// validateRole validates role creation and update requests.
+func (s *Handler) validateRole(role Role) error {
+	var v validator
+
+	v.check(role.Name != "", "name", "name is required")
+	v.check(len(role.Name) <= 50, "name", "name must be at most 50 characters long")
+	v.check(len(role.Description) <= 255, "description", "description must be at most 255 characters long")
+
+	for idx, rule := range role.FirewallRules {
+		path := fmt.Sprintf("firewallRules[%d]", idx)
+
+		v.check(lo.Contains([]string{"ANY", "TCP", "UDP", "ICMP"}, rule.Protocol), path+".protocol",
+			"protocol %q must be one of ANY, TCP, UDP or ICMP", rule.Protocol)
+		v.check(len(rule.Description) <= 255, path+".description", "description must be at most 255 characters long")
+
+		if rule.PortRange != nil {
+			v.check(
+				rule.PortRange.From >= 1 && rule.PortRange.To <= 65535 && rule.PortRange.From <= rule.PortRange.To,
+				path+".portRange", "port range %d-%d must be within 1-65535", rule.PortRange.From, rule.PortRange.To,
+			)
+		}
+
+		// Rules may allow their own role, which does not exist before it is created.
+		if rule.AllowedRoleID != "" && rule.AllowedRoleID != role.ID {
+			_, err := s.Roles.Get(rule.AllowedRoleID)
+			v.check(err == nil, path+".allowedRoleID", "role %q does not exist", rule.AllowedRoleID)
+		}
+
+		for tagIdx, tag := range rule.AllowedTags {
+			v.check(tagPattern.MatchString(tag), fmt.Sprintf("%s.allowedTags[%d]", path, tagIdx),
+				"tag %q must be in the format key:value", tag)
+		}
+	}
+
+	return v.err()
+}
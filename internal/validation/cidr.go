@@ -0,0 +1,43 @@
+package validation
+
+import (
+	"context"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// CIDR validates the value is a network address in CIDR notation.
+//
+// Null (unconfigured) and unknown (known after apply) values are skipped.
+func CIDR() validator.String {
+	return cidrValidator{}
+}
+
+type cidrValidator struct{}
+
+func (v cidrValidator) Description(_ context.Context) string {
+	return "value must be a network address in CIDR notation, e.g. 10.0.0.0/8"
+}
+
+func (v cidrValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v cidrValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+
+	// Host bits must not be set, so the configured value matches what Defined.net stores.
+	if prefix, err := netip.ParsePrefix(value); err != nil || prefix.Masked() != prefix {
+		resp.Diagnostics.Append(validatordiag.InvalidAttributeValueMatchDiagnostic(
+			req.Path,
+			v.Description(ctx),
+			value,
+		))
+	}
+}
@@ -0,0 +1,71 @@
+package validation_test
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/validation"
+)
+
+var _ = Describe("validating CIDRs", func() {
+	DescribeTable("valid values pass validation",
+		func(ctx SpecContext, cidr string) {
+			res := new(validator.StringResponse)
+			validation.CIDR().ValidateString(ctx, validator.StringRequest{
+				Path:        path.Empty().AtName("test"),
+				ConfigValue: basetypes.NewStringValue(cidr),
+			}, res)
+
+			Expect(res.Diagnostics.HasError()).To(BeFalse(), GetDiagnosticsMessage(res.Diagnostics))
+		},
+		Entry("IPv4 network", "10.128.0.0/16"),
+		Entry("IPv4 host", "10.128.0.1/32"),
+		Entry("IPv4 any", "0.0.0.0/0"),
+		Entry("IPv6 network", "fd00:beef::/64"),
+	)
+
+	DescribeTable("invalid values fail validation",
+		func(ctx SpecContext, cidr string) {
+			res := new(validator.StringResponse)
+			validation.CIDR().ValidateString(ctx, validator.StringRequest{
+				Path:        path.Empty().AtName("test"),
+				ConfigValue: basetypes.NewStringValue(cidr),
+			}, res)
+
+			Expect(res.Diagnostics).To(ContainElement(diag.NewAttributeErrorDiagnostic(
+				path.Empty().AtName("test"),
+				"Invalid Attribute Value Match",
+				fmt.Sprintf("Attribute test value must be a network address in CIDR notation, e.g. 10.0.0.0/8, got: %s", cidr),
+			)))
+		},
+		Entry("missing prefix length", "10.128.0.0"),
+		Entry("invalid address", "10.128.0.256/24"),
+		Entry("invalid prefix length", "10.128.0.0/33"),
+		Entry("host bits set", "10.128.0.1/16"),
+	)
+
+	Specify("null values pass validation", func(ctx SpecContext) {
+		res := new(validator.StringResponse)
+		validation.CIDR().ValidateString(ctx, validator.StringRequest{
+			Path:        path.Empty().AtName("test"),
+			ConfigValue: basetypes.NewStringNull(),
+		}, res)
+
+		Expect(res.Diagnostics.HasError()).To(BeFalse(), GetDiagnosticsMessage(res.Diagnostics))
+	})
+
+	Specify("unknown values pass validation", func(ctx SpecContext) {
+		res := new(validator.StringResponse)
+		validation.CIDR().ValidateString(ctx, validator.StringRequest{
+			Path:        path.Empty().AtName("test"),
+			ConfigValue: basetypes.NewStringUnknown(),
+		}, res)
+
+		Expect(res.Diagnostics.HasError()).To(BeFalse(), GetDiagnosticsMessage(res.Diagnostics))
+	})
+})
@@ -0,0 +1,49 @@
+package validation
+
+import (
+	"context"
+	"net"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var dnsNamePattern = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)*[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.?$`)
+
+// HostAddress validates the value is an IP address or a DNS name.
+//
+// Null (unconfigured) and unknown (known after apply) values are skipped.
+func HostAddress() validator.String {
+	return hostAddressValidator{}
+}
+
+type hostAddressValidator struct{}
+
+func (v hostAddressValidator) Description(_ context.Context) string {
+	return "value must be an IP address or a DNS name"
+}
+
+func (v hostAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v hostAddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+
+	if net.ParseIP(value) != nil {
+		return
+	}
+
+	if len(value) > 253 || !dnsNamePattern.MatchString(value) {
+		resp.Diagnostics.Append(validatordiag.InvalidAttributeValueMatchDiagnostic(
+			req.Path,
+			v.Description(ctx),
+			value,
+		))
+	}
+}
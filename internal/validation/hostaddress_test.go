@@ -0,0 +1,78 @@
+package validation_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/validation"
+)
+
+var _ = Describe("validating host addresses", func() {
+	DescribeTable("valid values pass validation",
+		func(ctx SpecContext, addr string) {
+			res := new(validator.StringResponse)
+			validation.HostAddress().ValidateString(ctx, validator.StringRequest{
+				Path:        path.Empty().AtName("test"),
+				ConfigValue: basetypes.NewStringValue(addr),
+			}, res)
+
+			Expect(res.Diagnostics.HasError()).To(BeFalse(), GetDiagnosticsMessage(res.Diagnostics))
+		},
+		Entry("IPv4 address", "172.16.0.1"),
+		Entry("IPv6 address", "fd:beef::1"),
+		Entry("DNS name", "lighthouse.defined.test"),
+		Entry("fully qualified DNS name", "lighthouse.defined.test."),
+		Entry("single label", "lighthouse"),
+		Entry("labels with hyphens and digits", "lighthouse-1.eu-west-1.defined.test"),
+	)
+
+	DescribeTable("invalid values fail validation",
+		func(ctx SpecContext, addr string) {
+			res := new(validator.StringResponse)
+			validation.HostAddress().ValidateString(ctx, validator.StringRequest{
+				Path:        path.Empty().AtName("test"),
+				ConfigValue: basetypes.NewStringValue(addr),
+			}, res)
+
+			Expect(res.Diagnostics).To(ContainElement(diag.NewAttributeErrorDiagnostic(
+				path.Empty().AtName("test"),
+				"Invalid Attribute Value Match",
+				fmt.Sprintf("Attribute test value must be an IP address or a DNS name, got: %s", addr),
+			)))
+		},
+		Entry("empty value", ""),
+		Entry("host-port", "172.16.0.1:4242"),
+		Entry("URL", "https://lighthouse.defined.test"),
+		Entry("leading hyphen", "-lighthouse.defined.test"),
+		Entry("trailing hyphen", "lighthouse-.defined.test"),
+		Entry("empty label", "lighthouse..defined.test"),
+		Entry("too long label", strings.Repeat("a", 64)+".defined.test"),
+		Entry("too long name", strings.Repeat("a.", 127)+"test"),
+	)
+
+	Specify("null values pass validation", func(ctx SpecContext) {
+		res := new(validator.StringResponse)
+		validation.HostAddress().ValidateString(ctx, validator.StringRequest{
+			Path:        path.Empty().AtName("test"),
+			ConfigValue: basetypes.NewStringNull(),
+		}, res)
+
+		Expect(res.Diagnostics.HasError()).To(BeFalse(), GetDiagnosticsMessage(res.Diagnostics))
+	})
+
+	Specify("unknown values pass validation", func(ctx SpecContext) {
+		res := new(validator.StringResponse)
+		validation.HostAddress().ValidateString(ctx, validator.StringRequest{
+			Path:        path.Empty().AtName("test"),
+			ConfigValue: basetypes.NewStringUnknown(),
+		}, res)
+
+		Expect(res.Diagnostics.HasError()).To(BeFalse(), GetDiagnosticsMessage(res.Diagnostics))
+	})
+})
@@ -0,0 +1,81 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var portsPattern = regexp.MustCompile(`^[1-9]\d*(-[1-9]\d*)?$`)
+
+// Ports validates the value is either a single port or a port range in the format FROM-TO.
+//
+// Null (unconfigured) and unknown (known after apply) values are skipped.
+func Ports(start, end int32) validator.String {
+	return portsValidator{
+		start: start,
+		end:   end,
+	}
+}
+
+type portsValidator struct {
+	start, end int32
+}
+
+func (v portsValidator) Description(context.Context) string {
+	return fmt.Sprintf("value must be a port or a port range in the format FROM-TO, with ports between %d and %d", v.start, v.end)
+}
+
+func (v portsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v portsValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if !portsPattern.MatchString(value) {
+		resp.Diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(
+			req.Path,
+			v.Description(ctx),
+			value,
+		))
+
+		return
+	}
+
+	fromStr, toStr, isRange := strings.Cut(value, "-")
+	if !isRange {
+		toStr = fromStr
+	}
+
+	from, fromErr := strconv.ParseInt(fromStr, 10, 32)
+	to, toErr := strconv.ParseInt(toStr, 10, 32)
+
+	if fromErr != nil || toErr != nil ||
+		int32(from) < v.start || int32(from) > v.end ||
+		int32(to) < v.start || int32(to) > v.end {
+		resp.Diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(
+			req.Path,
+			v.Description(ctx),
+			value,
+		))
+
+		return
+	}
+
+	if isRange && to <= from {
+		resp.Diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(
+			req.Path,
+			`"to" port must be greater than "from" port`,
+			value,
+		))
+	}
+}
@@ -0,0 +1,93 @@
+package validation_test
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/validation"
+)
+
+var _ = Describe("validating ports", func() {
+	DescribeTable("valid values pass validation",
+		func(ctx SpecContext, value string) {
+			res := new(validator.StringResponse)
+			validation.Ports(1, 65535).ValidateString(ctx, validator.StringRequest{
+				Path:        path.Empty().AtName("test"),
+				ConfigValue: basetypes.NewStringValue(value),
+			}, res)
+
+			Expect(res.Diagnostics.HasError()).To(BeFalse(), GetDiagnosticsMessage(res.Diagnostics))
+		},
+		Entry("single port", "443"),
+		Entry("lowest port", "1"),
+		Entry("highest port", "65535"),
+		Entry("port range", "1024-2048"),
+	)
+
+	DescribeTable("invalid values fail validation",
+		func(ctx SpecContext, value, detail string) {
+			res := new(validator.StringResponse)
+			validation.Ports(2048, 4096).ValidateString(ctx, validator.StringRequest{
+				Path:        path.Empty().AtName("test"),
+				ConfigValue: basetypes.NewStringValue(value),
+			}, res)
+
+			Expect(res.Diagnostics).To(ContainElement(diag.NewAttributeErrorDiagnostic(
+				path.Empty().AtName("test"),
+				"Invalid Attribute Value",
+				detail,
+			)))
+		},
+		Entry("not a number",
+			"https",
+			"Attribute test value must be a port or a port range in the format FROM-TO, with ports between 2048 and 4096, got: https",
+		),
+		Entry("leading zeros",
+			"02048",
+			"Attribute test value must be a port or a port range in the format FROM-TO, with ports between 2048 and 4096, got: 02048",
+		),
+		Entry("open-ended range",
+			"2048-",
+			"Attribute test value must be a port or a port range in the format FROM-TO, with ports between 2048 and 4096, got: 2048-",
+		),
+		Entry("port undercuts the allowed range",
+			"1024",
+			"Attribute test value must be a port or a port range in the format FROM-TO, with ports between 2048 and 4096, got: 1024",
+		),
+		Entry("port exceeds the allowed range",
+			"8192",
+			"Attribute test value must be a port or a port range in the format FROM-TO, with ports between 2048 and 4096, got: 8192",
+		),
+		Entry("range exceeds the allowed range",
+			"2048-8192",
+			"Attribute test value must be a port or a port range in the format FROM-TO, with ports between 2048 and 4096, got: 2048-8192",
+		),
+		Entry("to port is not greater than from port",
+			"4096-2048",
+			`Attribute test "to" port must be greater than "from" port, got: 4096-2048`,
+		),
+	)
+
+	Specify("null values pass validation", func(ctx SpecContext) {
+		res := new(validator.StringResponse)
+		validation.Ports(1, 65535).ValidateString(ctx, validator.StringRequest{
+			Path:        path.Empty().AtName("test"),
+			ConfigValue: basetypes.NewStringNull(),
+		}, res)
+
+		Expect(res.Diagnostics.HasError()).To(BeFalse(), GetDiagnosticsMessage(res.Diagnostics))
+	})
+
+	Specify("unknown values pass validation", func(ctx SpecContext) {
+		res := new(validator.StringResponse)
+		validation.Ports(1, 65535).ValidateString(ctx, validator.StringRequest{
+			Path:        path.Empty().AtName("test"),
+			ConfigValue: basetypes.NewStringUnknown(),
+		}, res)
+
+		Expect(res.Diagnostics.HasError()).To(BeFalse(), GetDiagnosticsMessage(res.Diagnostics))
+	})
+})
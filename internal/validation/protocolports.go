@@ -0,0 +1,75 @@
+package validation
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ProtocolPorts validates the object's port attributes are applicable to its "protocol" attribute.
+//
+// Ports are rejected for ICMP, and a warning is raised for ANY, which covers ICMP traffic as well.
+func ProtocolPorts(attributes ...string) validator.Object {
+	return protocolPortsValidator{
+		attributes: attributes,
+	}
+}
+
+type protocolPortsValidator struct {
+	attributes []string
+}
+
+func (v protocolPortsValidator) Description(context.Context) string {
+	return "ports must not be set for ICMP traffic"
+}
+
+func (v protocolPortsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v protocolPortsValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	attrs := req.ConfigValue.Attributes()
+
+	protocol, ok := attrs["protocol"].(types.String)
+	if !ok {
+		resp.Diagnostics.Append(validatordiag.InvalidAttributeTypeDiagnostic(
+			req.Path.AtName("protocol"),
+			"must be string",
+			attrs["protocol"].Type(ctx).String(),
+		))
+
+		return
+	}
+
+	if protocol.IsNull() || protocol.IsUnknown() {
+		return
+	}
+
+	for _, name := range v.attributes {
+		if val, exists := attrs[name]; !exists || val.IsNull() {
+			continue
+		}
+
+		switch protocol.ValueString() {
+		case "ICMP":
+			resp.Diagnostics.Append(validatordiag.InvalidAttributeCombinationDiagnostic(
+				req.Path.AtName(name),
+				v.Description(ctx),
+			))
+
+		case "ANY":
+			resp.Diagnostics.AddAttributeWarning(
+				req.Path.AtName(name),
+				"Ambiguous Ports",
+				"Protocol ANY covers ICMP traffic as well, which has no ports. "+
+					"Consider declaring separate TCP and UDP rules instead.",
+			)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package validation_test
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sendsmaily/terraform-provider-definednet/internal/validation"
+)
+
+var _ = Describe("validating protocol ports", func() {
+	rule := func(protocol types.String, port types.Int32) basetypes.ObjectValue {
+		return basetypes.NewObjectValueMust(
+			map[string]attr.Type{
+				"protocol": types.StringType,
+				"port":     types.Int32Type,
+			},
+			map[string]attr.Value{
+				"protocol": protocol,
+				"port":     port,
+			},
+		)
+	}
+
+	DescribeTable("valid values pass validation",
+		func(ctx SpecContext, protocol types.String, port types.Int32) {
+			res := new(validator.ObjectResponse)
+			validation.ProtocolPorts("port").ValidateObject(ctx, validator.ObjectRequest{
+				Path:        path.Empty().AtName("test"),
+				ConfigValue: rule(protocol, port),
+			}, res)
+
+			Expect(res.Diagnostics).To(BeEmpty(), GetDiagnosticsMessage(res.Diagnostics))
+		},
+		Entry("TCP with ports", types.StringValue("TCP"), types.Int32Value(443)),
+		Entry("UDP with ports", types.StringValue("UDP"), types.Int32Value(53)),
+		Entry("TCP without ports", types.StringValue("TCP"), types.Int32Null()),
+		Entry("ICMP without ports", types.StringValue("ICMP"), types.Int32Null()),
+		Entry("ANY without ports", types.StringValue("ANY"), types.Int32Null()),
+		Entry("unknown protocol", types.StringUnknown(), types.Int32Value(443)),
+	)
+
+	Specify("ICMP with ports fails validation", func(ctx SpecContext) {
+		res := new(validator.ObjectResponse)
+		validation.ProtocolPorts("port").ValidateObject(ctx, validator.ObjectRequest{
+			Path:        path.Empty().AtName("test"),
+			ConfigValue: rule(types.StringValue("ICMP"), types.Int32Value(443)),
+		}, res)
+
+		Expect(res.Diagnostics).To(ContainElement(diag.NewAttributeErrorDiagnostic(
+			path.Empty().AtName("test").AtName("port"),
+			"Invalid Attribute Combination",
+			"Ports must not be set for ICMP traffic",
+		)))
+	})
+
+	Specify("ANY with ports raises a warning", func(ctx SpecContext) {
+		res := new(validator.ObjectResponse)
+		validation.ProtocolPorts("port").ValidateObject(ctx, validator.ObjectRequest{
+			Path:        path.Empty().AtName("test"),
+			ConfigValue: rule(types.StringValue("ANY"), types.Int32Value(443)),
+		}, res)
+
+		Expect(res.Diagnostics.HasError()).To(BeFalse(), GetDiagnosticsMessage(res.Diagnostics))
+		Expect(res.Diagnostics.Warnings()).To(ContainElement(HaveField("Summary()", "Ambiguous Ports")))
+	})
+
+	Specify("null values pass validation", func(ctx SpecContext) {
+		res := new(validator.ObjectResponse)
+		validation.ProtocolPorts("port").ValidateObject(ctx, validator.ObjectRequest{
+			Path:        path.Empty().AtName("test"),
+			ConfigValue: basetypes.NewObjectNull(nil),
+		}, res)
+
+		Expect(res.Diagnostics.HasError()).To(BeFalse(), GetDiagnosticsMessage(res.Diagnostics))
+	})
+
+	Specify("unknown values pass validation", func(ctx SpecContext) {
+		res := new(validator.ObjectResponse)
+		validation.ProtocolPorts("port").ValidateObject(ctx, validator.ObjectRequest{
+			Path:        path.Empty().AtName("test"),
+			ConfigValue: basetypes.NewObjectUnknown(nil),
+		}, res)
+
+		Expect(res.Diagnostics.HasError()).To(BeFalse(), GetDiagnosticsMessage(res.Diagnostics))
+	})
+})
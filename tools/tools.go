@@ -9,6 +9,9 @@ import (
 	_ "github.com/hashicorp/terraform-plugin-docs/cmd/tfplugindocs"
 )
 
+// Generate the Defined.net HTTP API client's models and endpoint functions.
+//go:generate go -C .. run ./cmd/definednetgen -out internal/definednet/api_gen.go
+
 // Format Terraform code for use in documentation.
 //go:generate terraform fmt -recursive ../examples/
 